@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	aplFromJson bool
+	aplWrite    bool
+)
+
+var aplCmd = &cobra.Command{
+	Use:   "apl",
+	Short: "convert APL rotations between the text and protojson formats",
+	Long:  "convert APL rotations between the text and protojson formats",
+}
+
+var aplFmtCmd = &cobra.Command{
+	Use:   "fmt [file]",
+	Short: "print an APL rotation in canonical text format",
+	Long:  "print an APL rotation in canonical text format. Reads from stdin if no file is given.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rotation, err := readAPLRotation(args, aplFromJson)
+		if err != nil {
+			return err
+		}
+		text, err := core.APLRotationToTextString(rotation)
+		if err != nil {
+			return err
+		}
+
+		if aplWrite {
+			if len(args) == 0 || aplFromJson {
+				return fmt.Errorf("-w requires a text input file")
+			}
+			return os.WriteFile(args[0], []byte(text), 0666)
+		}
+		fmt.Print(text)
+		return nil
+	},
+}
+
+var aplParseCmd = &cobra.Command{
+	Use:   "parse [file]",
+	Short: "convert an APL rotation from text format to protojson",
+	Long:  "convert an APL rotation from text format to protojson. Reads from stdin if no file is given.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rotation, err := readAPLRotation(args, false)
+		if err != nil {
+			return err
+		}
+		fmt.Println(protojson.Format(rotation))
+		return nil
+	},
+}
+
+func init() {
+	aplFmtCmd.Flags().BoolVar(&aplFromJson, "json", false, "input is an APLRotation in protojson format")
+	aplFmtCmd.Flags().BoolVarP(&aplWrite, "write", "w", false, "write the result back to the input file instead of stdout")
+	aplCmd.AddCommand(aplFmtCmd)
+	aplCmd.AddCommand(aplParseCmd)
+}
+
+func readAPLRotation(args []string, fromJson bool) (*proto.APLRotation, error) {
+	var data []byte
+	var err error
+	if len(args) == 0 || args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	if fromJson {
+		rotation := &proto.APLRotation{}
+		if err := protojson.Unmarshal(data, rotation); err != nil {
+			return nil, fmt.Errorf("failed to parse protojson: %w", err)
+		}
+		return rotation, nil
+	}
+	return core.APLRotationFromTextString(string(data))
+}
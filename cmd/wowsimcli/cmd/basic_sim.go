@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
 
@@ -11,6 +14,11 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+var concurrency int32
+var eventsFile string
+var eventIterations []int32
+var replaySeed int64
+
 var simCmd = &cobra.Command{
 	Use:   "sim",
 	Short: "simulate items & settings",
@@ -19,12 +27,22 @@ var simCmd = &cobra.Command{
 
 func init() {
 	simCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
-	simCmd.Flags().StringVar(&infile, "output", "", "location of output file, defaults to stdout")
+	simCmd.Flags().StringVar(&outfile, "output", "", "location of output file, defaults to stdout")
 	simCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	simCmd.Flags().Int32Var(&concurrency, "concurrency", 0, "number of goroutines to split iterations across, -1 for one per CPU (overrides simOptions.concurrency)")
+	simCmd.Flags().StringVar(&eventsFile, "events", "", "location of a JSONL file to write structured combat events to, one SimEvent per line")
+	simCmd.Flags().Int32SliceVar(&eventIterations, "event-iterations", []int32{0}, "iterations to record events for when --events is set (overrides simOptions.eventIterations)")
+	simCmd.Flags().Int64Var(&replaySeed, "replay-seed", 0, "run only the iteration with this seed (e.g. a maxSeed or minSeed from a previous run), with debug logs and events")
+	addFormatFlag(simCmd, formatJSON)
 	simCmd.MarkFlagRequired("infile")
 }
 
 func simMain(cmd *cobra.Command, args []string) {
+	format, err := getFormat(cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	data, err := os.ReadFile(infile)
 	if err != nil {
 		log.Fatalf("failed to load input json file %q: %v", infile, err)
@@ -36,9 +54,29 @@ func simMain(cmd *cobra.Command, args []string) {
 		log.Fatalf("failed to load input json file: %s", err)
 	}
 
-	var output []byte
+	if cmd.Flags().Changed("concurrency") {
+		if input.SimOptions == nil {
+			input.SimOptions = &proto.SimOptions{}
+		}
+		input.SimOptions.Concurrency = concurrency
+	}
+	if replaySeed != 0 {
+		if input.SimOptions == nil {
+			input.SimOptions = &proto.SimOptions{}
+		}
+		input.SimOptions.ReplaySeed = replaySeed
+	}
+	if eventsFile != "" {
+		if input.SimOptions == nil {
+			input.SimOptions = &proto.SimOptions{}
+		}
+		if cmd.Flags().Changed("event-iterations") || len(input.SimOptions.EventIterations) == 0 {
+			input.SimOptions.EventIterations = eventIterations
+		}
+	}
+
 	reporter := make(chan *proto.ProgressMetrics, 10)
-	core.RunRaidSimAsync(input, reporter)
+	core.RunRaidSimAsync(context.Background(), input, reporter)
 
 	var finalResult *proto.RaidSimResult
 	for v := range reporter {
@@ -51,20 +89,106 @@ func simMain(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	output, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(finalResult)
+	if eventsFile != "" {
+		writeEvents(eventsFile, finalResult.Events)
+		if verbose {
+			fmt.Printf("Wrote %d events to `%s` successfully.\n", len(finalResult.Events), eventsFile)
+		}
+		// Events can be very large, so only write them to the events file.
+		finalResult.Events = nil
+	}
+
+	if format != formatJSON && finalResult.ErrorResult != "" {
+		log.Fatalf("sim failed: %s", finalResult.ErrorResult)
+	}
+	err = writeOutput(func(w io.Writer) error {
+		return writeResult(w, format, finalResult, func() []*resultTable {
+			return []*resultTable{simResultTable(finalResult), distributionTable(finalResult)}
+		})
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Summarizes the metrics of each player, pet and target.
+func simResultTable(result *proto.RaidSimResult) *resultTable {
+	table := &resultTable{
+		title:  "Units",
+		header: []string{"Name", "DPS", "DPS Stdev", "HPS", "TPS", "DTPS"},
+	}
+	addUnit := func(name string, metrics *proto.UnitMetrics) {
+		table.addRow(name, metrics.Dps.GetAvg(), metrics.Dps.GetStdev(), metrics.Hps.GetAvg(), metrics.Threat.GetAvg(), metrics.Dtps.GetAvg())
+	}
+	for _, party := range result.RaidMetrics.GetParties() {
+		for _, player := range party.Players {
+			if player.Name == "" {
+				continue
+			}
+			addUnit(player.Name, player)
+			for _, pet := range player.Pets {
+				addUnit(player.Name+" - "+pet.Name, pet)
+			}
+		}
+	}
+	for _, target := range result.EncounterMetrics.GetTargets() {
+		addUnit(target.Name, target)
+	}
+	table.addRow("Raid", result.RaidMetrics.GetDps().GetAvg(), result.RaidMetrics.GetDps().GetStdev(), result.RaidMetrics.GetHps().GetAvg(), 0.0, 0.0)
+	return table
+}
+
+// Writes each event as a single line of protojson.
+func writeEvents(path string, events []*proto.SimEvent) {
+	file, err := os.Create(path)
 	if err != nil {
-		log.Fatalf("failed to marshal final results: %s", err)
+		log.Fatalf("failed to create events file: %s", err)
 	}
+	defer file.Close()
 
-	if outfile == "" {
-		fmt.Print(string(output))
-	} else {
-		err = os.WriteFile(outfile, output, 0666)
+	writer := bufio.NewWriter(file)
+	for _, event := range events {
+		line, err := protojson.Marshal(event)
 		if err != nil {
-			log.Fatalf("failed to write output file:: %s", err)
+			log.Fatalf("failed to marshal event: %s", err)
 		}
-		if verbose {
-			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+		writer.Write(line)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		log.Fatalf("failed to write events file: %s", err)
+	}
+}
+
+// Lists the mean, standard error, 95% confidence interval and percentiles of
+// each player's metrics.
+func distributionTable(result *proto.RaidSimResult) *resultTable {
+	table := &resultTable{
+		title:  "Distributions",
+		header: []string{"Name", "Metric", "Mean", "Stderr", "95% CI Low", "95% CI High", "P5", "P25", "P50", "P75", "P95"},
+	}
+	for _, party := range result.RaidMetrics.GetParties() {
+		for _, player := range party.Players {
+			if player.Name == "" {
+				continue
+			}
+			for _, metric := range []struct {
+				name string
+				dist *proto.DistributionMetrics
+			}{
+				{"DPS", player.Dps},
+				{"HPS", player.Hps},
+				{"TPS", player.Threat},
+				{"DTPS", player.Dtps},
+				{"TMI", player.Tmi},
+			} {
+				d := metric.dist
+				if d == nil || d.Avg == 0 {
+					continue
+				}
+				table.addRow(player.Name, metric.name, d.Avg, d.Stderr, d.Ci95Low, d.Ci95High, d.P5, d.P25, d.P50, d.P75, d.P95)
+			}
 		}
 	}
+	return table
 }
@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	batchDir        string
+	batchIterations int32
+	batchJobs       int
+	batchBaseline   string
+	batchThreshold  float64
+)
+
+// Suffix of the per-file results written next to each input file.
+const batchResultSuffix = ".result.json"
+
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "simulate every request in a directory",
+	Long: "simulate every RaidSimRequest (*.json) in a directory, writing each result next to its input as " + batchResultSuffix +
+		", and print a summary sorted by DPS. Use --format json to write a summary which can later be used as --baseline.",
+	RunE: batchMain,
+}
+
+func init() {
+	batchCmd.Flags().StringVar(&batchDir, "dir", "", "directory of input files (RaidSimRequest in protojson format)")
+	batchCmd.Flags().Int32Var(&batchIterations, "iterations", 0, "number of iterations for every file, defaults to the iterations of each file")
+	batchCmd.Flags().IntVar(&batchJobs, "jobs", runtime.NumCPU(), "number of files to simulate at once")
+	batchCmd.Flags().StringVar(&batchBaseline, "baseline", "", "location of a previous json summary to check for regressions")
+	batchCmd.Flags().Float64Var(&batchThreshold, "threshold", 2, "percentage by which DPS, HPS or TPS must drop below the baseline to count as a regression")
+	batchCmd.Flags().StringVar(&outfile, "output", "", "location of output file, defaults to stdout")
+	batchCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	addFormatFlag(batchCmd, formatTable)
+	batchCmd.MarkFlagRequired("dir")
+}
+
+// Summary of a single player in a single file. The json summary is a list of
+// these, and is read back for --baseline.
+type batchEntry struct {
+	File   string `json:"file"`
+	Player string `json:"player,omitempty"`
+	Error  string `json:"error,omitempty"`
+
+	Dps batchMetric `json:"dps"`
+	Hps batchMetric `json:"hps"`
+	Tps batchMetric `json:"tps"`
+}
+
+type batchMetric struct {
+	Avg      float64 `json:"avg"`
+	Ci95Low  float64 `json:"ci95Low"`
+	Ci95High float64 `json:"ci95High"`
+}
+
+func newBatchMetric(metrics *proto.DistributionMetrics) batchMetric {
+	return batchMetric{
+		Avg:      metrics.GetAvg(),
+		Ci95Low:  metrics.GetCi95Low(),
+		Ci95High: metrics.GetCi95High(),
+	}
+}
+
+func (entry *batchEntry) key() string {
+	return entry.File + "/" + entry.Player
+}
+
+func batchMain(cmd *cobra.Command, args []string) error {
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	var baseline []batchEntry
+	if batchBaseline != "" {
+		data, err := os.ReadFile(batchBaseline)
+		if err != nil {
+			return fmt.Errorf("failed to load baseline file %q: %w", batchBaseline, err)
+		}
+		if err := json.Unmarshal(data, &baseline); err != nil {
+			return fmt.Errorf("failed to parse baseline file: %w", err)
+		}
+	}
+
+	files, err := batchInputFiles(batchDir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no input files in %q", batchDir)
+	}
+
+	// Failures past this point are results rather than usage errors.
+	cmd.SilenceUsage = true
+
+	// Files are spread across the cores, and when there are fewer files than
+	// cores each sim also splits its iterations across the remaining ones.
+	jobs := core.MinInt(core.MaxInt(batchJobs, 1), len(files))
+	simConcurrency := int32(core.MaxInt(runtime.NumCPU()/jobs, 1))
+
+	fileEntries := make([][]batchEntry, len(files))
+	fileIndices := make(chan int)
+	var waitGroup sync.WaitGroup
+	for j := 0; j < jobs; j++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for i := range fileIndices {
+				fileEntries[i] = runBatchFile(files[i], simConcurrency)
+				if verbose {
+					fmt.Fprintf(os.Stderr, "Finished %s\n", files[i])
+				}
+			}
+		}()
+	}
+	for i := range files {
+		fileIndices <- i
+	}
+	close(fileIndices)
+	waitGroup.Wait()
+
+	var entries []batchEntry
+	for _, fileEntry := range fileEntries {
+		entries = append(entries, fileEntry...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Dps.Avg != entries[j].Dps.Avg {
+			return entries[i].Dps.Avg > entries[j].Dps.Avg
+		}
+		return entries[i].key() < entries[j].key()
+	})
+
+	regressions := findBatchRegressions(entries, baseline, batchThreshold/100)
+
+	err = writeOutput(func(w io.Writer) error {
+		if format == formatJSON {
+			data, err := json.MarshalIndent(entries, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(w, string(data))
+			return err
+		}
+		return writeTables(w, format, batchTables(entries, regressions))
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(regressions) > 0 {
+		if format == formatJSON {
+			for _, regression := range regressions {
+				fmt.Fprintf(os.Stderr, "Regression: %s %s %.2f -> %.2f\n", regression.entry, regression.metric, regression.baseline, regression.current)
+			}
+		}
+		return fmt.Errorf("%d regression(s) beyond %.2f%% compared to %s", len(regressions), batchThreshold, batchBaseline)
+	}
+	return nil
+}
+
+// Returns the input files in dir, skipping results from previous runs and
+// the files used for the summary.
+func batchInputFiles(dir string) ([]string, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %q: %w", dir, err)
+	}
+
+	skip := make(map[string]bool)
+	for _, path := range []string{batchBaseline, outfile} {
+		if abs, err := filepath.Abs(path); path != "" && err == nil {
+			skip[abs] = true
+		}
+	}
+
+	var files []string
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if dirEntry.IsDir() || !strings.HasSuffix(name, ".json") || strings.HasSuffix(name, batchResultSuffix) {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if abs, err := filepath.Abs(path); err == nil && skip[abs] {
+			continue
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+// Simulates a single file and writes its result next to it. Failures are
+// reported as an entry with an error instead, so that other files still run.
+func runBatchFile(path string, concurrency int32) []batchEntry {
+	name := filepath.Base(path)
+	failed := func(err error) []batchEntry {
+		return []batchEntry{{File: name, Error: err.Error()}}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return failed(err)
+	}
+	input := &proto.RaidSimRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, input); err != nil {
+		return failed(err)
+	}
+	if input.SimOptions == nil {
+		input.SimOptions = &proto.SimOptions{}
+	}
+	if batchIterations > 0 {
+		input.SimOptions.Iterations = batchIterations
+	}
+	input.SimOptions.Concurrency = concurrency
+
+	result := core.RunRaidSim(input)
+	output, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(result)
+	if err != nil {
+		return failed(err)
+	}
+	resultPath := strings.TrimSuffix(path, ".json") + batchResultSuffix
+	if err := os.WriteFile(resultPath, output, 0666); err != nil {
+		return failed(err)
+	}
+	if result.ErrorResult != "" {
+		return failed(fmt.Errorf("sim failed: %s", strings.SplitN(result.ErrorResult, "\n", 2)[0]))
+	}
+
+	var entries []batchEntry
+	for _, party := range result.RaidMetrics.Parties {
+		for _, player := range party.Players {
+			if player.Name == "" {
+				continue
+			}
+			entries = append(entries, batchEntry{
+				File:   name,
+				Player: player.Name,
+				Dps:    newBatchMetric(player.Dps),
+				Hps:    newBatchMetric(player.Hps),
+				Tps:    newBatchMetric(player.Threat),
+			})
+		}
+	}
+	return entries
+}
+
+type batchRegression struct {
+	entry    string
+	metric   string
+	baseline float64
+	current  float64
+}
+
+// Returns the metrics which dropped by more than threshold, as a fraction of
+// the baseline. Entries which aren't in the baseline are ignored.
+func findBatchRegressions(entries []batchEntry, baseline []batchEntry, threshold float64) []batchRegression {
+	baselineByKey := make(map[string]batchEntry)
+	for _, entry := range baseline {
+		baselineByKey[entry.key()] = entry
+	}
+
+	var regressions []batchRegression
+	for _, entry := range entries {
+		base, ok := baselineByKey[entry.key()]
+		if !ok || base.Error != "" {
+			continue
+		}
+		if entry.Error != "" {
+			regressions = append(regressions, batchRegression{entry: entry.key(), metric: "error"})
+			continue
+		}
+		for _, metric := range []struct {
+			name     string
+			current  float64
+			baseline float64
+		}{
+			{"DPS", entry.Dps.Avg, base.Dps.Avg},
+			{"HPS", entry.Hps.Avg, base.Hps.Avg},
+			{"TPS", entry.Tps.Avg, base.Tps.Avg},
+		} {
+			if metric.baseline > 0 && metric.current < metric.baseline*(1-threshold) {
+				regressions = append(regressions, batchRegression{
+					entry:    entry.key(),
+					metric:   metric.name,
+					baseline: metric.baseline,
+					current:  metric.current,
+				})
+			}
+		}
+	}
+	return regressions
+}
+
+func batchTables(entries []batchEntry, regressions []batchRegression) []*resultTable {
+	ci95 := func(metric batchMetric) string {
+		return fmt.Sprintf("%.2f - %.2f", metric.Ci95Low, metric.Ci95High)
+	}
+
+	summary := &resultTable{
+		title:  "Summary",
+		header: []string{"File", "Player", "DPS", "DPS 95% CI", "HPS", "HPS 95% CI", "TPS", "TPS 95% CI", "Error"},
+	}
+	for _, entry := range entries {
+		summary.addRow(entry.File, entry.Player,
+			entry.Dps.Avg, ci95(entry.Dps),
+			entry.Hps.Avg, ci95(entry.Hps),
+			entry.Tps.Avg, ci95(entry.Tps),
+			entry.Error)
+	}
+	if len(regressions) == 0 {
+		return []*resultTable{summary}
+	}
+
+	regressionTable := &resultTable{
+		title:  "Regressions",
+		header: []string{"Entry", "Metric", "Baseline", "Current", "Change %"},
+	}
+	for _, regression := range regressions {
+		change := 0.0
+		if regression.baseline > 0 {
+			change = (regression.current/regression.baseline - 1) * 100
+		}
+		regressionTable.addRow(regression.entry, regression.metric, regression.baseline, regression.current, change)
+	}
+	return []*resultTable{summary, regressionTable}
+}
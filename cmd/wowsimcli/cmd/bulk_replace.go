@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strconv"
@@ -25,20 +26,26 @@ var (
 var bulkCmd = &cobra.Command{
 	Use:   "bulk",
 	Short: "bulk simulate item replacements and combinations",
-	Long:  "bulk simulate item replacements and combinations",
+	Long:  "bulk simulate item replacements and combinations. Without --format, each combo is printed as a headerless `[items],dps` line.",
 	Run:   bulkSimMain,
 }
 
 func init() {
 	bulkCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
-	bulkCmd.Flags().StringVar(&replacefile, "replacefile", "", "location of replacement items file. Writes a CSV result of the items replaced instead of JSON")
+	bulkCmd.Flags().StringVar(&replacefile, "replacefile", "", "location of replacement items file")
 	bulkCmd.Flags().StringVar(&outfile, "output", "", "location of output file, defaults to stdout")
 	bulkCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	addFormatFlag(bulkCmd, "")
 	bulkCmd.MarkFlagRequired("infile")
 	bulkCmd.MarkFlagRequired("replacefile")
 }
 
 func bulkSimMain(cmd *cobra.Command, args []string) {
+	format, err := getFormatIfSet(cmd)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	data, err := os.ReadFile(infile)
 	if err != nil {
 		log.Fatalf("failed to load input json file %q: %v", infile, err)
@@ -50,18 +57,22 @@ func bulkSimMain(cmd *cobra.Command, args []string) {
 		log.Fatalf("failed to load input json file: %s", err)
 	}
 
-	output := BulkSim(input, replacefile, verbose)
+	result := BulkSim(input, replacefile, verbose)
+	if result == nil {
+		return
+	}
 
-	if outfile == "" {
-		print(string(output))
-	} else {
-		err = os.WriteFile(outfile, []byte(output), 0666)
-		if err != nil {
-			log.Fatalf("failed to write output file:: %s", err)
-		}
-		if verbose {
-			fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+	err = writeOutput(func(w io.Writer) error {
+		if format == "" {
+			_, err := io.WriteString(w, printCombos(result))
+			return err
 		}
+		return writeResult(w, format, result, func() []*resultTable {
+			return []*resultTable{bulkResultTable(result)}
+		})
+	})
+	if err != nil {
+		log.Fatal(err)
 	}
 }
 
@@ -76,7 +87,8 @@ type ReplaceIter struct {
 	Slots []core.ItemSlot  // Slots for each sub item
 }
 
-func BulkSim(input *proto.RaidSimRequest, replaceFile string, verbose bool) string {
+// Returns nil if the bulk sim failed.
+func BulkSim(input *proto.RaidSimRequest, replaceFile string, verbose bool) *proto.BulkSimResult {
 	// 1. Load up all the sim data we need
 	replaceData, err := os.ReadFile(replaceFile)
 	if err != nil {
@@ -108,13 +120,13 @@ func BulkSim(input *proto.RaidSimRequest, replaceFile string, verbose bool) stri
 		select {
 		case status, ok := <-progress:
 			if !ok {
-				return ""
+				return nil
 			}
 			if status.FinalBulkResult != nil {
 				if status.FinalBulkResult.ErrorResult != "" {
 					fmt.Printf("Failed: %s\n", status.FinalBulkResult.ErrorResult)
 				} else {
-					return printCombos(status.FinalBulkResult)
+					return status.FinalBulkResult
 				}
 			}
 
@@ -152,22 +164,33 @@ func BulkSim(input *proto.RaidSimRequest, replaceFile string, verbose bool) stri
 	}
 }
 
-func printCombos(results *proto.BulkSimResult) string {
-	result := ""
+func bulkResultTable(results *proto.BulkSimResult) *resultTable {
+	table := &resultTable{
+		title:  "Combos",
+		header: []string{"Items", "DPS"},
+	}
 	foundBase := false
-	for i := 0; i < len(results.Results); i++ {
-		if len(results.Results[i].ItemsAdded) == 0 {
+	for _, combo := range results.Results {
+		if len(combo.ItemsAdded) == 0 {
 			foundBase = true
 		}
-		result += printCombo(results.Results[i])
+		table.addRow(comboName(combo), combo.UnitMetrics.Dps.Avg)
 	}
 	if !foundBase {
-		result += fmt.Sprintf("[BASE RESULT],%0.1f\n", results.EquippedGearResult.UnitMetrics.Dps.Avg)
+		table.addRow("[BASE RESULT]", results.EquippedGearResult.UnitMetrics.Dps.Avg)
+	}
+	return table
+}
+
+func printCombos(results *proto.BulkSimResult) string {
+	result := ""
+	for _, row := range bulkResultTable(results).rows {
+		result += fmt.Sprintf("%s,%0.1f\n", row[0], row[1])
 	}
 	return result
 }
 
-func printCombo(combo *proto.BulkComboResult) string {
+func comboName(combo *proto.BulkComboResult) string {
 	itemtext := "["
 	if len(combo.ItemsAdded) == 0 {
 		itemtext += "BASE RESULT"
@@ -179,5 +202,5 @@ func printCombo(combo *proto.BulkComboResult) string {
 		itemtext += fmt.Sprintf("%s@%s", core.ItemsByID[item.Item.Id].Name, item.Slot.String())
 	}
 	itemtext += "]"
-	return fmt.Sprintf("%s,%0.1f\n", itemtext, combo.UnitMetrics.Dps.Avg)
+	return itemtext
 }
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var (
+	combatLogFile          string
+	compareLogPlayer       string
+	compareLogKeepDuration bool
+)
+
+var compareLogCmd = &cobra.Command{
+	Use:   "compare-log",
+	Short: "compare a WoWCombatLog.txt against a sim of the same player",
+	Long:  "compare cast counts, aura uptimes and DPS per action between a 3.3.5 WoWCombatLog.txt and a sim of the same player",
+	RunE:  compareLogMain,
+}
+
+func init() {
+	compareLogCmd.Flags().StringVar(&combatLogFile, "log", "", "location of the combat log (WoWCombatLog.txt)")
+	compareLogCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	compareLogCmd.Flags().StringVar(&compareLogPlayer, "player", "", "name of the player in the log and sim, defaults to the first player of the sim")
+	compareLogCmd.Flags().BoolVar(&compareLogKeepDuration, "keep-duration", false, "use the encounter duration from the input file instead of the fight length from the log")
+	compareLogCmd.Flags().StringVar(&outfile, "output", "", "location of output file, defaults to stdout")
+	addFormatFlag(compareLogCmd, formatTable)
+	compareLogCmd.MarkFlagRequired("log")
+	compareLogCmd.MarkFlagRequired("infile")
+}
+
+func compareLogMain(cmd *cobra.Command, args []string) error {
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	logData, err := os.Open(combatLogFile)
+	if err != nil {
+		return fmt.Errorf("failed to open combat log: %w", err)
+	}
+	defer logData.Close()
+	combatLog, err := core.ParseCombatLog(logData)
+	if err != nil {
+		return fmt.Errorf("failed to parse combat log: %w", err)
+	}
+
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		return fmt.Errorf("failed to load input json file %q: %w", infile, err)
+	}
+	input := &proto.RaidSimRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, input); err != nil {
+		return fmt.Errorf("failed to load input json file: %w", err)
+	}
+
+	simPlayer := findSimPlayer(input.Raid, compareLogPlayer)
+	if simPlayer == nil {
+		return fmt.Errorf("no player %q in input file", compareLogPlayer)
+	}
+	playerName := compareLogPlayer
+	if playerName == "" {
+		playerName = simPlayer.Name
+	}
+	logPlayer, err := combatLog.Player(playerName)
+	if err != nil {
+		return err
+	}
+
+	if !compareLogKeepDuration && input.Encounter != nil {
+		input.Encounter.Duration = logPlayer.Duration.Seconds()
+		input.Encounter.DurationVariation = 0
+	}
+	result := core.RunRaidSim(input)
+	if result.ErrorResult != "" {
+		return fmt.Errorf("sim failed: %s", result.ErrorResult)
+	}
+
+	var simMetrics *proto.UnitMetrics
+	for _, party := range result.RaidMetrics.Parties {
+		for _, player := range party.Players {
+			if player.Name == simPlayer.Name && simMetrics == nil {
+				simMetrics = player
+			}
+		}
+	}
+	simDuration := time.Duration(result.AvgIterationDuration * float64(time.Second))
+	comparison := core.CompareCombatLog(logPlayer, simMetrics, result.Iterations, simDuration)
+
+	summary := &resultTable{
+		title:  "Summary",
+		header: []string{"Player", "Log Duration", "Sim Duration", "Log DPS", "Sim DPS", "DPS Delta"},
+	}
+	summary.addRow(playerName, logPlayer.Duration.Seconds(), simDuration.Seconds(), comparison.LogDPS, comparison.SimDPS, comparison.SimDPS-comparison.LogDPS)
+
+	actions := &resultTable{
+		title:  "Actions",
+		header: []string{"Action", "Log Casts", "Sim Casts", "Cast Delta", "Log DPS", "Sim DPS", "DPS Delta"},
+	}
+	for _, action := range comparison.Actions {
+		actions.addRow(combatLogActionName(logPlayer, action.ActionID),
+			action.LogCasts, action.SimCasts, action.SimCasts-action.LogCasts,
+			action.LogDPS, action.SimDPS, action.SimDPS-action.LogDPS)
+	}
+
+	auras := &resultTable{
+		title:  "Aura Uptime %",
+		header: []string{"Aura", "Log", "Sim", "Delta"},
+	}
+	for _, aura := range comparison.Auras {
+		auras.addRow(combatLogActionName(logPlayer, aura.ActionID),
+			aura.LogUptime*100, aura.SimUptime*100, (aura.SimUptime-aura.LogUptime)*100)
+	}
+
+	return writeOutput(func(w io.Writer) error {
+		return writeTables(w, format, []*resultTable{summary, actions, auras})
+	})
+}
+
+// Returns the player with the given name, or the first player if name is empty.
+func findSimPlayer(raid *proto.Raid, name string) *proto.Player {
+	for _, party := range raid.GetParties() {
+		for _, player := range party.Players {
+			if player.GetClass() == proto.Class_ClassUnknown {
+				continue
+			}
+			if name == "" || strings.EqualFold(player.Name, name) {
+				return player
+			}
+		}
+	}
+	return nil
+}
+
+func combatLogActionName(logPlayer *core.CombatLogPlayer, actionID core.ActionID) string {
+	if name, ok := logPlayer.ActionNames[actionID]; ok {
+		return name
+	}
+	return actionID.String()
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"github.com/wowsims/wotlk/sim/core/stats"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var computeStatsCmd = &cobra.Command{
+	Use:   "computestats",
+	Short: "compute character stats",
+	Long:  "compute the stats of each player in a raid, taking into account gear, talents, buffs and consumes",
+	RunE:  computeStatsMain,
+}
+
+func init() {
+	computeStatsCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (ComputeStatsRequest in protojson format)")
+	computeStatsCmd.Flags().StringVar(&outfile, "output", "", "location of output file, defaults to stdout")
+	addFormatFlag(computeStatsCmd, formatJSON)
+	computeStatsCmd.MarkFlagRequired("infile")
+}
+
+func computeStatsMain(cmd *cobra.Command, args []string) error {
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		return fmt.Errorf("failed to load input json file %q: %w", infile, err)
+	}
+	input := &proto.ComputeStatsRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, input); err != nil {
+		return fmt.Errorf("failed to load input json file: %w", err)
+	}
+
+	result := core.ComputeStats(input)
+	if result.ErrorResult != "" {
+		return fmt.Errorf("failed to compute stats: %s", result.ErrorResult)
+	}
+
+	return writeOutput(func(w io.Writer) error {
+		return writeResult(w, format, result, func() []*resultTable {
+			return []*resultTable{computeStatsTable(input, result)}
+		})
+	})
+}
+
+// One row per stat, with the final stats of each player as columns. Stats
+// which are 0 for every player are skipped.
+func computeStatsTable(input *proto.ComputeStatsRequest, result *proto.ComputeStatsResult) *resultTable {
+	table := &resultTable{
+		title:  "Final Stats",
+		header: []string{"Stat"},
+	}
+
+	var playerStats [][]float64
+	for i, party := range result.RaidStats.GetParties() {
+		for j, player := range party.Players {
+			if player.GetFinalStats() == nil {
+				continue
+			}
+			name := fmt.Sprintf("Player %d", i*5+j+1)
+			if configured := input.Raid.GetParties()[i].GetPlayers(); j < len(configured) && configured[j].GetName() != "" {
+				name = configured[j].Name
+			}
+			table.header = append(table.header, name)
+			playerStats = append(playerStats, player.FinalStats.Stats)
+		}
+	}
+
+	for stat := 0; stat < int(stats.Len); stat++ {
+		cells := []interface{}{stats.Stat(stat).StatName()}
+		nonZero := false
+		for _, values := range playerStats {
+			value := 0.0
+			if stat < len(values) {
+				value = values[stat]
+			}
+			nonZero = nonZero || value != 0
+			cells = append(cells, value)
+		}
+		if nonZero {
+			table.addRow(cells...)
+		}
+	}
+	return table
+}
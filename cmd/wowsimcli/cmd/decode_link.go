@@ -6,27 +6,37 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	goproto "github.com/golang/protobuf/proto"
 	"github.com/spf13/cobra"
 	"github.com/wowsims/wotlk/sim/core/proto"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 var decodeLinkCmd = &cobra.Command{
 	Use:   "decodelink [link]",
 	Short: "decode wowsims link/url",
-	Long:  "decode wowsims link/url",
+	Long:  "decode wowsims link/url. The csv, table and markdown formats list each set field of the settings.",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return decodeLink(args[0])
+		format, err := getFormat(cmd)
+		if err != nil {
+			return err
+		}
+		return decodeLink(args[0], format)
 	},
 }
 
+func init() {
+	addFormatFlag(decodeLinkCmd, formatJSON)
+}
+
 var errInvalidLink = errors.New("invalid wowsims export link")
 
-func decodeLink(link string) error {
+func decodeLink(link string, format string) error {
 	parts := strings.Split(link, "#")
 	switch {
 	case len(parts) != 2:
@@ -62,6 +72,52 @@ func decodeLink(link string) error {
 		return fmt.Errorf("cannot unmarshal raw proto: %w", err)
 	}
 
-	fmt.Println(protojson.Format(goproto.MessageV2(settings)))
-	return nil
+	if format == formatJSON {
+		fmt.Println(protojson.Format(goproto.MessageV2(settings)))
+		return nil
+	}
+	table := &resultTable{
+		title:  "Settings",
+		header: []string{"Field", "Value"},
+	}
+	addFieldRows(table, "", goproto.MessageV2(settings).ProtoReflect())
+	return writeTables(os.Stdout, format, []*resultTable{table})
+}
+
+// Adds a row for each set scalar field of message, named by its path from the
+// root message, e.g. player.equipment.items[0].id.
+func addFieldRows(table *resultTable, prefix string, message protoreflect.Message) {
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		name := prefix + string(field.Name())
+		switch {
+		case field.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				addFieldValue(table, fmt.Sprintf("%s[%d]", name, i), field, list.Get(i))
+			}
+		case field.IsMap():
+			value.Map().Range(func(key protoreflect.MapKey, mapValue protoreflect.Value) bool {
+				addFieldValue(table, fmt.Sprintf("%s[%v]", name, key.Interface()), field.MapValue(), mapValue)
+				return true
+			})
+		default:
+			addFieldValue(table, name, field, value)
+		}
+		return true
+	})
+}
+
+func addFieldValue(table *resultTable, name string, field protoreflect.FieldDescriptor, value protoreflect.Value) {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		addFieldRows(table, name+".", value.Message())
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			table.addRow(name, string(enumValue.Name()))
+		} else {
+			table.addRow(name, fmt.Sprint(value.Enum()))
+		}
+	default:
+		table.addRow(name, fmt.Sprint(value.Interface()))
+	}
 }
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Output formats for the --format flag.
+const (
+	formatJSON     = "json"
+	formatCSV      = "csv"
+	formatTable    = "table"
+	formatMarkdown = "markdown"
+)
+
+// Adds the --format flag. Commands read it with getFormat, since a shared
+// variable would take the default of whichever command registered it last.
+func addFormatFlag(cmd *cobra.Command, defaultFormat string) {
+	cmd.Flags().String("format", defaultFormat, "output format: json, csv, table or markdown")
+}
+
+func getFormat(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case formatJSON, formatCSV, formatTable, formatMarkdown:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown format %q, expected json, csv, table or markdown", format)
+}
+
+// Like getFormat, but returns an empty format if --format wasn't given, for
+// commands which keep their plain output by default.
+func getFormatIfSet(cmd *cobra.Command) (string, error) {
+	if !cmd.Flags().Changed("format") {
+		return "", nil
+	}
+	return getFormat(cmd)
+}
+
+// A result rendered as rows, for the csv, table and markdown formats. Cells
+// are strings, or float64s which are printed with 2 decimals.
+type resultTable struct {
+	title  string
+	header []string
+	rows   [][]interface{}
+}
+
+func (table *resultTable) addRow(cells ...interface{}) {
+	table.rows = append(table.rows, cells)
+}
+
+func formatCell(cell interface{}) string {
+	switch value := cell.(type) {
+	case float64:
+		return strconv.FormatFloat(value, 'f', 2, 64)
+	case string:
+		return value
+	}
+	return fmt.Sprint(cell)
+}
+
+// Writes the tables in the given format. For json, each table is written as
+// a list of objects keyed by its header, under its title.
+func writeTables(w io.Writer, format string, tables []*resultTable) error {
+	switch format {
+	case formatJSON:
+		output := make(map[string][]map[string]interface{})
+		for _, table := range tables {
+			rows := []map[string]interface{}{}
+			for _, row := range table.rows {
+				object := make(map[string]interface{})
+				for i, cell := range row {
+					object[table.header[i]] = cell
+				}
+				rows = append(rows, object)
+			}
+			output[table.title] = rows
+		}
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+
+	case formatCSV:
+		writer := csv.NewWriter(w)
+		for i, table := range tables {
+			if i > 0 {
+				writer.Write(nil)
+			}
+			writer.Write(table.header)
+			for _, row := range table.rows {
+				record := make([]string, len(row))
+				for j, cell := range row {
+					record[j] = formatCell(cell)
+				}
+				writer.Write(record)
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case formatTable:
+		for i, table := range tables {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			if table.title != "" {
+				fmt.Fprintf(w, "%s:\n", table.title)
+			}
+			writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(writer, strings.Join(table.header, "\t"))
+			for _, row := range table.rows {
+				cells := make([]string, len(row))
+				for j, cell := range row {
+					cells[j] = formatCell(cell)
+				}
+				fmt.Fprintln(writer, strings.Join(cells, "\t"))
+			}
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case formatMarkdown:
+		for i, table := range tables {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			if table.title != "" {
+				fmt.Fprintf(w, "### %s\n\n", table.title)
+			}
+			fmt.Fprintf(w, "| %s |\n", strings.Join(table.header, " | "))
+			fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(table.header)))
+			for _, row := range table.rows {
+				cells := make([]string, len(row))
+				for j, cell := range row {
+					cells[j] = strings.ReplaceAll(formatCell(cell), "|", "\\|")
+				}
+				fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// Writes message as protojson for the json format, and as the given tables
+// otherwise.
+func writeResult(w io.Writer, format string, message googleProto.Message, tables func() []*resultTable) error {
+	if format == formatJSON {
+		data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(message)
+		if err != nil {
+			return fmt.Errorf("failed to marshal result: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	return writeTables(w, format, tables())
+}
+
+// Calls write with the --output file, or stdout if it isn't set.
+func writeOutput(write func(w io.Writer) error) error {
+	if outfile == "" {
+		return write(os.Stdout)
+	}
+
+	file, err := os.Create(outfile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	if verbose {
+		fmt.Printf("Wrote output file: `%s` successfully.\n", outfile)
+	}
+	return nil
+}
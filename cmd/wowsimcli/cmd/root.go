@@ -17,7 +17,12 @@ func Execute(version string) {
 	rootCmd.AddCommand(newVersionCommand(version))
 	rootCmd.AddCommand(simCmd)
 	rootCmd.AddCommand(bulkCmd)
+	rootCmd.AddCommand(statWeightsCmd)
+	rootCmd.AddCommand(computeStatsCmd)
+	rootCmd.AddCommand(batchCmd)
 	rootCmd.AddCommand(decodeLinkCmd)
+	rootCmd.AddCommand(aplCmd)
+	rootCmd.AddCommand(compareLogCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/wotlk/sim/core"
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"github.com/wowsims/wotlk/sim/core/stats"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var statWeightsCmd = &cobra.Command{
+	Use:   "statweights",
+	Short: "calculate stat weights and EP values",
+	Long:  "calculate stat weights and EP values, with standard deviations, for the stats listed in the request",
+	RunE:  statWeightsMain,
+}
+
+func init() {
+	statWeightsCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (StatWeightsRequest in protojson format)")
+	statWeightsCmd.Flags().StringVar(&outfile, "output", "", "location of output file, defaults to stdout")
+	statWeightsCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	addFormatFlag(statWeightsCmd, formatJSON)
+	statWeightsCmd.MarkFlagRequired("infile")
+}
+
+func statWeightsMain(cmd *cobra.Command, args []string) error {
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(infile)
+	if err != nil {
+		return fmt.Errorf("failed to load input json file %q: %w", infile, err)
+	}
+	input := &proto.StatWeightsRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, input); err != nil {
+		return fmt.Errorf("failed to load input json file: %w", err)
+	}
+
+	result := core.StatWeights(input)
+
+	return writeOutput(func(w io.Writer) error {
+		return writeResult(w, format, result, func() []*resultTable {
+			return []*resultTable{statWeightsTable(input, result)}
+		})
+	})
+}
+
+// One row per weighed stat, with the weight, its standard deviation and the
+// EP value for each metric that has any non-zero weights.
+func statWeightsTable(input *proto.StatWeightsRequest, result *proto.StatWeightsResult) *resultTable {
+	table := &resultTable{
+		title:  "Stat Weights",
+		header: []string{"Stat"},
+	}
+
+	type statRow struct {
+		name  string
+		value func(*proto.UnitStats) float64
+	}
+	var statRows []statRow
+	for _, stat := range input.StatsToWeigh {
+		idx := int(stat)
+		statRows = append(statRows, statRow{
+			name: stats.Stat(stat).StatName(),
+			value: func(unitStats *proto.UnitStats) float64 {
+				if idx < len(unitStats.GetStats()) {
+					return unitStats.Stats[idx]
+				}
+				return 0
+			},
+		})
+	}
+	for _, pseudoStat := range input.PseudoStatsToWeigh {
+		idx := int(pseudoStat)
+		statRows = append(statRows, statRow{
+			name: strings.TrimPrefix(pseudoStat.String(), "PseudoStat"),
+			value: func(unitStats *proto.UnitStats) float64 {
+				if idx < len(unitStats.GetPseudoStats()) {
+					return unitStats.PseudoStats[idx]
+				}
+				return 0
+			},
+		})
+	}
+
+	var metrics []*proto.StatWeightValues
+	for _, metric := range []struct {
+		name   string
+		values *proto.StatWeightValues
+	}{
+		{"DPS", result.Dps},
+		{"HPS", result.Hps},
+		{"TPS", result.Tps},
+		{"DTPS", result.Dtps},
+		{"TMI", result.Tmi},
+		{"Death", result.PDeath},
+	} {
+		hasWeights := false
+		for _, row := range statRows {
+			if row.value(metric.values.GetWeights()) != 0 {
+				hasWeights = true
+			}
+		}
+		if !hasWeights {
+			continue
+		}
+		metrics = append(metrics, metric.values)
+		table.header = append(table.header, metric.name, metric.name+" Stdev", metric.name+" EP")
+	}
+
+	for _, row := range statRows {
+		cells := []interface{}{row.name}
+		for _, values := range metrics {
+			cells = append(cells, row.value(values.Weights), row.value(values.WeightsStdev), row.value(values.EpValues))
+		}
+		table.addRow(cells...)
+	}
+	return table
+}
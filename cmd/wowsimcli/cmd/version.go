@@ -1,21 +1,45 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 )
 
 func newVersionCommand(version string) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "prints version information",
 		Long:  "prints version information",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := getFormatIfSet(cmd)
+			if err != nil {
+				return err
+			}
 			if version == "" {
 				version = "development"
 			}
-			fmt.Println(version)
+			switch format {
+			case "":
+				fmt.Println(version)
+				return nil
+			case formatJSON:
+				data, err := json.MarshalIndent(map[string]string{"version": version}, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+			table := &resultTable{
+				header: []string{"Version"},
+			}
+			table.addRow(version)
+			return writeTables(os.Stdout, format, []*resultTable{table})
 		},
 	}
+	addFormatFlag(cmd, "")
+	return cmd
 }
@@ -55,7 +55,7 @@ func init() {
 			})
 		}
 
-		numHits := core.MinInt32(5, character.Env.GetNumTargets())
+		maxHits := core.MinInt32(5, character.Env.GetNumTargets())
 		debuffAuras := make([]*core.Aura, len(character.Env.Encounter.TargetUnits))
 		for i, target := range character.Env.Encounter.TargetUnits {
 			debuffAuras[i] = makeDebuffAura(target)
@@ -70,6 +70,7 @@ func init() {
 			FlatThreatBonus:  63,
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				numHits := core.MinInt32(maxHits, int32(len(sim.Encounter.ActiveTargetUnits)))
 				curTarget := target
 				for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
 					result := spell.CalcDamage(sim, curTarget, 0, spell.OutcomeMagicHit)
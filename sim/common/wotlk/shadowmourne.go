@@ -35,8 +35,8 @@ func init() {
 			ThreatMultiplier: 1,
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-				baseDamage := sim.Roll(1900, 2100) / float64(sim.GetNumTargets())
-				for _, target := range sim.Encounter.TargetUnits {
+				baseDamage := sim.Roll(1900, 2100) / float64(len(sim.Encounter.ActiveTargetUnits))
+				for _, target := range sim.Encounter.ActiveTargetUnits {
 					spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeMagicHit) // probably has a very low crit rate
 				}
 			},
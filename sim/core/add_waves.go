@@ -0,0 +1,119 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"github.com/wowsims/wotlk/sim/core/stats"
+)
+
+// A group of targets which spawn together partway through the fight.
+type addWave struct {
+	name      string
+	spawnTime time.Duration
+	interval  time.Duration
+	scripted  bool
+
+	targets []*Target
+}
+
+func newAddWave(config *proto.AddWave, encounter *Encounter) addWave {
+	wave := addWave{
+		name:      config.Name,
+		spawnTime: DurationFromSeconds(config.SpawnTime),
+		interval:  DurationFromSeconds(config.Interval),
+		scripted:  config.Scripted,
+	}
+	for _, targetIndex := range config.TargetIndices {
+		if targetIndex < 0 || int(targetIndex) >= len(encounter.Targets) {
+			panic(fmt.Sprintf("Add wave `%s` references invalid target index %d", config.Name, targetIndex))
+		}
+		target := encounter.Targets[targetIndex]
+		target.trackDeath()
+		wave.targets = append(wave.targets, target)
+	}
+	return wave
+}
+
+// Despawns every target belonging to a wave and schedules the wave spawns.
+// Scripted waves are left to the encounter scripts.
+func (encounter *Encounter) resetAddWaves(sim *Simulation) {
+	for _, wave := range encounter.addWaves {
+		for _, target := range wave.targets {
+			target.Despawn(sim)
+		}
+	}
+
+	for _, wave := range encounter.addWaves {
+		wave := wave
+		if wave.scripted {
+			continue
+		}
+		pa := &PendingAction{
+			NextActionAt: wave.spawnTime,
+		}
+		pa.OnAction = func(sim *Simulation) {
+			wave.spawn(sim)
+			if wave.interval > 0 {
+				pa.NextActionAt = sim.CurrentTime + wave.interval
+				sim.AddPendingAction(pa)
+			}
+		}
+		sim.AddPendingAction(pa)
+	}
+}
+
+func (wave *addWave) spawn(sim *Simulation) {
+	if sim.Log != nil {
+		sim.Log("Add wave: %s", wave.name)
+	}
+	for _, target := range wave.targets {
+		target.Spawn(sim)
+	}
+}
+
+// Gives the target its own health pool, drained by the damage it takes. The
+// target dies once it runs out.
+func (target *Target) trackDeath() {
+	if target.HasHealthBar() || target.stats[stats.Health] <= 0 {
+		return
+	}
+	target.EnableHealthBar()
+
+	onDamageTaken := func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+		if result.Damage <= 0 || !target.IsEnabled() {
+			return
+		}
+		target.RemoveHealth(sim, result.Damage)
+		if target.CurrentHealth() <= 0 {
+			target.die(sim)
+		}
+	}
+
+	target.RegisterAura(Aura{
+		Label:    "Health Pool",
+		Duration: NeverExpires,
+		OnReset: func(aura *Aura, sim *Simulation) {
+			aura.Activate(sim)
+		},
+		OnSpellHitTaken:       onDamageTaken,
+		OnPeriodicDamageTaken: onDamageTaken,
+	})
+}
+
+// Despawns a target which ran out of health. Temporary auras, including any
+// dots on it, fall off; permanent debuffs stay for the next spawn.
+func (target *Target) die(sim *Simulation) {
+	if sim.Log != nil {
+		target.Log(sim, "Died")
+	}
+
+	for _, aura := range target.auras {
+		if aura.IsActive() && aura.Duration != NeverExpires {
+			aura.Deactivate(sim)
+		}
+	}
+
+	target.Despawn(sim)
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"github.com/wowsims/wotlk/sim/core/stats"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+func TestAddWaves(t *testing.T) {
+	firstAdd := googleProto.Clone(NewDefaultTarget()).(*proto.Target)
+	firstAdd.Stats[stats.Health] = 20000
+	request := fakeCasterRequest(&proto.SimOptions{Iterations: 5, RandomSeed: 101})
+	request.Encounter.Targets = []*proto.Target{firstAdd, NewDefaultTarget()}
+	request.Encounter.AddWaves = []*proto.AddWave{
+		{Name: "First", SpawnTime: 0, TargetIndices: []int32{0}},
+		{Name: "Second", SpawnTime: 60, TargetIndices: []int32{1}},
+	}
+
+	result := RunRaidSim(request)
+	if result.ErrorResult != "" {
+		t.Fatalf("Sim failed: %s", result.ErrorResult)
+	}
+
+	// The first add dies once it has taken its health worth of damage, the
+	// second has no health pool and lives until the end.
+	targets := result.EncounterMetrics.Targets
+	if active := targets[0].SecondsActiveAvg; active <= 0 || active >= 300 {
+		t.Fatalf("Expected the first add to die, was active for %f seconds", active)
+	}
+	if active := targets[1].SecondsActiveAvg; math.Abs(active-240) > 1e-6 {
+		t.Fatalf("Expected the second add to be active for 240 seconds, got %f", active)
+	}
+
+	// The pet moves on to the second add once the first one dies.
+	damageByTarget := make(map[int32]float64)
+	for _, action := range result.RaidMetrics.Parties[0].Players[0].Pets[0].Actions {
+		for _, target := range action.Targets {
+			damageByTarget[target.UnitIndex] += target.Damage
+		}
+	}
+	if damageByTarget[0] == 0 || damageByTarget[1] == 0 {
+		t.Fatalf("Expected damage on both adds, got %v", damageByTarget)
+	}
+
+	// The AoE proc loops over every target, but despawned ones aren't hit. The
+	// second add is only hit from 63s on, and the first one until it dies.
+	novaHits := make(map[int32]int32)
+	for _, action := range result.RaidMetrics.Parties[0].Players[0].Actions {
+		if action.Id.GetSpellId() != 5 {
+			continue
+		}
+		for _, target := range action.Targets {
+			novaHits[target.UnitIndex] += target.Hits + target.Crits + target.Misses
+		}
+	}
+	procsPerIteration := int32(300 / FakeCasterNovaPeriod.Seconds())
+	procsBeforeSpawn := int32(60 / FakeCasterNovaPeriod.Seconds())
+	if expected := (procsPerIteration - procsBeforeSpawn) * result.Iterations; novaHits[1] != expected {
+		t.Fatalf("Expected %d AoE hits on the second add, got %d", expected, novaHits[1])
+	}
+	if novaHits[0] == 0 || novaHits[0] >= procsPerIteration*result.Iterations {
+		t.Fatalf("Expected AoE hits on the first add only while it was alive, got %d", novaHits[0])
+	}
+}
+
+func TestAddWavesStartDespawned(t *testing.T) {
+	request := fakeCasterRequest(&proto.SimOptions{Iterations: 5, RandomSeed: 101})
+	request.Encounter.Targets = []*proto.Target{NewDefaultTarget(), NewDefaultTarget()}
+	request.Encounter.AddWaves = []*proto.AddWave{
+		{Name: "Late", SpawnTime: 60, TargetIndices: []int32{0}},
+	}
+
+	result := RunRaidSim(request)
+	if result.ErrorResult != "" {
+		t.Fatalf("Sim failed: %s", result.ErrorResult)
+	}
+
+	// The player's default target starts despawned, so it starts on the other
+	// target instead, and stays on it once the late add spawns.
+	nukeDamage := make(map[int32]float64)
+	for _, action := range result.RaidMetrics.Parties[0].Players[0].Actions {
+		if action.Id.GetSpellId() != 1 {
+			continue
+		}
+		for _, target := range action.Targets {
+			nukeDamage[target.UnitIndex] += target.Damage
+		}
+	}
+	if nukeDamage[1] == 0 || nukeDamage[0] != 0 {
+		t.Fatalf("Expected the player to only nuke the target which was there from the start, got %v", nukeDamage)
+	}
+}
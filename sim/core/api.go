@@ -23,13 +23,13 @@ func ComputeStats(csr *proto.ComputeStatsRequest) *proto.ComputeStatsResult {
  * Returns stat weights and EP values, with standard deviations, for all stats.
  */
 func StatWeights(request *proto.StatWeightsRequest) *proto.StatWeightsResult {
-	result := CalcStatWeight(request, stats.Stat(request.EpReferenceStat), nil)
+	result := CalcStatWeight(context.Background(), request, stats.Stat(request.EpReferenceStat), nil)
 	return result.ToProto()
 }
 
-func StatWeightsAsync(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics) {
+func StatWeightsAsync(ctx context.Context, request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics) {
 	go func() {
-		result := CalcStatWeight(request, stats.Stat(request.EpReferenceStat), progress)
+		result := CalcStatWeight(ctx, request, stats.Stat(request.EpReferenceStat), progress)
 		progress <- &proto.ProgressMetrics{
 			FinalWeightResult: result.ToProto(),
 		}
@@ -43,8 +43,8 @@ func RunRaidSim(request *proto.RaidSimRequest) *proto.RaidSimResult {
 	return RunSim(request, nil)
 }
 
-func RunRaidSimAsync(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics) {
-	go RunSim(request, progress)
+func RunRaidSimAsync(ctx context.Context, request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics) {
+	go RunSimWithContext(ctx, request, progress)
 }
 
 func RunBulkSim(request *proto.BulkSimRequest) *proto.BulkSimResult {
@@ -2,15 +2,52 @@ package core
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/wowsims/wotlk/sim/core/proto"
+	"golang.org/x/exp/slices"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 type APLRotation struct {
 	unit         *Unit
 	priorityList []*APLAction
+
+	// Named action lists and variables, compiled lazily as they are referenced.
+	actionLists map[string]*APLActionList
+	variables   map[string]APLValue
+
+	actionListConfigs map[string]*proto.APLActionList
+	variableConfigs   map[string]*proto.APLVariable
+
+	// Names of the lists / variables currently being compiled, for cycle detection.
+	actionListStack []string
+	variableStack   []string
+
+	// Validation state, only used while compiling. See apl_validation.go.
+	configStack      []protoreflect.ProtoMessage
+	validationGroups []*aplValidationGroup
+	curGroups        []*aplValidationGroup
+
+	// Errors / warnings found while compiling, for displaying in the UI.
+	validations *proto.APLRotationValidations
+
+	// Action currently controlling this rotation (only used for certain actions, such as StrictSequence).
+	controllingAction APLActionImpl
+
+	// Spells cast explicitly by this rotation. Major cooldowns among these are
+	// excluded from autocast_other_cooldowns.
+	castSpells []*Spell
+
+	// Every compiled list item, including those in named action lists.
+	listItems []*APLAction
+	// Number of iterations covered by the list item metrics.
+	metricsIterations int
+
+	// Whether the unit is idling because no actions were available.
+	idling bool
 }
 
 func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
@@ -18,49 +55,236 @@ func (unit *Unit) newAPLRotation(config *proto.APLRotation) *APLRotation {
 		return nil
 	}
 
-	priorityList := MapSlice(config.PriorityList, func(aplItem *proto.APLListItem) *APLAction {
+	rot := &APLRotation{
+		unit:              unit,
+		actionLists:       make(map[string]*APLActionList),
+		variables:         make(map[string]APLValue),
+		actionListConfigs: make(map[string]*proto.APLActionList),
+		variableConfigs:   make(map[string]*proto.APLVariable),
+	}
+
+	rot.doWithValidation(config, func() {
+		for _, listConfig := range config.ActionLists {
+			if listConfig.Name == "" {
+				rot.validationWarning("Action lists must have a name")
+				continue
+			}
+			if _, ok := rot.actionListConfigs[listConfig.Name]; ok {
+				rot.validationWarning("Duplicate action list name: %s", listConfig.Name)
+				continue
+			}
+			rot.actionListConfigs[listConfig.Name] = listConfig
+		}
+		for _, variableConfig := range config.Variables {
+			if variableConfig.Name == "" {
+				rot.validationWarning("Variables must have a name")
+				continue
+			}
+			if _, ok := rot.variableConfigs[variableConfig.Name]; ok {
+				rot.validationWarning("Duplicate variable name: %s", variableConfig.Name)
+				continue
+			}
+			rot.variableConfigs[variableConfig.Name] = variableConfig
+		}
+	})
+
+	rot.priorityList = rot.newAPLListItems(config.PriorityList)
+
+	// Compile anything which wasn't referenced from the main list, so errors are still reported.
+	for _, listConfig := range config.ActionLists {
+		if rot.actionListConfigs[listConfig.Name] == listConfig {
+			rot.getActionList(listConfig.Name)
+		}
+	}
+	for _, variableConfig := range config.Variables {
+		if rot.variableConfigs[variableConfig.Name] == variableConfig {
+			rot.getVariable(variableConfig.Name)
+		}
+	}
+
+	// Cooldowns cast explicitly by the rotation shouldn't also be used automatically.
+	if len(rot.castSpells) > 0 {
+		if agent := unit.Env.Raid.GetPlayerFromUnitIndex(unit.UnitIndex); agent != nil {
+			for _, spell := range rot.castSpells {
+				agent.GetCharacter().disableAutoUse(spell)
+			}
+		}
+	}
+
+	paths := make(map[protoreflect.ProtoMessage]string)
+	collectAPLConfigPaths(config.ProtoReflect(), "", paths)
+	for _, item := range rot.listItems {
+		item.path = paths[item.listItem]
+	}
+
+	rot.validations = rot.finalizeValidations(paths)
+	return rot
+}
+
+func (rot *APLRotation) newAPLListItems(items []*proto.APLListItem) []*APLAction {
+	actions := MapSlice(items, func(aplItem *proto.APLListItem) *APLAction {
 		if aplItem.Hide {
 			return nil
-		} else {
-			return unit.newAPLAction(aplItem.Action)
 		}
+
+		var action *APLAction
+		rot.doWithValidation(aplItem, func() {
+			action = rot.newAPLAction(aplItem.Action)
+		})
+		if action != nil {
+			action.listItem = aplItem
+			rot.listItems = append(rot.listItems, action)
+		}
+		return action
 	})
-	priorityList = FilterSlice(priorityList, func(action *APLAction) bool { return action != nil })
+	return FilterSlice(actions, func(action *APLAction) bool { return action != nil })
+}
+
+// Returns the compiled action list with the given name, compiling it if needed.
+func (rot *APLRotation) getActionList(name string) *APLActionList {
+	if list, ok := rot.actionLists[name]; ok {
+		return list
+	}
+
+	config, ok := rot.actionListConfigs[name]
+	if !ok {
+		rot.validationError("Unknown action list: %s", name)
+	}
+	if slices.Contains(rot.actionListStack, name) {
+		rot.validationError("Action list cycle: %s", strings.Join(append(rot.actionListStack, name), " -> "))
+	}
+
+	rot.actionListStack = append(rot.actionListStack, name)
+	defer func() {
+		rot.actionListStack = rot.actionListStack[:len(rot.actionListStack)-1]
+	}()
 
-	return &APLRotation{
-		unit:         unit,
-		priorityList: priorityList,
+	list := &APLActionList{
+		name:    name,
+		actions: rot.newAPLListItems(config.Items),
 	}
+	rot.actionLists[name] = list
+	return list
+}
+
+// Returns the compiled value of the variable with the given name, compiling it if needed.
+func (rot *APLRotation) getVariable(name string) APLValue {
+	if value, ok := rot.variables[name]; ok {
+		return value
+	}
+
+	config, ok := rot.variableConfigs[name]
+	if !ok {
+		rot.validationError("Unknown variable: %s", name)
+	}
+	if slices.Contains(rot.variableStack, name) {
+		rot.validationError("Variable cycle: %s", strings.Join(append(rot.variableStack, name), " -> "))
+	}
+
+	rot.variableStack = append(rot.variableStack, name)
+	defer func() {
+		rot.variableStack = rot.variableStack[:len(rot.variableStack)-1]
+	}()
+
+	var value APLValue
+	rot.doWithValidation(config, func() {
+		value = rot.newAPLValue(config.Value)
+	})
+	rot.variables[name] = value
+	return value
+}
+
+// Variables evaluate to the value they were defined with, so the same compiled value is shared by every reference.
+func (rot *APLRotation) newValueVariable(config *proto.APLValueVariable) APLValue {
+	return rot.getVariable(config.Name)
+}
+
+func (apl *APLRotation) reset(sim *Simulation) {
+	apl.controllingAction = nil
+	apl.idling = false
+	for _, action := range apl.priorityList {
+		action.Reset(sim)
+	}
+	for _, list := range apl.actionLists {
+		list.reset(sim)
+	}
+}
+
+// Returns the highest priority action which is available right now, or nil if none are.
+func (apl *APLRotation) getNextAction(sim *Simulation) *APLAction {
+	return getNextAvailableAction(sim, apl.priorityList)
+}
+
+func getNextAvailableAction(sim *Simulation, actions []*APLAction) *APLAction {
+	for _, action := range actions {
+		if action.IsAvailable(sim) {
+			return action
+		}
+	}
+	return nil
 }
 
 // We intentionally try to mimic the behavior of simc APL to avoid confusion
 // and leverage the community's existing familiarity.
 // https://github.com/simulationcraft/simc/wiki/ActionLists
 func (apl *APLRotation) DoNextAction(sim *Simulation) {
-	for _, action := range apl.priorityList {
-		if action.IsAvailable(sim) {
+	apl.idling = false
+
+	// Off-GCD actions don't consume any time, so keep going until the unit is busy.
+	for i := 0; ; i++ {
+		if i > 1000 {
+			panic(fmt.Sprintf("%s: APL rotation performed too many actions at once, check for off-GCD actions which are always available", apl.unit.Label))
+		}
+
+		if apl.controllingAction != nil {
+			if !apl.controllingAction.IsAvailable(sim) {
+				// Wait for the next step, e.g. for resources, rather than breaking up the sequence.
+				break
+			}
+			if sim.Log != nil {
+				apl.unit.Log(sim, "APL continuing controlling action")
+			}
+			apl.controllingAction.Execute(sim)
+		} else if action := apl.getNextAction(sim); action != nil {
+			if sim.Log != nil {
+				apl.unit.Log(sim, "APL executing %s", action.path)
+			}
 			action.Execute(sim)
+		} else {
+			break
+		}
+
+		if !apl.unit.GCD.IsReady(sim) || apl.unit.Hardcast.Expires > sim.CurrentTime {
 			return
 		}
 	}
 
+	apl.doNoAvailableActions(sim)
+}
+
+func (apl *APLRotation) doNoAvailableActions(sim *Simulation) {
 	if sim.Log != nil {
 		apl.unit.Log(sim, "No available actions!")
 	}
 	if apl.unit.GCD.IsReady(sim) {
 		apl.unit.WaitUntil(sim, sim.CurrentTime+time.Millisecond*500)
+		apl.idling = true
 	} else {
 		apl.unit.DoNothing()
 	}
 }
 
-func validationError(message string, vals ...interface{}) {
-	panic("Validation Error: " + fmt.Sprintf(message, vals...))
-}
-
-// For validation issues that we can manage internally. Will probably make this a test-only panic later.
-func validationWarning(message string, vals ...interface{}) {
-	panic("Validation Warning: " + fmt.Sprintf(message, vals...))
+// Called whenever the unit gains a resource or stops moving. If the rotation is
+// idling because nothing was available, re-check right away since something may
+// have become castable, instead of waiting out the rest of the idle period.
+func (apl *APLRotation) recheckIfIdling(sim *Simulation) {
+	if !apl.idling || sim.CurrentTime < 0 {
+		return
+	}
+	apl.idling = false
+	if apl.unit.NextGCDAt() > sim.CurrentTime {
+		apl.unit.SetGCDTimer(sim, sim.CurrentTime)
+	}
 }
 
 func APLRotationFromJsonString(jsonString string) *proto.APLRotation {
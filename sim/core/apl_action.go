@@ -7,47 +7,129 @@ import (
 type APLAction struct {
 	condition APLValue
 	impl      APLActionImpl
+
+	// Config and location of the list item this action was compiled from,
+	// e.g. "priority_list[2]". Only set for list items.
+	listItem *proto.APLListItem
+	path     string
+
+	// Only set when SimOptions.AplMetrics is enabled, see apl_metrics.go.
+	metrics *aplListItemMetrics
 }
 
 func (action *APLAction) IsAvailable(sim *Simulation) bool {
-	return (action.condition == nil || action.condition.GetBool(sim)) && action.impl.IsAvailable(sim)
+	if action.metrics != nil {
+		action.metrics.evaluations++
+	}
+	if action.condition != nil && !action.condition.GetBool(sim) {
+		return false
+	}
+	if action.metrics != nil {
+		action.metrics.conditionTrue++
+	}
+	return action.impl.IsAvailable(sim)
 }
 
 func (action *APLAction) Execute(sim *Simulation) {
+	if action.metrics != nil {
+		action.metrics.executions++
+	}
 	action.impl.Execute(sim)
 }
 
+func (action *APLAction) Reset(sim *Simulation) {
+	action.impl.Reset(sim)
+}
+
 type APLActionImpl interface {
 	// Whether this action is available to be used right now.
 	IsAvailable(*Simulation) bool
 
 	// Performs the action.
 	Execute(*Simulation)
+
+	// Invoked before each sim iteration.
+	Reset(*Simulation)
 }
 
-func (unit *Unit) newAPLAction(config *proto.APLAction) *APLAction {
+// Provides an empty implementation of Reset() for actions without any state.
+type defaultAPLActionImpl struct {
+}
+
+func (impl defaultAPLActionImpl) Reset(sim *Simulation) {}
+
+func (rot *APLRotation) newAPLAction(config *proto.APLAction) *APLAction {
 	if config == nil {
 		return nil
 	}
 
+	defer rot.pushConfig(config)()
+
+	impl := rot.newAPLActionImpl(config)
+	if impl == nil {
+		return nil
+	}
+
+	condition := rot.coerceTo(rot.newAPLValue(config.Condition), proto.APLValueType_ValueTypeBool)
+	if config.Condition != nil {
+		if condition == nil {
+			// Already reported, but skip the action rather than performing it unconditionally.
+			return nil
+		} else if isConstAPLValue(condition) {
+			rot.validationWarning("Condition never changes, it is always %t", condition.GetBool(nil))
+		}
+	}
+
 	return &APLAction{
-		condition: unit.coerceTo(unit.newAPLValue(config.Condition), proto.APLValueType_ValueTypeBool),
-		impl:      unit.newAPLActionImpl(config),
+		condition: condition,
+		impl:      impl,
 	}
 }
 
-func (unit *Unit) newAPLActionImpl(config *proto.APLAction) APLActionImpl {
+func (rot *APLRotation) newAPLActionImpl(config *proto.APLAction) APLActionImpl {
 	if config == nil {
 		return nil
 	}
 
 	switch config.Action.(type) {
+	// Sequences
+	case *proto.APLAction_Sequence:
+		return rot.newActionSequence(config.GetSequence())
+	case *proto.APLAction_StrictSequence:
+		return rot.newActionStrictSequence(config.GetStrictSequence())
+
+	// Casting
 	case *proto.APLAction_CastSpell:
-		return unit.newActionCastSpell(config.GetCastSpell())
+		return rot.newActionCastSpell(config.GetCastSpell())
+	case *proto.APLAction_ChannelSpell:
+		return rot.newActionChannelSpell(config.GetChannelSpell())
+	case *proto.APLAction_Multidot:
+		return rot.newActionMultidot(config.GetMultidot())
+
+	// Auras
+	case *proto.APLAction_ActivateAura:
+		return rot.newActionActivateAura(config.GetActivateAura())
+	case *proto.APLAction_CancelAura:
+		return rot.newActionCancelAura(config.GetCancelAura())
+
+	// Misc
 	case *proto.APLAction_Wait:
-		return unit.newActionWait(config.GetWait())
+		return rot.newActionWait(config.GetWait())
+	case *proto.APLAction_WaitUntil:
+		return rot.newActionWaitUntil(config.GetWaitUntil())
+	case *proto.APLAction_TriggerItemSwap:
+		return rot.newActionTriggerItemSwap(config.GetTriggerItemSwap())
+	case *proto.APLAction_AutocastOtherCooldowns:
+		return rot.newActionAutocastOtherCooldowns(config.GetAutocastOtherCooldowns())
+
+	// Action lists
+	case *proto.APLAction_CallActionList:
+		return rot.newActionCallActionList(config.GetCallActionList())
+	case *proto.APLAction_RunActionList:
+		return rot.newActionRunActionList(config.GetRunActionList())
+
 	default:
-		validationError("Unimplemented action type")
+		rot.validationError("Unimplemented action type")
 		return nil
 	}
 }
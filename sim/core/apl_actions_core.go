@@ -6,36 +6,228 @@ import (
 	"github.com/wowsims/wotlk/sim/core/proto"
 )
 
+// Warns about spells which the unit will never be able to afford.
+func (rot *APLRotation) checkSpellCastable(spell *Spell) {
+	unit := rot.unit
+	cost := spell.DefaultCast.Cost
+
+	switch spell.Cost.(type) {
+	case *ManaCost:
+		if !unit.HasManaBar() || cost > unit.MaxMana() {
+			rot.validationWarning("%s can never be cast: costs %0.0f mana, but %s has %0.0f", spell.ActionID, cost, unit.Label, unit.MaxMana())
+		}
+	case *RageCost:
+		if !unit.HasRageBar() || cost > MaxRage {
+			rot.validationWarning("%s can never be cast: %s does not have enough Rage", spell.ActionID, unit.Label)
+		}
+	case *EnergyCost:
+		if !unit.HasEnergyBar() || cost > unit.maxEnergy {
+			rot.validationWarning("%s can never be cast: %s does not have enough Energy", spell.ActionID, unit.Label)
+		}
+	case *FocusCost:
+		if !unit.HasFocusBar() {
+			rot.validationWarning("%s can never be cast: %s does not use Focus", spell.ActionID, unit.Label)
+		}
+	case *RuneCostImpl:
+		if !unit.HasRunicPowerBar() {
+			rot.validationWarning("%s can never be cast: %s does not use Runes", spell.ActionID, unit.Label)
+		}
+	}
+}
+
 type APLActionCastSpell struct {
+	defaultAPLActionImpl
 	spell *Spell
+
+	targetType proto.APLActionCastSpell_TargetType
+	target     *Unit // Only set for TargetIndexed.
 }
 
-func (unit *Unit) newActionCastSpell(config *proto.APLActionCastSpell) APLActionImpl {
-	spell := unit.GetSpell(ProtoToActionID(config.SpellId))
+func (rot *APLRotation) newActionCastSpell(config *proto.APLActionCastSpell) APLActionImpl {
+	spell := rot.aplGetSpell(config.SpellId)
 	if spell == nil {
-		validationWarning("No spell found for id: %s", ProtoToActionID(config.SpellId).String())
 		return nil
 	}
-	return &APLActionCastSpell{
-		spell: spell,
+
+	rot.checkSpellCastable(spell)
+	rot.castSpells = append(rot.castSpells, spell)
+
+	action := &APLActionCastSpell{
+		spell:      spell,
+		targetType: config.Target,
+	}
+	if config.Target == proto.APLActionCastSpell_TargetIndexed {
+		if config.TargetIndex < 0 || config.TargetIndex >= rot.unit.Env.GetNumTargets() {
+			rot.validationError("Invalid target index %d for %s", config.TargetIndex, spell.ActionID)
+		}
+		action.target = rot.unit.Env.GetTargetUnit(config.TargetIndex)
 	}
+	return action
+}
+func (action *APLActionCastSpell) getTarget() *Unit {
+	unit := action.spell.Unit
+	switch action.targetType {
+	case proto.APLActionCastSpell_TargetNext:
+		if unit.CurrentTarget.Type == EnemyUnit {
+			return unit.Env.NextTargetUnit(unit.CurrentTarget)
+		}
+	case proto.APLActionCastSpell_TargetIndexed:
+		return action.target
+	}
+	return unit.CurrentTarget
 }
 func (action *APLActionCastSpell) IsAvailable(sim *Simulation) bool {
-	return action.spell.CanCast(sim, action.spell.Unit.CurrentTarget)
+	return action.spell.CanCast(sim, action.getTarget())
 }
 func (action *APLActionCastSpell) Execute(sim *Simulation) {
-	action.spell.Cast(sim, action.spell.Unit.CurrentTarget)
+	action.spell.Cast(sim, action.getTarget())
+}
+
+type APLActionMultidot struct {
+	defaultAPLActionImpl
+	spell      *Spell
+	maxDots    int32
+	maxOverlap APLValue
+
+	nextTarget *Unit
+}
+
+func (rot *APLRotation) newActionMultidot(config *proto.APLActionMultidot) APLActionImpl {
+	spell := rot.aplGetSpell(config.SpellId)
+	if spell == nil {
+		return nil
+	}
+	if len(spell.dots) == 0 {
+		rot.validationError("%s has no dot which can be applied to each target", spell.ActionID)
+		return nil
+	}
+	if config.MaxDots <= 0 {
+		rot.validationWarning("Multidot max dots must be positive, got %d", config.MaxDots)
+		return nil
+	}
+
+	rot.checkSpellCastable(spell)
+	rot.castSpells = append(rot.castSpells, spell)
+	return &APLActionMultidot{
+		spell:      spell,
+		maxDots:    config.MaxDots,
+		maxOverlap: rot.coerceTo(rot.newAPLValue(config.MaxOverlap), proto.APLValueType_ValueTypeDuration),
+	}
+}
+func (action *APLActionMultidot) IsAvailable(sim *Simulation) bool {
+	maxOverlap := time.Duration(0)
+	if action.maxOverlap != nil {
+		maxOverlap = action.maxOverlap.GetDuration(sim)
+	}
+
+	targets := sim.Encounter.ActiveTargetUnits
+	for _, target := range targets[:MinInt(int(action.maxDots), len(targets))] {
+		dot := action.spell.Dot(target)
+		if (!dot.IsActive() || dot.RemainingDuration(sim) <= maxOverlap) && action.spell.CanCast(sim, target) {
+			action.nextTarget = target
+			return true
+		}
+	}
+	return false
+}
+func (action *APLActionMultidot) Execute(sim *Simulation) {
+	action.spell.Cast(sim, action.nextTarget)
+}
+
+type APLActionChannelSpell struct {
+	defaultAPLActionImpl
+	spell       *Spell
+	interruptIf APLValue
+
+	checkAction *PendingAction
+}
+
+func (rot *APLRotation) newActionChannelSpell(config *proto.APLActionChannelSpell) APLActionImpl {
+	spell := rot.aplGetSpell(config.SpellId)
+	if spell == nil {
+		return nil
+	}
+	if spell.DefaultCast.ChannelTime == 0 {
+		rot.validationError("%s is not a channeled spell", spell.ActionID)
+	}
+	rot.checkSpellCastable(spell)
+	rot.castSpells = append(rot.castSpells, spell)
+	return &APLActionChannelSpell{
+		spell:       spell,
+		interruptIf: rot.coerceTo(rot.newAPLValue(config.InterruptIf), proto.APLValueType_ValueTypeBool),
+	}
+}
+func (action *APLActionChannelSpell) IsAvailable(sim *Simulation) bool {
+	return action.spell.CanCast(sim, action.spell.Unit.CurrentTarget)
+}
+func (action *APLActionChannelSpell) Execute(sim *Simulation) {
+	spell := action.spell
+	unit := spell.Unit
+	if !spell.Cast(sim, unit.CurrentTarget) || action.interruptIf == nil {
+		return
+	}
+
+	dot := spell.AOEDot()
+	if dot == nil {
+		dot = spell.CurDot()
+	}
+	if dot == nil || !dot.IsActive() || dot.NumberOfTicks <= 1 {
+		return
+	}
+
+	gcdReadyAt := sim.CurrentTime
+	if spell.CurCast.GCD != 0 {
+		gcdReadyAt += MaxDuration(GCDMin, spell.CurCast.GCD)
+	}
+	channelEndsAt := unit.Hardcast.Expires
+
+	if action.checkAction != nil {
+		action.checkAction.Cancel(sim)
+	}
+	// Low priority so the check happens after the tick at the same timestamp.
+	var checkAction *PendingAction
+	checkAction = NewPeriodicAction(sim, PeriodicActionOptions{
+		Period:   dot.TickPeriod(),
+		NumTicks: int(dot.NumberOfTicks) - 1,
+		Priority: ActionPriorityLow,
+		OnAction: func(sim *Simulation) {
+			if unit.Hardcast.Expires != channelEndsAt || !dot.IsActive() {
+				checkAction.Cancel(sim)
+				return
+			}
+			if action.interruptIf.GetBool(sim) {
+				if sim.Log != nil {
+					unit.Log(sim, "Interrupting channel of %s", spell.ActionID)
+				}
+				checkAction.Cancel(sim)
+				dot.Cancel(sim)
+				unit.Hardcast.Expires = sim.CurrentTime
+				unit.SetGCDTimer(sim, MaxDuration(sim.CurrentTime, gcdReadyAt))
+			}
+		},
+	})
+	action.checkAction = checkAction
+	sim.AddPendingAction(checkAction)
+}
+func (action *APLActionChannelSpell) Reset(sim *Simulation) {
+	action.checkAction = nil
 }
 
 type APLActionWait struct {
+	defaultAPLActionImpl
 	unit     *Unit
 	duration time.Duration
 }
 
-func (unit *Unit) newActionWait(config *proto.APLActionWait) APLActionImpl {
+func (rot *APLRotation) newActionWait(config *proto.APLActionWait) APLActionImpl {
+	duration := DurationFromProto(config.Duration)
+	if duration <= 0 {
+		rot.validationWarning("Wait duration must be positive, got %s", duration)
+		return nil
+	}
 	return &APLActionWait{
-		unit:     unit,
-		duration: DurationFromProto(config.Duration),
+		unit:     rot.unit,
+		duration: duration,
 	}
 }
 func (action *APLActionWait) IsAvailable(sim *Simulation) bool {
@@ -44,3 +236,29 @@ func (action *APLActionWait) IsAvailable(sim *Simulation) bool {
 func (action *APLActionWait) Execute(sim *Simulation) {
 	action.unit.WaitUntil(sim, sim.CurrentTime+action.duration)
 }
+
+// How often a wait_until action re-checks its condition.
+const aplWaitUntilPollInterval = time.Millisecond * 50
+
+type APLActionWaitUntil struct {
+	defaultAPLActionImpl
+	unit      *Unit
+	condition APLValue
+}
+
+func (rot *APLRotation) newActionWaitUntil(config *proto.APLActionWaitUntil) APLActionImpl {
+	condition := rot.coerceTo(rot.newAPLValue(config.Condition), proto.APLValueType_ValueTypeBool)
+	if condition == nil {
+		rot.validationError("Wait Until requires a condition")
+	}
+	return &APLActionWaitUntil{
+		unit:      rot.unit,
+		condition: condition,
+	}
+}
+func (action *APLActionWaitUntil) IsAvailable(sim *Simulation) bool {
+	return !action.condition.GetBool(sim)
+}
+func (action *APLActionWaitUntil) Execute(sim *Simulation) {
+	action.unit.WaitUntil(sim, sim.CurrentTime+aplWaitUntilPollInterval)
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+type APLActionList struct {
+	name    string
+	actions []*APLAction
+}
+
+func (list *APLActionList) reset(sim *Simulation) {
+	for _, action := range list.actions {
+		action.Reset(sim)
+	}
+}
+
+type APLActionCallActionList struct {
+	defaultAPLActionImpl
+	list *APLActionList
+
+	// Action found by the most recent call to IsAvailable().
+	nextAction *APLAction
+}
+
+func (rot *APLRotation) newActionCallActionList(config *proto.APLActionCallActionList) APLActionImpl {
+	return &APLActionCallActionList{
+		list: rot.getActionList(config.Name),
+	}
+}
+func (action *APLActionCallActionList) IsAvailable(sim *Simulation) bool {
+	action.nextAction = getNextAvailableAction(sim, action.list.actions)
+	return action.nextAction != nil
+}
+func (action *APLActionCallActionList) Execute(sim *Simulation) {
+	action.nextAction.Execute(sim)
+}
+
+type APLActionRunActionList struct {
+	defaultAPLActionImpl
+	rot  *APLRotation
+	list *APLActionList
+}
+
+func (rot *APLRotation) newActionRunActionList(config *proto.APLActionRunActionList) APLActionImpl {
+	return &APLActionRunActionList{
+		rot:  rot,
+		list: rot.getActionList(config.Name),
+	}
+}
+func (action *APLActionRunActionList) IsAvailable(sim *Simulation) bool {
+	return true
+}
+func (action *APLActionRunActionList) Execute(sim *Simulation) {
+	if nextAction := getNextAvailableAction(sim, action.list.actions); nextAction != nil {
+		nextAction.Execute(sim)
+	} else {
+		action.rot.doNoAvailableActions(sim)
+	}
+}
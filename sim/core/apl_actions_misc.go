@@ -0,0 +1,107 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+type APLActionActivateAura struct {
+	defaultAPLActionImpl
+	aura *Aura
+}
+
+func (rot *APLRotation) newActionActivateAura(config *proto.APLActionActivateAura) APLActionImpl {
+	aura := rot.aplGetAura(config.AuraId, false)
+	if aura == nil {
+		return nil
+	}
+	return &APLActionActivateAura{
+		aura: aura,
+	}
+}
+func (action *APLActionActivateAura) IsAvailable(sim *Simulation) bool {
+	return !action.aura.IsActive()
+}
+func (action *APLActionActivateAura) Execute(sim *Simulation) {
+	action.aura.Activate(sim)
+}
+
+type APLActionCancelAura struct {
+	defaultAPLActionImpl
+	aura *Aura
+}
+
+func (rot *APLRotation) newActionCancelAura(config *proto.APLActionCancelAura) APLActionImpl {
+	aura := rot.aplGetAura(config.AuraId, false)
+	if aura == nil {
+		return nil
+	}
+	return &APLActionCancelAura{
+		aura: aura,
+	}
+}
+func (action *APLActionCancelAura) IsAvailable(sim *Simulation) bool {
+	return action.aura.IsActive()
+}
+func (action *APLActionCancelAura) Execute(sim *Simulation) {
+	action.aura.Deactivate(sim)
+}
+
+type APLActionTriggerItemSwap struct {
+	defaultAPLActionImpl
+	character *Character
+	swapSet   proto.APLActionTriggerItemSwap_SwapSet
+}
+
+func (rot *APLRotation) newActionTriggerItemSwap(config *proto.APLActionTriggerItemSwap) APLActionImpl {
+	agent := rot.unit.Env.Raid.GetPlayerFromUnitIndex(rot.unit.UnitIndex)
+	if agent == nil || !agent.GetCharacter().ItemSwap.IsEnabled() {
+		rot.validationWarning("%s does not have item swap enabled", rot.unit.Label)
+		return nil
+	}
+	return &APLActionTriggerItemSwap{
+		character: agent.GetCharacter(),
+		swapSet:   config.SwapSet,
+	}
+}
+func (action *APLActionTriggerItemSwap) IsAvailable(sim *Simulation) bool {
+	return action.character.ItemSwap.IsSwapped() != (action.swapSet == proto.APLActionTriggerItemSwap_Swap1)
+}
+func (action *APLActionTriggerItemSwap) Execute(sim *Simulation) {
+	action.character.ItemSwap.SwapItems(sim, []proto.ItemSlot{
+		proto.ItemSlot_ItemSlotMainHand,
+		proto.ItemSlot_ItemSlotOffHand,
+		proto.ItemSlot_ItemSlotRanged,
+	}, false)
+}
+
+type APLActionAutocastOtherCooldowns struct {
+	defaultAPLActionImpl
+	character *Character
+
+	// TryUseCooldowns may not use any MCDs, so only attempt once per timestamp to
+	// let the rotation move on to the next action.
+	lastExecutedAt time.Duration
+}
+
+func (rot *APLRotation) newActionAutocastOtherCooldowns(config *proto.APLActionAutocastOtherCooldowns) APLActionImpl {
+	agent := rot.unit.Env.Raid.GetPlayerFromUnitIndex(rot.unit.UnitIndex)
+	if agent == nil {
+		rot.validationWarning("%s does not have major cooldowns", rot.unit.Label)
+		return nil
+	}
+	return &APLActionAutocastOtherCooldowns{
+		character: agent.GetCharacter(),
+	}
+}
+func (action *APLActionAutocastOtherCooldowns) Reset(*Simulation) {
+	action.lastExecutedAt = -1
+}
+func (action *APLActionAutocastOtherCooldowns) IsAvailable(sim *Simulation) bool {
+	return action.lastExecutedAt != sim.CurrentTime && sim.CurrentTime >= action.character.minReady
+}
+func (action *APLActionAutocastOtherCooldowns) Execute(sim *Simulation) {
+	action.lastExecutedAt = sim.CurrentTime
+	action.character.TryUseCooldowns(sim)
+}
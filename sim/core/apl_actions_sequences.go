@@ -0,0 +1,110 @@
+package core
+
+import (
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func (rot *APLRotation) newAPLSubactions(configs []*proto.APLAction) []*APLAction {
+	subactions := MapSlice(configs, func(config *proto.APLAction) *APLAction {
+		return rot.newAPLAction(config)
+	})
+	return FilterSlice(subactions, func(action *APLAction) bool { return action != nil })
+}
+
+type APLActionSequence struct {
+	subactions []*APLAction
+	curIdx     int
+}
+
+func (rot *APLRotation) newActionSequence(config *proto.APLActionSequence) APLActionImpl {
+	subactions := rot.newAPLSubactions(config.Actions)
+	if len(subactions) == 0 {
+		return nil
+	}
+	return &APLActionSequence{
+		subactions: subactions,
+	}
+}
+func (action *APLActionSequence) IsAvailable(sim *Simulation) bool {
+	return action.curIdx < len(action.subactions) && action.subactions[action.curIdx].IsAvailable(sim)
+}
+func (action *APLActionSequence) Execute(sim *Simulation) {
+	action.subactions[action.curIdx].Execute(sim)
+	action.curIdx++
+}
+func (action *APLActionSequence) Reset(sim *Simulation) {
+	action.curIdx = 0
+	for _, subaction := range action.subactions {
+		subaction.Reset(sim)
+	}
+}
+
+type APLActionStrictSequence struct {
+	unit       *Unit
+	subactions []*APLAction
+	curIdx     int
+
+	// Spells cast by this sequence, which must all be ready before starting.
+	spells []*Spell
+}
+
+func (rot *APLRotation) newActionStrictSequence(config *proto.APLActionStrictSequence) APLActionImpl {
+	subactions := rot.newAPLSubactions(config.Actions)
+	if len(subactions) == 0 {
+		return nil
+	}
+
+	var spells []*Spell
+	for i, subaction := range subactions {
+		if subaction.condition != nil {
+			rot.validationWarning("Action %d has a condition, the sequence will wait until it is true", i)
+		}
+		switch impl := subaction.impl.(type) {
+		case *APLActionCastSpell:
+			spells = append(spells, impl.spell)
+		case *APLActionChannelSpell:
+			spells = append(spells, impl.spell)
+		}
+	}
+
+	return &APLActionStrictSequence{
+		unit:       rot.unit,
+		subactions: subactions,
+		spells:     spells,
+	}
+}
+func (action *APLActionStrictSequence) IsAvailable(sim *Simulation) bool {
+	if action.curIdx > 0 {
+		// Already started, so only the next step matters.
+		return action.subactions[action.curIdx].IsAvailable(sim)
+	}
+
+	if !action.subactions[0].IsAvailable(sim) {
+		return false
+	}
+	for _, spell := range action.spells {
+		if !spell.IsReady(sim) {
+			return false
+		}
+	}
+	return true
+}
+func (action *APLActionStrictSequence) Execute(sim *Simulation) {
+	rotation := action.unit.Rotation
+	if action.curIdx == 0 {
+		rotation.controllingAction = action
+	}
+
+	action.subactions[action.curIdx].Execute(sim)
+	action.curIdx++
+	if action.curIdx == len(action.subactions) {
+		action.curIdx = 0
+		rotation.controllingAction = nil
+	}
+}
+func (action *APLActionStrictSequence) Reset(sim *Simulation) {
+	action.curIdx = 0
+	for _, subaction := range action.subactions {
+		subaction.Reset(sim)
+	}
+}
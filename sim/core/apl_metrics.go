@@ -0,0 +1,57 @@
+package core
+
+import (
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Execution counts for a single APL list item, summed over all iterations.
+type aplListItemMetrics struct {
+	evaluations   int
+	conditionTrue int
+	executions    int
+}
+
+// Starts recording list item metrics if they were requested in the sim options.
+func (apl *APLRotation) init(sim *Simulation) {
+	if !sim.Options.AplMetrics {
+		return
+	}
+	for _, item := range apl.listItems {
+		item.metrics = &aplListItemMetrics{}
+	}
+}
+
+func (apl *APLRotation) doneIteration(sim *Simulation) {
+	apl.metricsIterations++
+}
+
+// Adds the metrics of other, which must be the same rotation in another Simulation.
+func (apl *APLRotation) mergeMetrics(other *APLRotation) {
+	apl.metricsIterations += other.metricsIterations
+	for i, item := range apl.listItems {
+		if item.metrics == nil || i >= len(other.listItems) || other.listItems[i].metrics == nil {
+			continue
+		}
+		otherMetrics := other.listItems[i].metrics
+		item.metrics.evaluations += otherMetrics.evaluations
+		item.metrics.conditionTrue += otherMetrics.conditionTrue
+		item.metrics.executions += otherMetrics.executions
+	}
+}
+
+// Returns the recorded list item metrics, or nil if none were recorded.
+func (apl *APLRotation) getMetricsProto() []*proto.APLListItemMetrics {
+	if apl.metricsIterations == 0 || len(apl.listItems) == 0 || apl.listItems[0].metrics == nil {
+		return nil
+	}
+
+	n := float64(apl.metricsIterations)
+	return MapSlice(apl.listItems, func(item *APLAction) *proto.APLListItemMetrics {
+		return &proto.APLListItemMetrics{
+			Path:             item.path,
+			EvaluationsAvg:   float64(item.metrics.evaluations) / n,
+			ConditionTrueAvg: float64(item.metrics.conditionTrue) / n,
+			ExecutionsAvg:    float64(item.metrics.executions) / n,
+		}
+	})
+}
@@ -0,0 +1,480 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+func callListAPLItem(name string) *proto.APLListItem {
+	return &proto.APLListItem{Action: &proto.APLAction{
+		Action: &proto.APLAction_CallActionList{CallActionList: &proto.APLActionCallActionList{Name: name}},
+	}}
+}
+
+func variableAPLValue(name string) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Variable{Variable: &proto.APLValueVariable{Name: name}}}
+}
+
+// Checks that validations contains exactly 1 entry, with the given type, path and message.
+func expectAPLValidation(t *testing.T, validations []*proto.APLValidation, validationType proto.APLValidation_APLValidationType, path string, contains string) {
+	t.Helper()
+	if len(validations) != 1 {
+		t.Fatalf("Expected 1 validation, got: %v", validations)
+	}
+	validation := validations[0]
+	if validation.ValidationType != validationType || validation.Path != path || !strings.Contains(validation.Validation, contains) {
+		t.Fatalf("Expected %s at %s containing %q, got: %v", validationType, path, contains, validation)
+	}
+}
+
+func TestAPLActionListCycle(t *testing.T) {
+	config := &proto.APLRotation{
+		Enabled:      true,
+		PriorityList: []*proto.APLListItem{callListAPLItem("a")},
+		ActionLists: []*proto.APLActionList{
+			{Name: "a", Items: []*proto.APLListItem{callListAPLItem("b")}},
+			{Name: "b", Items: []*proto.APLListItem{callListAPLItem("a")}},
+		},
+	}
+
+	rot := (&Unit{}).newAPLRotation(config)
+	if len(rot.validations.ListItems) != 1 || rot.validations.ListItems[0].Path != "action_lists[1].items[0]" {
+		t.Fatalf("Unexpected validations: %v", rot.validations)
+	}
+	expectAPLValidation(t, rot.validations.ListItems[0].Validations, proto.APLValidation_ValidationTypeError, "action_lists[1].items[0].action", "a -> b -> a")
+}
+
+func TestAPLUnknownActionList(t *testing.T) {
+	config := &proto.APLRotation{
+		Enabled:      true,
+		PriorityList: []*proto.APLListItem{callListAPLItem("missing")},
+	}
+
+	rot := (&Unit{}).newAPLRotation(config)
+	if len(rot.priorityList) != 0 || len(rot.validations.ListItems) != 1 {
+		t.Fatalf("Expected invalid item to be skipped, got validations: %v", rot.validations)
+	}
+	expectAPLValidation(t, rot.validations.ListItems[0].Validations, proto.APLValidation_ValidationTypeError, "priority_list[0].action", "Unknown action list: missing")
+}
+
+func TestAPLVariables(t *testing.T) {
+	config := &proto.APLRotation{
+		Enabled: true,
+		Variables: []*proto.APLVariable{
+			{Name: "x", Value: constAPLValue("2")},
+			{Name: "y", Value: &proto.APLValue{Value: &proto.APLValue_Math{Math: &proto.APLValueMath{
+				Op:  proto.APLValueMath_OpMul,
+				Lhs: variableAPLValue("x"),
+				Rhs: constAPLValue("3"),
+			}}}},
+		},
+	}
+
+	rot := (&Unit{}).newAPLRotation(config)
+	if val := rot.getVariable("y").GetInt(&Simulation{}); val != 6 {
+		t.Fatalf("Unexpected variable value: %d", val)
+	}
+}
+
+func TestAPLVariableCycle(t *testing.T) {
+	config := &proto.APLRotation{
+		Enabled: true,
+		Variables: []*proto.APLVariable{
+			{Name: "x", Value: variableAPLValue("y")},
+			{Name: "y", Value: variableAPLValue("x")},
+		},
+	}
+
+	rot := (&Unit{}).newAPLRotation(config)
+	expectAPLValidation(t, rot.validations.Rotation, proto.APLValidation_ValidationTypeError, "variables[1].value", "x -> y -> x")
+}
+
+func TestAPLValidationWarnings(t *testing.T) {
+	config := &proto.APLRotation{
+		Enabled: true,
+		PriorityList: []*proto.APLListItem{
+			{Action: &proto.APLAction{
+				Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: ActionID{SpellID: 123}.ToProto()}},
+			}},
+			{Action: &proto.APLAction{
+				Condition: &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+					Op:  proto.APLValueCompare_OpLt,
+					Lhs: constAPLValue("1"),
+					Rhs: constAPLValue("2"),
+				}}},
+				Action: &proto.APLAction_Wait{Wait: &proto.APLActionWait{Duration: &proto.Duration{Ms: 1000}}},
+			}},
+		},
+	}
+
+	rot := (&Unit{}).newAPLRotation(config)
+	if len(rot.validations.ListItems) != 2 {
+		t.Fatalf("Unexpected validations: %v", rot.validations)
+	}
+	expectAPLValidation(t, rot.validations.ListItems[0].Validations, proto.APLValidation_ValidationTypeWarning, "priority_list[0].action", "No spell found")
+	expectAPLValidation(t, rot.validations.ListItems[1].Validations, proto.APLValidation_ValidationTypeWarning, "priority_list[1].action", "always true")
+	if len(rot.priorityList) != 1 {
+		t.Fatalf("Expected only the wait action to be kept, got %d actions", len(rot.priorityList))
+	}
+}
+
+func TestAPLListItemMetrics(t *testing.T) {
+	waitItem := func(condition *proto.APLValue) *proto.APLListItem {
+		return &proto.APLListItem{Action: &proto.APLAction{
+			Condition: condition,
+			Action:    &proto.APLAction_Wait{Wait: &proto.APLActionWait{Duration: &proto.Duration{Ms: 1000}}},
+		}}
+	}
+	config := &proto.APLRotation{
+		Enabled:      true,
+		PriorityList: []*proto.APLListItem{callListAPLItem("a")},
+		ActionLists: []*proto.APLActionList{
+			{Name: "a", Items: []*proto.APLListItem{
+				waitItem(variableAPLValue("never")),
+				waitItem(nil),
+			}},
+		},
+		Variables: []*proto.APLVariable{
+			{Name: "never", Value: &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+				Op:  proto.APLValueCompare_OpGt,
+				Lhs: constAPLValue("1"),
+				Rhs: constAPLValue("2"),
+			}}}},
+		},
+	}
+
+	rot := (&Unit{}).newAPLRotation(config)
+	sim := &Simulation{Options: &proto.SimOptions{AplMetrics: true}}
+	rot.init(sim)
+	for i := 0; i < 2; i++ {
+		if rot.getNextAction(sim) == nil {
+			t.Fatalf("Expected an available action")
+		}
+		rot.doneIteration(sim)
+	}
+
+	expected := []*proto.APLListItemMetrics{
+		{Path: "action_lists[0].items[0]", EvaluationsAvg: 1},
+		{Path: "action_lists[0].items[1]", EvaluationsAvg: 1, ConditionTrueAvg: 1},
+		{Path: "priority_list[0]", EvaluationsAvg: 1, ConditionTrueAvg: 1},
+	}
+	metrics := rot.getMetricsProto()
+	if len(metrics) != len(expected) {
+		t.Fatalf("Unexpected metrics: %v", metrics)
+	}
+	for i, item := range metrics {
+		if !googleProto.Equal(item, expected[i]) {
+			t.Fatalf("Expected %v, got %v", expected[i], item)
+		}
+	}
+}
+
+func castAPLAction(spellID int32) *proto.APLAction {
+	return &proto.APLAction{Action: &proto.APLAction_CastSpell{CastSpell: &proto.APLActionCastSpell{SpellId: ActionID{SpellID: spellID}.ToProto()}}}
+}
+
+func currentTimeAPLValue(op proto.APLValueCompare_ComparisonOperator, val string) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+		Op:  op,
+		Lhs: &proto.APLValue{Value: &proto.APLValue_CurrentTime{CurrentTime: &proto.APLValueCurrentTime{}}},
+		Rhs: constAPLValue(val),
+	}}}
+}
+
+// Runs the fake sim with the given rotation, after registering spells / auras
+// with setup. Returns a log of the spells which were cast.
+func runAPLFakeSim(t *testing.T, config *proto.APLRotation, until time.Duration, setup func(fa *FakeAgent, log *[]string)) (*Simulation, *FakeAgent, []string) {
+	t.Helper()
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+
+	var log []string
+	if setup != nil {
+		setup(fa, &log)
+	}
+	fa.Rotation = fa.newAPLRotation(config)
+
+	sim.Reset()
+	sim.PrePull()
+	sim.runPendingActions(until)
+	return sim, fa, log
+}
+
+// Registers a spell with a 1.5s GCD, which logs each cast as "<label>@<time>".
+func registerAPLTestSpell(fa *FakeAgent, log *[]string, spellID int32, label string, config SpellConfig) *Spell {
+	config.ActionID = ActionID{SpellID: spellID}
+	config.ProcMask = ProcMaskEmpty
+	config.Flags |= SpellFlagAPL
+	config.Cast.DefaultCast.GCD = GCDDefault
+	config.ApplyEffects = func(sim *Simulation, target *Unit, spell *Spell) {
+		*log = append(*log, fmt.Sprintf("%s@%s", label, sim.CurrentTime))
+	}
+	return fa.RegisterSpell(config)
+}
+
+func expectAPLCasts(t *testing.T, log []string, expected ...string) {
+	t.Helper()
+	if strings.Join(log, " ") != strings.Join(expected, " ") {
+		t.Fatalf("Expected casts %v, got %v", expected, log)
+	}
+}
+
+func TestAPLSequence(t *testing.T) {
+	config := &proto.APLRotation{
+		Enabled: true,
+		PriorityList: []*proto.APLListItem{
+			{Action: &proto.APLAction{Action: &proto.APLAction_Sequence{Sequence: &proto.APLActionSequence{
+				Actions: []*proto.APLAction{castAPLAction(100), castAPLAction(101)},
+			}}}},
+			{Action: castAPLAction(102)},
+		},
+	}
+
+	_, _, log := runAPLFakeSim(t, config, time.Second*5, func(fa *FakeAgent, log *[]string) {
+		registerAPLTestSpell(fa, log, 100, "A", SpellConfig{})
+		registerAPLTestSpell(fa, log, 101, "B", SpellConfig{})
+		registerAPLTestSpell(fa, log, 102, "C", SpellConfig{})
+	})
+	// Each action is used once, after which the sequence is skipped until reset.
+	expectAPLCasts(t, log, "A@0s", "B@1.5s", "C@3s", "C@4.5s")
+}
+
+func TestAPLStrictSequence(t *testing.T) {
+	config := &proto.APLRotation{
+		Enabled: true,
+		PriorityList: []*proto.APLListItem{
+			{Action: &proto.APLAction{Action: &proto.APLAction_StrictSequence{StrictSequence: &proto.APLActionStrictSequence{
+				Actions: []*proto.APLAction{castAPLAction(100), castAPLAction(101)},
+			}}}},
+			{Action: castAPLAction(102)},
+		},
+	}
+
+	_, _, log := runAPLFakeSim(t, config, time.Second*5, func(fa *FakeAgent, log *[]string) {
+		registerAPLTestSpell(fa, log, 100, "A", SpellConfig{
+			Cast: CastConfig{CD: Cooldown{Timer: fa.NewTimer(), Duration: time.Second * 20}},
+		})
+		registerAPLTestSpell(fa, log, 101, "B", SpellConfig{
+			ExtraCastCondition: func(sim *Simulation, target *Unit) bool {
+				return sim.CurrentTime >= time.Millisecond*2500
+			},
+		})
+		registerAPLTestSpell(fa, log, 102, "C", SpellConfig{})
+	})
+	// The sequence keeps control while B isn't available, instead of casting C.
+	expectAPLCasts(t, log, "A@0s", "B@2.5s", "C@4s")
+}
+
+func TestAPLStrictSequenceConditionWarning(t *testing.T) {
+	action := castAPLAction(100)
+	action.Condition = currentTimeAPLValue(proto.APLValueCompare_OpGt, "1s")
+	config := &proto.APLRotation{
+		Enabled: true,
+		PriorityList: []*proto.APLListItem{
+			{Action: &proto.APLAction{Action: &proto.APLAction_StrictSequence{StrictSequence: &proto.APLActionStrictSequence{
+				Actions: []*proto.APLAction{action},
+			}}}},
+		},
+	}
+
+	_, fa, _ := runAPLFakeSim(t, config, 0, func(fa *FakeAgent, log *[]string) {
+		registerAPLTestSpell(fa, log, 100, "A", SpellConfig{})
+	})
+	validations := fa.Rotation.validations.ListItems
+	if len(validations) != 1 {
+		t.Fatalf("Unexpected validations: %v", validations)
+	}
+	expectAPLValidation(t, validations[0].Validations, proto.APLValidation_ValidationTypeWarning, "priority_list[0].action", "wait until it is true")
+}
+
+func TestAPLWaitWithoutDuration(t *testing.T) {
+	config := &proto.APLRotation{
+		Enabled: true,
+		PriorityList: []*proto.APLListItem{
+			{Action: &proto.APLAction{Action: &proto.APLAction_Wait{Wait: &proto.APLActionWait{}}}},
+			{Action: castAPLAction(100)},
+		},
+	}
+
+	_, fa, log := runAPLFakeSim(t, config, time.Second*2, func(fa *FakeAgent, log *[]string) {
+		registerAPLTestSpell(fa, log, 100, "A", SpellConfig{})
+	})
+	validations := fa.Rotation.validations.ListItems
+	if len(validations) != 1 {
+		t.Fatalf("Unexpected validations: %v", validations)
+	}
+	expectAPLValidation(t, validations[0].Validations, proto.APLValidation_ValidationTypeWarning, "priority_list[0].action", "must be positive")
+	expectAPLCasts(t, log, "A@0s", "A@1.5s")
+}
+
+func TestAPLWaitUntil(t *testing.T) {
+	config := &proto.APLRotation{
+		Enabled: true,
+		PriorityList: []*proto.APLListItem{
+			{Action: &proto.APLAction{Action: &proto.APLAction_WaitUntil{WaitUntil: &proto.APLActionWaitUntil{
+				Condition: currentTimeAPLValue(proto.APLValueCompare_OpGe, "1s"),
+			}}}},
+			{Action: castAPLAction(100)},
+		},
+	}
+
+	_, _, log := runAPLFakeSim(t, config, time.Second*3, func(fa *FakeAgent, log *[]string) {
+		registerAPLTestSpell(fa, log, 100, "A", SpellConfig{})
+	})
+	expectAPLCasts(t, log, "A@1s", "A@2.5s")
+}
+
+func TestAPLChannelSpell(t *testing.T) {
+	config := &proto.APLRotation{
+		Enabled: true,
+		PriorityList: []*proto.APLListItem{
+			{Action: &proto.APLAction{Action: &proto.APLAction_ChannelSpell{ChannelSpell: &proto.APLActionChannelSpell{
+				SpellId: ActionID{SpellID: 100}.ToProto(),
+				InterruptIf: &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+					Op:  proto.APLValueCompare_OpLe,
+					Lhs: &proto.APLValue{Value: &proto.APLValue_DotTicksRemaining{DotTicksRemaining: &proto.APLValueDotTicksRemaining{SpellId: ActionID{SpellID: 100}.ToProto()}}},
+					Rhs: constAPLValue("1"),
+				}}},
+			}}}},
+			{Action: castAPLAction(101)},
+		},
+	}
+
+	numTicks := 0
+	_, _, log := runAPLFakeSim(t, config, time.Second*3, func(fa *FakeAgent, log *[]string) {
+		channel := registerAPLTestSpell(fa, log, 100, "Channel", SpellConfig{
+			Cast: CastConfig{
+				CD:          Cooldown{Timer: fa.NewTimer(), Duration: time.Second * 20},
+				DefaultCast: Cast{ChannelTime: time.Second * 3},
+			},
+			Dot: DotConfig{
+				Aura:          Aura{Label: "Channel"},
+				NumberOfTicks: 3,
+				TickLength:    time.Second,
+				OnTick: func(sim *Simulation, target *Unit, dot *Dot) {
+					numTicks++
+				},
+			},
+		})
+		applyEffects := channel.ApplyEffects
+		channel.ApplyEffects = func(sim *Simulation, target *Unit, spell *Spell) {
+			applyEffects(sim, target, spell)
+			spell.Dot(target).Apply(sim)
+		}
+		registerAPLTestSpell(fa, log, 101, "A", SpellConfig{})
+	})
+	// Interrupted after the 2nd tick, once only 1 tick is left.
+	expectAPLCasts(t, log, "Channel@0s", "A@2s")
+	if numTicks != 2 {
+		t.Fatalf("Expected 2 ticks, got %d", numTicks)
+	}
+}
+
+func TestAPLMultidot(t *testing.T) {
+	config := &proto.APLRotation{
+		Enabled: true,
+		PriorityList: []*proto.APLListItem{
+			{Action: &proto.APLAction{Action: &proto.APLAction_Multidot{Multidot: &proto.APLActionMultidot{
+				SpellId:    ActionID{SpellID: 100}.ToProto(),
+				MaxDots:    1,
+				MaxOverlap: constAPLValue("1s"),
+			}}}},
+			{Action: castAPLAction(101)},
+		},
+	}
+
+	_, _, log := runAPLFakeSim(t, config, time.Second*7, func(fa *FakeAgent, log *[]string) {
+		dot := registerAPLTestSpell(fa, log, 100, "Dot", SpellConfig{
+			Dot: DotConfig{
+				Aura:          Aura{Label: "Dot"},
+				NumberOfTicks: 4,
+				TickLength:    time.Second,
+				OnTick:        func(sim *Simulation, target *Unit, dot *Dot) {},
+			},
+		})
+		applyEffects := dot.ApplyEffects
+		dot.ApplyEffects = func(sim *Simulation, target *Unit, spell *Spell) {
+			applyEffects(sim, target, spell)
+			spell.Dot(target).Apply(sim)
+		}
+		registerAPLTestSpell(fa, log, 101, "A", SpellConfig{})
+	})
+	// The dot is reapplied once it has no more than 1s remaining.
+	expectAPLCasts(t, log, "Dot@0s", "A@1.5s", "Dot@3s", "A@4.5s", "Dot@6s")
+}
+
+func TestAPLAuraActions(t *testing.T) {
+	auraId := ActionID{SpellID: 200}.ToProto()
+	config := &proto.APLRotation{
+		Enabled: true,
+		PriorityList: []*proto.APLListItem{
+			{Action: &proto.APLAction{
+				Condition: currentTimeAPLValue(proto.APLValueCompare_OpLt, "1s"),
+				Action:    &proto.APLAction_ActivateAura{ActivateAura: &proto.APLActionActivateAura{AuraId: auraId}},
+			}},
+			{Action: &proto.APLAction{
+				Condition: currentTimeAPLValue(proto.APLValueCompare_OpGe, "1s"),
+				Action:    &proto.APLAction_CancelAura{CancelAura: &proto.APLActionCancelAura{AuraId: auraId}},
+			}},
+			{Action: castAPLAction(100)},
+		},
+	}
+
+	_, _, log := runAPLFakeSim(t, config, time.Second*2, func(fa *FakeAgent, log *[]string) {
+		fa.RegisterAura(Aura{
+			Label:    "Buff",
+			ActionID: ActionID{SpellID: 200},
+			Duration: NeverExpires,
+			OnGain: func(aura *Aura, sim *Simulation) {
+				*log = append(*log, fmt.Sprintf("Gain@%s", sim.CurrentTime))
+			},
+			OnExpire: func(aura *Aura, sim *Simulation) {
+				*log = append(*log, fmt.Sprintf("Expire@%s", sim.CurrentTime))
+			},
+		})
+		registerAPLTestSpell(fa, log, 100, "A", SpellConfig{})
+	})
+	// Aura actions don't use the GCD, so the cast happens at the same time.
+	expectAPLCasts(t, log, "Gain@0s", "A@0s", "Expire@1.5s", "A@1.5s")
+}
+
+func TestAPLTriggerItemSwap(t *testing.T) {
+	swapAction := func(swapSet proto.APLActionTriggerItemSwap_SwapSet, condition *proto.APLValue) *proto.APLListItem {
+		return &proto.APLListItem{Action: &proto.APLAction{
+			Condition: condition,
+			Action:    &proto.APLAction_TriggerItemSwap{TriggerItemSwap: &proto.APLActionTriggerItemSwap{SwapSet: swapSet}},
+		}}
+	}
+	config := &proto.APLRotation{
+		Enabled: true,
+		PriorityList: []*proto.APLListItem{
+			swapAction(proto.APLActionTriggerItemSwap_Swap1, currentTimeAPLValue(proto.APLValueCompare_OpLt, "1s")),
+			swapAction(proto.APLActionTriggerItemSwap_Main, currentTimeAPLValue(proto.APLValueCompare_OpGe, "1s")),
+			{Action: castAPLAction(100)},
+		},
+	}
+
+	setup := func(fa *FakeAgent, log *[]string) {
+		fa.EnableItemSwap(&proto.ItemSwap{}, 1, 1, 1)
+		registerAPLTestSpell(fa, log, 100, "A", SpellConfig{})
+	}
+	if _, fa, _ := runAPLFakeSim(t, config, time.Second, setup); !fa.ItemSwap.IsSwapped() {
+		t.Fatalf("Expected to be swapped to the first item set")
+	}
+	if _, fa, _ := runAPLFakeSim(t, config, time.Second*2, setup); fa.ItemSwap.IsSwapped() {
+		t.Fatalf("Expected to be swapped back to the main item set")
+	}
+
+	// Without item swap there's nothing to trigger, so the actions are dropped.
+	_, fa, _ := runAPLFakeSim(t, config, 0, func(fa *FakeAgent, log *[]string) {
+		registerAPLTestSpell(fa, log, 100, "A", SpellConfig{})
+	})
+	if len(fa.Rotation.priorityList) != 1 {
+		t.Fatalf("Expected only the cast action to be kept, got %d actions", len(fa.Rotation.priorityList))
+	}
+}
@@ -0,0 +1,804 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Compact text format for APL rotations, modeled after SimC action lists:
+//
+//	# Comments directly above an item become its notes.
+//	prepull=cast_spell,id=48441,at=-1s
+//	variable.execute=target.health.pct<35
+//	actions=cast_spell,id=47465,if=!dot.47465.active&mana.pct>20
+//	actions+=/call_action_list,name=aoe,if=variable.execute
+//	actions.aoe=cast_spell,id=item:40211
+//
+// Unlike SimC, "actions=" never clears a list, it appends just like "actions+=/".
+// See apl_text_format.go for the reverse conversion.
+
+var aplTextNameRegex = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Values which don't take any parameters, keyed by their text name.
+var aplTextSimpleValues = map[string]func() *proto.APLValue{
+	"time": func() *proto.APLValue {
+		return &proto.APLValue{Value: &proto.APLValue_CurrentTime{CurrentTime: &proto.APLValueCurrentTime{}}}
+	},
+	"remaining_time": func() *proto.APLValue {
+		return &proto.APLValue{Value: &proto.APLValue_RemainingTime{RemainingTime: &proto.APLValueRemainingTime{}}}
+	},
+	"target.health.pct": func() *proto.APLValue {
+		return &proto.APLValue{Value: &proto.APLValue_TargetHealthPercent{TargetHealthPercent: &proto.APLValueTargetHealthPercent{}}}
+	},
+	"active_enemies": func() *proto.APLValue {
+		return &proto.APLValue{Value: &proto.APLValue_NumberTargets{NumberTargets: &proto.APLValueNumberTargets{}}}
+	},
+	"mana": func() *proto.APLValue {
+		return &proto.APLValue{Value: &proto.APLValue_CurrentMana{CurrentMana: &proto.APLValueCurrentMana{}}}
+	},
+	"health.pct": func() *proto.APLValue {
+		return &proto.APLValue{Value: &proto.APLValue_CurrentHealthPercent{CurrentHealthPercent: &proto.APLValueCurrentHealthPercent{}}}
+	},
+	"mana.pct": func() *proto.APLValue {
+		return &proto.APLValue{Value: &proto.APLValue_CurrentManaPercent{CurrentManaPercent: &proto.APLValueCurrentManaPercent{}}}
+	},
+	"rage": func() *proto.APLValue {
+		return &proto.APLValue{Value: &proto.APLValue_CurrentRage{CurrentRage: &proto.APLValueCurrentRage{}}}
+	},
+	"energy": func() *proto.APLValue {
+		return &proto.APLValue{Value: &proto.APLValue_CurrentEnergy{CurrentEnergy: &proto.APLValueCurrentEnergy{}}}
+	},
+	"focus": func() *proto.APLValue {
+		return &proto.APLValue{Value: &proto.APLValue_CurrentFocus{CurrentFocus: &proto.APLValueCurrentFocus{}}}
+	},
+	"combo_points": func() *proto.APLValue {
+		return &proto.APLValue{Value: &proto.APLValue_CurrentComboPoints{CurrentComboPoints: &proto.APLValueCurrentComboPoints{}}}
+	},
+	"runic_power": func() *proto.APLValue {
+		return &proto.APLValue{Value: &proto.APLValue_CurrentRunicPower{CurrentRunicPower: &proto.APLValueCurrentRunicPower{}}}
+	},
+	"runes.blood":  func() *proto.APLValue { return aplTextRuneCount(proto.APLValueCurrentRuneCount_RuneBlood) },
+	"runes.frost":  func() *proto.APLValue { return aplTextRuneCount(proto.APLValueCurrentRuneCount_RuneFrost) },
+	"runes.unholy": func() *proto.APLValue { return aplTextRuneCount(proto.APLValueCurrentRuneCount_RuneUnholy) },
+	"runes.death":  func() *proto.APLValue { return aplTextRuneCount(proto.APLValueCurrentRuneCount_RuneDeath) },
+}
+
+func aplTextRuneCount(runeType proto.APLValueCurrentRuneCount_RuneType) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_CurrentRuneCount{CurrentRuneCount: &proto.APLValueCurrentRuneCount{RuneType: runeType}}}
+}
+
+// Parses a rotation in the text format described above. The result is always enabled.
+func APLRotationFromTextString(text string) (*proto.APLRotation, error) {
+	rotation := &proto.APLRotation{Enabled: true}
+	lists := make(map[string]*proto.APLActionList)
+	var notes []string
+
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			notes = nil
+			continue
+		} else if strings.HasPrefix(line, "#") {
+			notes = append(notes, strings.TrimSpace(strings.TrimPrefix(line, "#")))
+			continue
+		}
+
+		if err := parseAPLTextLine(rotation, lists, line, strings.Join(notes, "\n")); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		notes = nil
+	}
+
+	return rotation, nil
+}
+
+func parseAPLTextLine(rotation *proto.APLRotation, lists map[string]*proto.APLActionList, line string, notes string) error {
+	eqIdx := strings.Index(line, "=")
+	if eqIdx == -1 {
+		return fmt.Errorf("expected key=value, got %q", line)
+	}
+	key, value := line[:eqIdx], line[eqIdx+1:]
+	if strings.HasSuffix(key, "+") {
+		key = strings.TrimSuffix(key, "+")
+		value = strings.TrimPrefix(value, "/")
+	}
+
+	switch {
+	case key == "actions":
+		item, err := parseAPLTextListItem(value, notes)
+		if err != nil {
+			return err
+		}
+		rotation.PriorityList = append(rotation.PriorityList, item)
+	case strings.HasPrefix(key, "actions."):
+		name := strings.TrimPrefix(key, "actions.")
+		if !aplTextNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid action list name %q", name)
+		}
+		list, ok := lists[name]
+		if !ok {
+			list = &proto.APLActionList{Name: name}
+			lists[name] = list
+			rotation.ActionLists = append(rotation.ActionLists, list)
+		}
+		if value == "" {
+			// Allows declaring an empty list.
+			return nil
+		}
+		item, err := parseAPLTextListItem(value, notes)
+		if err != nil {
+			return err
+		}
+		list.Items = append(list.Items, item)
+	case strings.HasPrefix(key, "variable."):
+		name := strings.TrimPrefix(key, "variable.")
+		if !aplTextNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid variable name %q", name)
+		}
+		variable := &proto.APLVariable{Name: name}
+		if value != "" {
+			val, err := parseAPLTextValue(value)
+			if err != nil {
+				return err
+			}
+			variable.Value = val
+		}
+		rotation.Variables = append(rotation.Variables, variable)
+	case key == "prepull":
+		fields, err := splitAPLTextFields(value, ',')
+		if err != nil {
+			return err
+		}
+		at, fields := takeAPLTextField(fields, "at")
+		action, err := parseAPLTextAction(fields)
+		if err != nil {
+			return err
+		}
+		prepull := &proto.APLPrepullAction{Action: action}
+		if at != "" {
+			if prepull.DoAt, err = parseAPLTextDuration(at); err != nil {
+				return err
+			}
+		}
+		rotation.PrepullActions = append(rotation.PrepullActions, prepull)
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	return nil
+}
+
+func parseAPLTextListItem(text string, notes string) (*proto.APLListItem, error) {
+	fields, err := splitAPLTextFields(text, ',')
+	if err != nil {
+		return nil, err
+	}
+	hide, fields := takeAPLTextField(fields, "hide")
+	action, err := parseAPLTextAction(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.APLListItem{
+		Hide:   hide == "1" || hide == "true",
+		Notes:  notes,
+		Action: action,
+	}, nil
+}
+
+// Removes the key=value field with the given key, returning its value and the remaining fields.
+func takeAPLTextField(fields []string, key string) (string, []string) {
+	for i, field := range fields {
+		if strings.HasPrefix(field, key+"=") {
+			return strings.TrimPrefix(field, key+"="), append(fields[:i:i], fields[i+1:]...)
+		}
+	}
+	return "", fields
+}
+
+// Splits text on sep, ignoring separators inside parens, braces or quotes.
+func splitAPLTextFields(text string, sep rune) ([]string, error) {
+	var fields []string
+	depth := 0
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case inQuotes:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inQuotes = false
+			}
+		case c == '"':
+			inQuotes = true
+		case c == '(' || c == '{':
+			depth++
+		case c == ')' || c == '}':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced %q in %q", c, text)
+			}
+		case rune(c) == sep && depth == 0:
+			fields = append(fields, strings.TrimSpace(text[start:i]))
+			start = i + 1
+		}
+	}
+	if depth != 0 || inQuotes {
+		return nil, fmt.Errorf("unterminated group or string in %q", text)
+	}
+	return append(fields, strings.TrimSpace(text[start:])), nil
+}
+
+type aplTextArgs map[string]string
+
+// Removes and returns the given option.
+func (args aplTextArgs) take(key string) string {
+	val := args[key]
+	delete(args, key)
+	return val
+}
+
+func parseAPLTextAction(fields []string) (*proto.APLAction, error) {
+	if len(fields) == 0 || fields[0] == "" || strings.Contains(fields[0], "=") {
+		return nil, fmt.Errorf("missing action name")
+	}
+	name := fields[0]
+
+	args := make(aplTextArgs)
+	for _, field := range fields[1:] {
+		eqIdx := strings.Index(field, "=")
+		if eqIdx == -1 {
+			return nil, fmt.Errorf("expected key=value option for %s, got %q", name, field)
+		}
+		key := field[:eqIdx]
+		if _, ok := args[key]; ok {
+			return nil, fmt.Errorf("duplicate option %q for %s", key, name)
+		}
+		args[key] = field[eqIdx+1:]
+	}
+
+	action := &proto.APLAction{}
+	if cond := args.take("if"); cond != "" {
+		val, err := parseAPLTextValue(cond)
+		if err != nil {
+			return nil, err
+		}
+		action.Condition = val
+	}
+
+	var err error
+	switch name {
+	case "sequence":
+		seq := &proto.APLActionSequence{}
+		seq.Actions, err = parseAPLTextSubactions(args.take("actions"))
+		action.Action = &proto.APLAction_Sequence{Sequence: seq}
+	case "strict_sequence":
+		seq := &proto.APLActionStrictSequence{}
+		seq.Actions, err = parseAPLTextSubactions(args.take("actions"))
+		action.Action = &proto.APLAction_StrictSequence{StrictSequence: seq}
+	case "cast_spell":
+		cast := &proto.APLActionCastSpell{}
+		if cast.SpellId, err = parseAPLTextActionID(args.take("id")); err == nil {
+			switch target := args.take("target"); target {
+			case "":
+			case "next":
+				cast.Target = proto.APLActionCastSpell_TargetNext
+			default:
+				cast.Target = proto.APLActionCastSpell_TargetIndexed
+				cast.TargetIndex, err = parseAPLTextInt(target)
+			}
+		}
+		action.Action = &proto.APLAction_CastSpell{CastSpell: cast}
+	case "channel_spell":
+		channel := &proto.APLActionChannelSpell{}
+		if channel.SpellId, err = parseAPLTextActionID(args.take("id")); err == nil {
+			if interruptIf := args.take("interrupt_if"); interruptIf != "" {
+				channel.InterruptIf, err = parseAPLTextValue(interruptIf)
+			}
+		}
+		action.Action = &proto.APLAction_ChannelSpell{ChannelSpell: channel}
+	case "multidot":
+		multidot := &proto.APLActionMultidot{}
+		if multidot.SpellId, err = parseAPLTextActionID(args.take("id")); err == nil {
+			if maxDots := args.take("max_dots"); maxDots != "" {
+				multidot.MaxDots, err = parseAPLTextInt(maxDots)
+			}
+		}
+		if maxOverlap := args.take("max_overlap"); maxOverlap != "" && err == nil {
+			multidot.MaxOverlap, err = parseAPLTextValue(maxOverlap)
+		}
+		action.Action = &proto.APLAction_Multidot{Multidot: multidot}
+	case "activate_aura":
+		activate := &proto.APLActionActivateAura{}
+		activate.AuraId, err = parseAPLTextActionID(args.take("id"))
+		action.Action = &proto.APLAction_ActivateAura{ActivateAura: activate}
+	case "cancel_aura":
+		cancel := &proto.APLActionCancelAura{}
+		cancel.AuraId, err = parseAPLTextActionID(args.take("id"))
+		action.Action = &proto.APLAction_CancelAura{CancelAura: cancel}
+	case "wait":
+		wait := &proto.APLActionWait{}
+		if duration := args.take("duration"); duration != "" {
+			wait.Duration, err = parseAPLTextDuration(duration)
+		}
+		action.Action = &proto.APLAction_Wait{Wait: wait}
+	case "wait_until":
+		waitUntil := &proto.APLActionWaitUntil{}
+		if condition := args.take("condition"); condition != "" {
+			waitUntil.Condition, err = parseAPLTextValue(condition)
+		}
+		action.Action = &proto.APLAction_WaitUntil{WaitUntil: waitUntil}
+	case "trigger_item_swap":
+		swap := &proto.APLActionTriggerItemSwap{}
+		switch set := args.take("set"); set {
+		case "", "main":
+			swap.SwapSet = proto.APLActionTriggerItemSwap_Main
+		case "swap1":
+			swap.SwapSet = proto.APLActionTriggerItemSwap_Swap1
+		default:
+			err = fmt.Errorf("unknown item swap set %q", set)
+		}
+		action.Action = &proto.APLAction_TriggerItemSwap{TriggerItemSwap: swap}
+	case "autocast_other_cooldowns":
+		action.Action = &proto.APLAction_AutocastOtherCooldowns{AutocastOtherCooldowns: &proto.APLActionAutocastOtherCooldowns{}}
+	case "call_action_list":
+		action.Action = &proto.APLAction_CallActionList{CallActionList: &proto.APLActionCallActionList{Name: args.take("name")}}
+	case "run_action_list":
+		action.Action = &proto.APLAction_RunActionList{RunActionList: &proto.APLActionRunActionList{Name: args.take("name")}}
+	default:
+		return nil, fmt.Errorf("unknown action %q", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	for key := range args {
+		return nil, fmt.Errorf("unknown option %q for %s", key, name)
+	}
+	return action, nil
+}
+
+// Parses a list of actions in the form {action1;action2;...}.
+func parseAPLTextSubactions(text string) ([]*proto.APLAction, error) {
+	if !strings.HasPrefix(text, "{") || !strings.HasSuffix(text, "}") {
+		return nil, fmt.Errorf("expected actions={...}, got %q", text)
+	}
+	text = strings.TrimSpace(text[1 : len(text)-1])
+	if text == "" {
+		return nil, nil
+	}
+
+	subtexts, err := splitAPLTextFields(text, ';')
+	if err != nil {
+		return nil, err
+	}
+	actions := make([]*proto.APLAction, len(subtexts))
+	for i, subtext := range subtexts {
+		fields, err := splitAPLTextFields(subtext, ',')
+		if err != nil {
+			return nil, err
+		}
+		if actions[i], err = parseAPLTextAction(fields); err != nil {
+			return nil, err
+		}
+	}
+	return actions, nil
+}
+
+// Parses an action ID in the form [spell:|item:|other:]<id>[@tag].
+func parseAPLTextActionID(text string) (*proto.ActionID, error) {
+	if text == "" {
+		return nil, fmt.Errorf("missing id")
+	}
+
+	actionID := &proto.ActionID{}
+	if atIdx := strings.Index(text, "@"); atIdx != -1 {
+		tag, err := parseAPLTextInt(text[atIdx+1:])
+		if err != nil {
+			return nil, err
+		}
+		actionID.Tag = tag
+		text = text[:atIdx]
+	}
+
+	kind := "spell"
+	if colonIdx := strings.Index(text, ":"); colonIdx != -1 {
+		kind, text = text[:colonIdx], text[colonIdx+1:]
+	}
+	id, err := parseAPLTextInt(text)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case "spell":
+		actionID.RawId = &proto.ActionID_SpellId{SpellId: id}
+	case "item":
+		actionID.RawId = &proto.ActionID_ItemId{ItemId: id}
+	case "other":
+		actionID.RawId = &proto.ActionID_OtherId{OtherId: proto.OtherAction(id)}
+	default:
+		return nil, fmt.Errorf("unknown action id type %q", kind)
+	}
+	return actionID, nil
+}
+
+func parseAPLTextInt(text string) (int32, error) {
+	val, err := strconv.ParseInt(text, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer %q", text)
+	}
+	return int32(val), nil
+}
+
+func parseAPLTextDuration(text string) (*proto.Duration, error) {
+	dur, err := time.ParseDuration(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration %q", text)
+	}
+	return &proto.Duration{Ms: float64(dur) / float64(time.Millisecond)}, nil
+}
+
+///////////////////////////////////////////////////////////////////////////
+//                               EXPRESSIONS
+///////////////////////////////////////////////////////////////////////////
+
+type aplTextTokenKind int
+
+const (
+	aplTextTokenEOF aplTextTokenKind = iota
+	aplTextTokenNumber
+	aplTextTokenString
+	aplTextTokenIdent
+	aplTextTokenOp
+)
+
+type aplTextToken struct {
+	kind aplTextTokenKind
+	text string
+}
+
+func isAPLTextIdentChar(c byte) bool {
+	return c == '_' || c == '.' || c == ':' || c == '@' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// Number tokens include any duration suffix, e.g. "1.5s" or "1m30s".
+func isAPLTextNumberChar(c byte) bool {
+	return c == '.' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func tokenizeAPLTextExpr(text string) ([]aplTextToken, error) {
+	var tokens []aplTextToken
+	for i := 0; i < len(text); {
+		c := text[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+			continue
+		case c == '"':
+			for i++; i < len(text) && text[i] != '"'; i++ {
+				if text[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(text) {
+				return nil, fmt.Errorf("unterminated string in %q", text)
+			}
+			i++
+			str, err := strconv.Unquote(text[start:i])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s", text[start:i])
+			}
+			tokens = append(tokens, aplTextToken{kind: aplTextTokenString, text: str})
+			continue
+		case c == '.' || (c >= '0' && c <= '9'):
+			for i < len(text) && isAPLTextNumberChar(text[i]) {
+				i++
+			}
+			tokens = append(tokens, aplTextToken{kind: aplTextTokenNumber, text: text[start:i]})
+			continue
+		case isAPLTextIdentChar(c):
+			for i < len(text) && isAPLTextIdentChar(text[i]) {
+				i++
+			}
+			tokens = append(tokens, aplTextToken{kind: aplTextTokenIdent, text: text[start:i]})
+			continue
+		}
+
+		if i+1 < len(text) {
+			if op := text[i : i+2]; op == "!=" || op == "<=" || op == ">=" || op == "==" {
+				tokens = append(tokens, aplTextToken{kind: aplTextTokenOp, text: op})
+				i += 2
+				continue
+			}
+		}
+		if !strings.ContainsRune("!&|=<>+-*/(),", rune(c)) {
+			return nil, fmt.Errorf("unexpected character %q in %q", c, text)
+		}
+		tokens = append(tokens, aplTextToken{kind: aplTextTokenOp, text: string(c)})
+		i++
+	}
+	return tokens, nil
+}
+
+type aplTextExprParser struct {
+	tokens []aplTextToken
+	pos    int
+}
+
+func parseAPLTextValue(text string) (*proto.APLValue, error) {
+	tokens, err := tokenizeAPLTextExpr(text)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &aplTextExprParser{tokens: tokens}
+	value, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := parser.peek(); next.kind != aplTextTokenEOF {
+		return nil, fmt.Errorf("unexpected %q in %q", next.text, text)
+	}
+	return value, nil
+}
+
+func (parser *aplTextExprParser) peek() aplTextToken {
+	if parser.pos >= len(parser.tokens) {
+		return aplTextToken{kind: aplTextTokenEOF}
+	}
+	return parser.tokens[parser.pos]
+}
+
+// Consumes the next token if it is one of the given operators.
+func (parser *aplTextExprParser) acceptOp(ops ...string) (string, bool) {
+	next := parser.peek()
+	if next.kind != aplTextTokenOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if next.text == op {
+			parser.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (parser *aplTextExprParser) parseOr() (*proto.APLValue, error) {
+	vals, err := parser.parseList("|", parser.parseAnd)
+	if err != nil || len(vals) == 1 {
+		return vals[0], err
+	}
+	return &proto.APLValue{Value: &proto.APLValue_Or{Or: &proto.APLValueOr{Vals: vals}}}, nil
+}
+
+func (parser *aplTextExprParser) parseAnd() (*proto.APLValue, error) {
+	vals, err := parser.parseList("&", parser.parseCompare)
+	if err != nil || len(vals) == 1 {
+		return vals[0], err
+	}
+	return &proto.APLValue{Value: &proto.APLValue_And{And: &proto.APLValueAnd{Vals: vals}}}, nil
+}
+
+// Parses 1 or more operands separated by op.
+func (parser *aplTextExprParser) parseList(op string, parseOperand func() (*proto.APLValue, error)) ([]*proto.APLValue, error) {
+	var vals []*proto.APLValue
+	for {
+		val, err := parseOperand()
+		if err != nil {
+			return []*proto.APLValue{nil}, err
+		}
+		vals = append(vals, val)
+		if _, ok := parser.acceptOp(op); !ok {
+			return vals, nil
+		}
+	}
+}
+
+var aplTextCompareOps = map[string]proto.APLValueCompare_ComparisonOperator{
+	"=":  proto.APLValueCompare_OpEq,
+	"==": proto.APLValueCompare_OpEq,
+	"!=": proto.APLValueCompare_OpNe,
+	"<":  proto.APLValueCompare_OpLt,
+	"<=": proto.APLValueCompare_OpLe,
+	">":  proto.APLValueCompare_OpGt,
+	">=": proto.APLValueCompare_OpGe,
+}
+
+func (parser *aplTextExprParser) parseCompare() (*proto.APLValue, error) {
+	lhs, err := parser.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := parser.acceptOp("=", "==", "!=", "<", "<=", ">", ">=")
+	if !ok {
+		return lhs, nil
+	}
+	rhs, err := parser.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	return &proto.APLValue{Value: &proto.APLValue_Cmp{Cmp: &proto.APLValueCompare{
+		Op:  aplTextCompareOps[op],
+		Lhs: lhs,
+		Rhs: rhs,
+	}}}, nil
+}
+
+var aplTextMathOps = map[string]proto.APLValueMath_MathOperator{
+	"+": proto.APLValueMath_OpAdd,
+	"-": proto.APLValueMath_OpSub,
+	"*": proto.APLValueMath_OpMul,
+	"/": proto.APLValueMath_OpDiv,
+}
+
+func (parser *aplTextExprParser) parseSum() (*proto.APLValue, error) {
+	return parser.parseMath([]string{"+", "-"}, parser.parseProduct)
+}
+
+func (parser *aplTextExprParser) parseProduct() (*proto.APLValue, error) {
+	return parser.parseMath([]string{"*", "/"}, parser.parseUnary)
+}
+
+// Parses left-associative math operations using any of the given operators.
+func (parser *aplTextExprParser) parseMath(ops []string, parseOperand func() (*proto.APLValue, error)) (*proto.APLValue, error) {
+	lhs, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := parser.acceptOp(ops...)
+		if !ok {
+			return lhs, nil
+		}
+		rhs, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		lhs = &proto.APLValue{Value: &proto.APLValue_Math{Math: &proto.APLValueMath{
+			Op:  aplTextMathOps[op],
+			Lhs: lhs,
+			Rhs: rhs,
+		}}}
+	}
+}
+
+func (parser *aplTextExprParser) parseUnary() (*proto.APLValue, error) {
+	if _, ok := parser.acceptOp("!"); ok {
+		val, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &proto.APLValue{Value: &proto.APLValue_Not{Not: &proto.APLValueNot{Val: val}}}, nil
+	}
+	if _, ok := parser.acceptOp("-"); ok {
+		// Only negative literals are supported, use 0-x for anything else.
+		if next := parser.peek(); next.kind == aplTextTokenNumber {
+			parser.pos++
+			return aplTextConst("-" + next.text), nil
+		}
+		return nil, fmt.Errorf("expected number after '-'")
+	}
+	return parser.parsePrimary()
+}
+
+func (parser *aplTextExprParser) parsePrimary() (*proto.APLValue, error) {
+	next := parser.peek()
+	parser.pos++
+
+	switch next.kind {
+	case aplTextTokenNumber, aplTextTokenString:
+		return aplTextConst(next.text), nil
+	case aplTextTokenIdent:
+		if _, ok := parser.acceptOp("("); ok {
+			return parser.parseFunction(next.text)
+		}
+		return parseAPLTextIdent(next.text)
+	case aplTextTokenOp:
+		if next.text == "(" {
+			val, err := parser.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := parser.acceptOp(")"); !ok {
+				return nil, fmt.Errorf("expected ')'")
+			}
+			return val, nil
+		}
+		return nil, fmt.Errorf("unexpected %q", next.text)
+	}
+	return nil, fmt.Errorf("unexpected end of expression")
+}
+
+// Parses the arguments of a function call, after the opening paren.
+func (parser *aplTextExprParser) parseFunction(name string) (*proto.APLValue, error) {
+	var vals []*proto.APLValue
+	if _, ok := parser.acceptOp(")"); !ok {
+		for {
+			val, err := parser.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, val)
+			if _, ok := parser.acceptOp(")"); ok {
+				break
+			} else if _, ok := parser.acceptOp(","); !ok {
+				return nil, fmt.Errorf("expected ',' or ')' in %s()", name)
+			}
+		}
+	}
+
+	switch name {
+	case "max":
+		return &proto.APLValue{Value: &proto.APLValue_Max{Max: &proto.APLValueMax{Vals: vals}}}, nil
+	case "min":
+		return &proto.APLValue{Value: &proto.APLValue_Min{Min: &proto.APLValueMin{Vals: vals}}}, nil
+	}
+	return nil, fmt.Errorf("unknown function %q", name)
+}
+
+func aplTextConst(val string) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: val}}}
+}
+
+func parseAPLTextIdent(ident string) (*proto.APLValue, error) {
+	if newValue, ok := aplTextSimpleValues[ident]; ok {
+		return newValue(), nil
+	}
+
+	parts := strings.Split(ident, ".")
+	if len(parts) == 2 && parts[0] == "variable" {
+		return &proto.APLValue{Value: &proto.APLValue_Variable{Variable: &proto.APLValueVariable{Name: parts[1]}}}, nil
+	}
+
+	onTarget := false
+	if len(parts) == 4 && parts[0] == "target" && parts[1] == "aura" {
+		onTarget = true
+		parts = parts[1:]
+	}
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("unknown value %q", ident)
+	}
+
+	id, err := parseAPLTextActionID(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ident, err)
+	}
+
+	switch parts[0] + "." + parts[2] {
+	case "spell.ready":
+		return &proto.APLValue{Value: &proto.APLValue_SpellIsReady{SpellIsReady: &proto.APLValueSpellIsReady{SpellId: id}}}, nil
+	case "spell.time_to_ready":
+		return &proto.APLValue{Value: &proto.APLValue_SpellTimeToReady{SpellTimeToReady: &proto.APLValueSpellTimeToReady{SpellId: id}}}, nil
+	case "spell.cast_time":
+		return &proto.APLValue{Value: &proto.APLValue_SpellCastTime{SpellCastTime: &proto.APLValueSpellCastTime{SpellId: id}}}, nil
+	case "spell.can_cast":
+		return &proto.APLValue{Value: &proto.APLValue_SpellCanCast{SpellCanCast: &proto.APLValueSpellCanCast{SpellId: id}}}, nil
+	case "aura.active":
+		return &proto.APLValue{Value: &proto.APLValue_AuraIsActive{AuraIsActive: &proto.APLValueAuraIsActive{AuraId: id, OnTarget: onTarget}}}, nil
+	case "aura.stacks":
+		return &proto.APLValue{Value: &proto.APLValue_AuraNumStacks{AuraNumStacks: &proto.APLValueAuraNumStacks{AuraId: id, OnTarget: onTarget}}}, nil
+	case "aura.remains":
+		return &proto.APLValue{Value: &proto.APLValue_AuraRemainingTime{AuraRemainingTime: &proto.APLValueAuraRemainingTime{AuraId: id, OnTarget: onTarget}}}, nil
+	case "aura.icd":
+		if onTarget {
+			return nil, fmt.Errorf("%s: internal cooldowns are only supported for auras on the player", ident)
+		}
+		return &proto.APLValue{Value: &proto.APLValue_AuraInternalCooldown{AuraInternalCooldown: &proto.APLValueAuraInternalCooldown{AuraId: id}}}, nil
+	case "dot.active":
+		return &proto.APLValue{Value: &proto.APLValue_DotIsActive{DotIsActive: &proto.APLValueDotIsActive{SpellId: id}}}, nil
+	case "dot.remains":
+		return &proto.APLValue{Value: &proto.APLValue_DotRemainingTime{DotRemainingTime: &proto.APLValueDotRemainingTime{SpellId: id}}}, nil
+	case "dot.ticks_remaining":
+		return &proto.APLValue{Value: &proto.APLValue_DotTicksRemaining{DotTicksRemaining: &proto.APLValueDotTicksRemaining{SpellId: id}}}, nil
+	}
+	return nil, fmt.Errorf("unknown value %q", ident)
+}
@@ -0,0 +1,378 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Operator precedence in the text format, from loosest to tightest binding.
+const (
+	aplTextPrecOr = iota + 1
+	aplTextPrecAnd
+	aplTextPrecCompare
+	aplTextPrecSum
+	aplTextPrecProduct
+	aplTextPrecUnary
+	aplTextPrecPrimary
+)
+
+// Converts a rotation into the text format parsed by APLRotationFromTextString.
+func APLRotationToTextString(rotation *proto.APLRotation) (string, error) {
+	var sb strings.Builder
+
+	for _, prepull := range rotation.PrepullActions {
+		action, err := formatAPLTextAction(prepull.Action)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString("prepull=" + action)
+		if prepull.DoAt != nil {
+			sb.WriteString(",at=" + formatAPLTextDuration(prepull.DoAt))
+		}
+		sb.WriteString("\n")
+	}
+
+	for _, variable := range rotation.Variables {
+		value := ""
+		if variable.Value != nil {
+			var err error
+			if value, err = formatAPLTextValue(variable.Value); err != nil {
+				return "", fmt.Errorf("variable %s: %w", variable.Name, err)
+			}
+		}
+		sb.WriteString("variable." + variable.Name + "=" + value + "\n")
+	}
+
+	if err := formatAPLTextListItems(&sb, "actions", rotation.PriorityList); err != nil {
+		return "", err
+	}
+
+	for _, list := range rotation.ActionLists {
+		sb.WriteString("\n")
+		key := "actions." + list.Name
+		if len(list.Items) == 0 {
+			sb.WriteString(key + "=\n")
+		} else if err := formatAPLTextListItems(&sb, key, list.Items); err != nil {
+			return "", err
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func formatAPLTextListItems(sb *strings.Builder, key string, items []*proto.APLListItem) error {
+	for i, item := range items {
+		if item.Notes != "" {
+			if i > 0 {
+				// Keeps the notes from attaching to the previous item when re-parsed.
+				sb.WriteString("\n")
+			}
+			for _, line := range strings.Split(item.Notes, "\n") {
+				sb.WriteString(strings.TrimSpace("# "+line) + "\n")
+			}
+		}
+
+		action, err := formatAPLTextAction(item.Action)
+		if err != nil {
+			return fmt.Errorf("%s item %d: %w", key, i+1, err)
+		}
+		if i == 0 {
+			sb.WriteString(key + "=" + action)
+		} else {
+			sb.WriteString(key + "+=/" + action)
+		}
+		if item.Hide {
+			sb.WriteString(",hide=1")
+		}
+		sb.WriteString("\n")
+	}
+	return nil
+}
+
+func formatAPLTextAction(action *proto.APLAction) (string, error) {
+	if action == nil {
+		return "", fmt.Errorf("missing action")
+	}
+
+	fields := []string{}
+	addID := func(key string, id *proto.ActionID) {
+		if id != nil {
+			fields = append(fields, key+"="+formatAPLTextActionID(id))
+		}
+	}
+	addValue := func(key string, value *proto.APLValue) error {
+		if value == nil {
+			return nil
+		}
+		text, err := formatAPLTextValue(value)
+		if err == nil {
+			fields = append(fields, key+"="+text)
+		}
+		return err
+	}
+
+	var err error
+	switch a := action.Action.(type) {
+	case *proto.APLAction_Sequence:
+		fields = append(fields, "sequence")
+		err = addAPLTextSubactions(&fields, a.Sequence.Actions)
+	case *proto.APLAction_StrictSequence:
+		fields = append(fields, "strict_sequence")
+		err = addAPLTextSubactions(&fields, a.StrictSequence.Actions)
+	case *proto.APLAction_CastSpell:
+		fields = append(fields, "cast_spell")
+		addID("id", a.CastSpell.SpellId)
+		switch a.CastSpell.Target {
+		case proto.APLActionCastSpell_TargetNext:
+			fields = append(fields, "target=next")
+		case proto.APLActionCastSpell_TargetIndexed:
+			fields = append(fields, "target="+strconv.Itoa(int(a.CastSpell.TargetIndex)))
+		}
+	case *proto.APLAction_ChannelSpell:
+		fields = append(fields, "channel_spell")
+		addID("id", a.ChannelSpell.SpellId)
+		err = addValue("interrupt_if", a.ChannelSpell.InterruptIf)
+	case *proto.APLAction_Multidot:
+		fields = append(fields, "multidot")
+		addID("id", a.Multidot.SpellId)
+		fields = append(fields, "max_dots="+strconv.Itoa(int(a.Multidot.MaxDots)))
+		err = addValue("max_overlap", a.Multidot.MaxOverlap)
+	case *proto.APLAction_ActivateAura:
+		fields = append(fields, "activate_aura")
+		addID("id", a.ActivateAura.AuraId)
+	case *proto.APLAction_CancelAura:
+		fields = append(fields, "cancel_aura")
+		addID("id", a.CancelAura.AuraId)
+	case *proto.APLAction_Wait:
+		fields = append(fields, "wait")
+		if a.Wait.Duration != nil {
+			fields = append(fields, "duration="+formatAPLTextDuration(a.Wait.Duration))
+		}
+	case *proto.APLAction_WaitUntil:
+		fields = append(fields, "wait_until")
+		err = addValue("condition", a.WaitUntil.Condition)
+	case *proto.APLAction_TriggerItemSwap:
+		fields = append(fields, "trigger_item_swap")
+		if a.TriggerItemSwap.SwapSet == proto.APLActionTriggerItemSwap_Swap1 {
+			fields = append(fields, "set=swap1")
+		} else {
+			fields = append(fields, "set=main")
+		}
+	case *proto.APLAction_AutocastOtherCooldowns:
+		fields = append(fields, "autocast_other_cooldowns")
+	case *proto.APLAction_CallActionList:
+		fields = append(fields, "call_action_list", "name="+a.CallActionList.Name)
+	case *proto.APLAction_RunActionList:
+		fields = append(fields, "run_action_list", "name="+a.RunActionList.Name)
+	default:
+		return "", fmt.Errorf("unsupported action type %T", action.Action)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := addValue("if", action.Condition); err != nil {
+		return "", err
+	}
+	return strings.Join(fields, ","), nil
+}
+
+func addAPLTextSubactions(fields *[]string, actions []*proto.APLAction) error {
+	subtexts := make([]string, len(actions))
+	for i, action := range actions {
+		subtext, err := formatAPLTextAction(action)
+		if err != nil {
+			return err
+		}
+		subtexts[i] = subtext
+	}
+	*fields = append(*fields, "actions={"+strings.Join(subtexts, ";")+"}")
+	return nil
+}
+
+func formatAPLTextActionID(id *proto.ActionID) string {
+	var text string
+	switch rawID := id.RawId.(type) {
+	case *proto.ActionID_ItemId:
+		text = "item:" + strconv.Itoa(int(rawID.ItemId))
+	case *proto.ActionID_OtherId:
+		text = "other:" + strconv.Itoa(int(rawID.OtherId))
+	default:
+		text = strconv.Itoa(int(id.GetSpellId()))
+	}
+	if id.Tag != 0 {
+		text += "@" + strconv.Itoa(int(id.Tag))
+	}
+	return text
+}
+
+func formatAPLTextDuration(dur *proto.Duration) string {
+	return time.Duration(dur.Ms * float64(time.Millisecond)).String()
+}
+
+func formatAPLTextValue(value *proto.APLValue) (string, error) {
+	text, _, err := formatAPLTextValueWithPrec(value)
+	return text, err
+}
+
+// Formats value, adding parens if it binds more loosely than minPrec.
+func formatAPLTextOperand(value *proto.APLValue, minPrec int) (string, error) {
+	text, prec, err := formatAPLTextValueWithPrec(value)
+	if err != nil {
+		return "", err
+	}
+	if prec < minPrec {
+		return "(" + text + ")", nil
+	}
+	return text, nil
+}
+
+func formatAPLTextOperands(values []*proto.APLValue, minPrec int, sep string) (string, error) {
+	texts := make([]string, len(values))
+	for i, value := range values {
+		text, err := formatAPLTextOperand(value, minPrec)
+		if err != nil {
+			return "", err
+		}
+		texts[i] = text
+	}
+	return strings.Join(texts, sep), nil
+}
+
+// Returns the text for value, along with the precedence of its outermost operator.
+func formatAPLTextValueWithPrec(value *proto.APLValue) (string, int, error) {
+	if value == nil {
+		return "", 0, fmt.Errorf("missing value")
+	}
+
+	switch v := value.Value.(type) {
+	case *proto.APLValue_Const:
+		return formatAPLTextConst(v.Const.Val), aplTextPrecPrimary, nil
+	case *proto.APLValue_Variable:
+		return "variable." + v.Variable.Name, aplTextPrecPrimary, nil
+	case *proto.APLValue_Or:
+		text, err := formatAPLTextOperands(v.Or.Vals, aplTextPrecAnd, "|")
+		return text, aplTextPrecOr, err
+	case *proto.APLValue_And:
+		text, err := formatAPLTextOperands(v.And.Vals, aplTextPrecCompare, "&")
+		return text, aplTextPrecAnd, err
+	case *proto.APLValue_Not:
+		text, err := formatAPLTextOperand(v.Not.Val, aplTextPrecUnary)
+		return "!" + text, aplTextPrecUnary, err
+	case *proto.APLValue_Cmp:
+		op, ok := aplTextCompareOpText(v.Cmp.Op)
+		if !ok {
+			return "", 0, fmt.Errorf("comparison operator must be set")
+		}
+		return formatAPLTextBinary(v.Cmp.Lhs, v.Cmp.Rhs, op, aplTextPrecCompare, aplTextPrecSum, aplTextPrecSum)
+	case *proto.APLValue_Math:
+		switch v.Math.Op {
+		case proto.APLValueMath_OpAdd:
+			return formatAPLTextBinary(v.Math.Lhs, v.Math.Rhs, "+", aplTextPrecSum, aplTextPrecSum, aplTextPrecProduct)
+		case proto.APLValueMath_OpSub:
+			return formatAPLTextBinary(v.Math.Lhs, v.Math.Rhs, "-", aplTextPrecSum, aplTextPrecSum, aplTextPrecProduct)
+		case proto.APLValueMath_OpMul:
+			return formatAPLTextBinary(v.Math.Lhs, v.Math.Rhs, "*", aplTextPrecProduct, aplTextPrecProduct, aplTextPrecUnary)
+		case proto.APLValueMath_OpDiv:
+			return formatAPLTextBinary(v.Math.Lhs, v.Math.Rhs, "/", aplTextPrecProduct, aplTextPrecProduct, aplTextPrecUnary)
+		}
+		return "", 0, fmt.Errorf("math operator must be set")
+	case *proto.APLValue_Max:
+		text, err := formatAPLTextOperands(v.Max.Vals, aplTextPrecOr, ",")
+		return "max(" + text + ")", aplTextPrecPrimary, err
+	case *proto.APLValue_Min:
+		text, err := formatAPLTextOperands(v.Min.Vals, aplTextPrecOr, ",")
+		return "min(" + text + ")", aplTextPrecPrimary, err
+
+	case *proto.APLValue_SpellIsReady:
+		return "spell." + formatAPLTextActionID(v.SpellIsReady.SpellId) + ".ready", aplTextPrecPrimary, nil
+	case *proto.APLValue_SpellTimeToReady:
+		return "spell." + formatAPLTextActionID(v.SpellTimeToReady.SpellId) + ".time_to_ready", aplTextPrecPrimary, nil
+	case *proto.APLValue_SpellCastTime:
+		return "spell." + formatAPLTextActionID(v.SpellCastTime.SpellId) + ".cast_time", aplTextPrecPrimary, nil
+	case *proto.APLValue_SpellCanCast:
+		return "spell." + formatAPLTextActionID(v.SpellCanCast.SpellId) + ".can_cast", aplTextPrecPrimary, nil
+	case *proto.APLValue_AuraIsActive:
+		return formatAPLTextAura(v.AuraIsActive.AuraId, v.AuraIsActive.OnTarget, "active"), aplTextPrecPrimary, nil
+	case *proto.APLValue_AuraNumStacks:
+		return formatAPLTextAura(v.AuraNumStacks.AuraId, v.AuraNumStacks.OnTarget, "stacks"), aplTextPrecPrimary, nil
+	case *proto.APLValue_AuraRemainingTime:
+		return formatAPLTextAura(v.AuraRemainingTime.AuraId, v.AuraRemainingTime.OnTarget, "remains"), aplTextPrecPrimary, nil
+	case *proto.APLValue_AuraInternalCooldown:
+		return formatAPLTextAura(v.AuraInternalCooldown.AuraId, false, "icd"), aplTextPrecPrimary, nil
+	case *proto.APLValue_DotIsActive:
+		return "dot." + formatAPLTextActionID(v.DotIsActive.SpellId) + ".active", aplTextPrecPrimary, nil
+	case *proto.APLValue_DotRemainingTime:
+		return "dot." + formatAPLTextActionID(v.DotRemainingTime.SpellId) + ".remains", aplTextPrecPrimary, nil
+	case *proto.APLValue_DotTicksRemaining:
+		return "dot." + formatAPLTextActionID(v.DotTicksRemaining.SpellId) + ".ticks_remaining", aplTextPrecPrimary, nil
+	}
+
+	// Everything else has no parameters, so just find it in the parse table.
+	for name, newValue := range aplTextSimpleValues {
+		if googleProto.Equal(newValue(), value) {
+			return name, aplTextPrecPrimary, nil
+		}
+	}
+	return "", 0, fmt.Errorf("unsupported value type %T", value.Value)
+}
+
+func formatAPLTextBinary(lhs, rhs *proto.APLValue, op string, prec int, lhsPrec int, rhsPrec int) (string, int, error) {
+	lhsText, err := formatAPLTextOperand(lhs, lhsPrec)
+	if err != nil {
+		return "", 0, err
+	}
+	rhsText, err := formatAPLTextOperand(rhs, rhsPrec)
+	if err != nil {
+		return "", 0, err
+	}
+	return lhsText + op + rhsText, prec, nil
+}
+
+func aplTextCompareOpText(op proto.APLValueCompare_ComparisonOperator) (string, bool) {
+	switch op {
+	case proto.APLValueCompare_OpEq:
+		return "=", true
+	case proto.APLValueCompare_OpNe:
+		return "!=", true
+	case proto.APLValueCompare_OpLt:
+		return "<", true
+	case proto.APLValueCompare_OpLe:
+		return "<=", true
+	case proto.APLValueCompare_OpGt:
+		return ">", true
+	case proto.APLValueCompare_OpGe:
+		return ">=", true
+	}
+	return "", false
+}
+
+func formatAPLTextAura(auraID *proto.ActionID, onTarget bool, property string) string {
+	text := "aura." + formatAPLTextActionID(auraID) + "." + property
+	if onTarget {
+		text = "target." + text
+	}
+	return text
+}
+
+// Numbers and durations are written as-is, anything else is quoted.
+func formatAPLTextConst(val string) string {
+	digits := strings.TrimPrefix(val, "-")
+	if digits != "" && (digits[0] == '.' || (digits[0] >= '0' && digits[0] <= '9')) {
+		isNumber := true
+		for i := 0; i < len(digits); i++ {
+			if !isAPLTextNumberChar(digits[i]) {
+				isNumber = false
+			}
+		}
+		if isNumber {
+			return val
+		}
+	}
+	return strconv.Quote(val)
+}
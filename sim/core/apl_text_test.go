@@ -0,0 +1,109 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Already in canonical form, so formatting the parsed rotation should reproduce it exactly.
+const testAPLText = `prepull=cast_spell,id=48441,at=-1.5s
+variable.execute=target.health.pct<35|remaining_time<=10s
+variable.pool=max(mana.pct,energy*2,min(rage,runic_power))
+# Keep the dot up.
+# Multiple lines are kept too.
+actions=cast_spell,id=47465,if=!dot.47465.active&mana.pct>20
+actions+=/cast_spell,id=item:40211,target=next,if=aura.item:40211@2.stacks=0|target.aura.other:3.remains<1.5s
+actions+=/cast_spell,id=12,target=1,hide=1
+actions+=/channel_spell,id=48156,interrupt_if=dot.48156.ticks_remaining<=1,if=(spell.48156.ready|variable.execute)&spell.48156.cast_time<2s
+actions+=/multidot,id=48125,max_dots=3,max_overlap=1s,if=spell.48125.can_cast&aura.71905.icd<2s&health.pct>50
+actions+=/sequence,actions={cast_spell,id=1;cast_spell,id=2,if=runes.blood>=1}
+actions+=/strict_sequence,actions={activate_aura,id=3;cancel_aura,id=4}
+actions+=/wait_until,condition=spell.5.time_to_ready-1s*2<=(time-1s)/2
+actions+=/trigger_item_swap,set=swap1,if=focus>combo_points-(runes.frost-runes.unholy)
+actions+=/wait,duration=500ms,if=runes.death!="abc"&!!aura.6.active&rage>-5
+actions+=/autocast_other_cooldowns
+
+# Notes on a later item.
+actions+=/call_action_list,name=aoe
+actions+=/run_action_list,name=empty
+
+actions.aoe=cast_spell,id=7,if=variable.pool>50&active_enemies>=3
+
+actions.empty=
+`
+
+func TestAPLTextRoundTrip(t *testing.T) {
+	rotation, err := APLRotationFromTextString(testAPLText)
+	if err != nil {
+		t.Fatalf("Failed to parse: %s", err)
+	}
+	if !rotation.Enabled || len(rotation.PriorityList) != 13 || len(rotation.ActionLists) != 2 || len(rotation.Variables) != 2 || len(rotation.PrepullActions) != 1 {
+		t.Fatalf("Unexpected rotation: %v", rotation)
+	}
+	if notes := rotation.PriorityList[0].Notes; notes != "Keep the dot up.\nMultiple lines are kept too." {
+		t.Fatalf("Unexpected notes: %q", notes)
+	}
+
+	text, err := APLRotationToTextString(rotation)
+	if err != nil {
+		t.Fatalf("Failed to format: %s", err)
+	}
+	if text != testAPLText {
+		t.Fatalf("Formatted text does not match.\nExpected:\n%s\nActual:\n%s", testAPLText, text)
+	}
+
+	reparsed, err := APLRotationFromTextString(text)
+	if err != nil {
+		t.Fatalf("Failed to reparse: %s", err)
+	}
+	if !googleProto.Equal(rotation, reparsed) {
+		t.Fatalf("Reparsed rotation does not match")
+	}
+}
+
+func TestAPLTextMatchesJson(t *testing.T) {
+	rotation, err := APLRotationFromTextString("actions=cast_spell,id=47465,if=!dot.47465.active&mana.pct>20")
+	if err != nil {
+		t.Fatalf("Failed to parse: %s", err)
+	}
+
+	expected := APLRotationFromJsonString(`{
+		"enabled": true,
+		"priorityList": [
+			{"action": {
+				"condition": {"and": {"vals": [
+					{"not": {"val": {"dotIsActive": {"spellId": {"spellId": 47465}}}}},
+					{"cmp": {"op": "OpGt", "lhs": {"currentManaPercent": {}}, "rhs": {"const": {"val": "20"}}}}
+				]}},
+				"castSpell": {"spellId": {"spellId": 47465}}
+			}}
+		]
+	}`)
+	if !googleProto.Equal(rotation, expected) {
+		t.Fatalf("Parsed rotation does not match json: %v", rotation)
+	}
+}
+
+func TestAPLTextErrors(t *testing.T) {
+	for _, tc := range []struct {
+		text     string
+		expected string
+	}{
+		{"actions=cast_spell", "missing id"},
+		{"actions=fly,id=1", "unknown action"},
+		{"actions=cast_spell,id=1,foo=2", "unknown option"},
+		{"actions=cast_spell,id=1,if=mana>", "unexpected end"},
+		{"actions=cast_spell,id=1,if=(mana>1", "unterminated"},
+		{"actions=cast_spell,id=1,if=spell.1.bogus", "unknown value"},
+		{"actions=cast_spell,id=1,if=target.aura.2.icd>0", "only supported for auras on the player"},
+		{"actions=multidot,id=1,max_dots=x", "invalid integer"},
+		{"\n\nactions=wait,if=mana>>1", "line 3"},
+		{"nonsense", "expected key=value"},
+	} {
+		if _, err := APLRotationFromTextString(tc.text); err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("Parsing %q: expected error containing %q, got %v", tc.text, tc.expected, err)
+		}
+	}
+}
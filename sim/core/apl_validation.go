@@ -0,0 +1,148 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Validations found while compiling a single list item or variable.
+type aplValidationGroup struct {
+	owner       protoreflect.ProtoMessage
+	validations []aplValidation
+}
+
+type aplValidation struct {
+	validationType proto.APLValidation_APLValidationType
+	message        string
+
+	// The config message which was being compiled when this was found.
+	config protoreflect.ProtoMessage
+}
+
+// Panic value used by validationError to abort compilation of the current
+// list item. Recovered by doWithValidation.
+type aplValidationError struct {
+	message string
+}
+
+func (err aplValidationError) Error() string {
+	return "Validation Error: " + err.message
+}
+
+// Marks config as the message currently being compiled, until the returned function is called.
+func (rot *APLRotation) pushConfig(config protoreflect.ProtoMessage) func() {
+	rot.configStack = append(rot.configStack, config)
+	return func() {
+		rot.configStack = rot.configStack[:len(rot.configStack)-1]
+	}
+}
+
+func (rot *APLRotation) addValidation(validationType proto.APLValidation_APLValidationType, message string) {
+	validation := aplValidation{
+		validationType: validationType,
+		message:        message,
+	}
+	if len(rot.configStack) > 0 {
+		validation.config = rot.configStack[len(rot.configStack)-1]
+	}
+
+	if len(rot.curGroups) == 0 {
+		// Only happens when compiling values directly, e.g. in tests.
+		rot.curGroups = append(rot.curGroups, &aplValidationGroup{})
+		rot.validationGroups = append(rot.validationGroups, rot.curGroups[0])
+	}
+	group := rot.curGroups[len(rot.curGroups)-1]
+	group.validations = append(group.validations, validation)
+}
+
+// Records an error which makes the current list item unusable, and aborts compiling it.
+func (rot *APLRotation) validationError(message string, vals ...interface{}) {
+	err := aplValidationError{message: fmt.Sprintf(message, vals...)}
+	rot.addValidation(proto.APLValidation_ValidationTypeError, err.message)
+	panic(err)
+}
+
+// Records an issue which the sim can work around, usually by ignoring the offending value or action.
+func (rot *APLRotation) validationWarning(message string, vals ...interface{}) {
+	rot.addValidation(proto.APLValidation_ValidationTypeWarning, fmt.Sprintf(message, vals...))
+}
+
+// Runs compile with a new validation group for owner, recovering from any validation errors.
+func (rot *APLRotation) doWithValidation(owner protoreflect.ProtoMessage, compile func()) {
+	group := &aplValidationGroup{owner: owner}
+	rot.validationGroups = append(rot.validationGroups, group)
+	rot.curGroups = append(rot.curGroups, group)
+	defer rot.pushConfig(owner)()
+
+	defer func() {
+		rot.curGroups = rot.curGroups[:len(rot.curGroups)-1]
+		if r := recover(); r != nil {
+			if _, ok := r.(aplValidationError); !ok {
+				panic(r)
+			}
+		}
+	}()
+
+	compile()
+}
+
+// Converts the validations found while compiling into their proto form, with
+// paths relative to the rotation config (see collectAPLConfigPaths).
+func (rot *APLRotation) finalizeValidations(paths map[protoreflect.ProtoMessage]string) *proto.APLRotationValidations {
+	result := &proto.APLRotationValidations{}
+	for _, group := range rot.validationGroups {
+		if len(group.validations) == 0 {
+			continue
+		}
+
+		validations := MapSlice(group.validations, func(validation aplValidation) *proto.APLValidation {
+			return &proto.APLValidation{
+				ValidationType: validation.validationType,
+				Validation:     validation.message,
+				Path:           paths[validation.config],
+			}
+		})
+
+		if _, ok := group.owner.(*proto.APLListItem); ok {
+			result.ListItems = append(result.ListItems, &proto.APLListItemValidations{
+				Path:        paths[group.owner],
+				Validations: validations,
+			})
+		} else {
+			result.Rotation = append(result.Rotation, validations...)
+		}
+	}
+
+	rot.configStack = nil
+	rot.validationGroups = nil
+	rot.curGroups = nil
+	return result
+}
+
+// Fills paths with the location of every message within msg, e.g. "priority_list[2].action".
+func collectAPLConfigPaths(msg protoreflect.Message, path string, paths map[protoreflect.ProtoMessage]string) {
+	paths[msg.Interface()] = path
+
+	msg.Range(func(fd protoreflect.FieldDescriptor, val protoreflect.Value) bool {
+		if fd.Message() == nil || fd.IsMap() {
+			return true
+		}
+
+		fieldPath := string(fd.Name())
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+
+		if fd.IsList() {
+			list := val.List()
+			for i := 0; i < list.Len(); i++ {
+				collectAPLConfigPaths(list.Get(i).Message(), fmt.Sprintf("%s[%d]", fieldPath, i), paths)
+			}
+		} else {
+			collectAPLConfigPaths(val.Message(), fieldPath, paths)
+		}
+		return true
+	})
+}
@@ -39,30 +39,95 @@ func (impl defaultAPLValueImpl) GetString(sim *Simulation) string {
 	panic("Unimplemented GetString")
 }
 
-func (unit *Unit) newAPLValue(config *proto.APLValue) APLValue {
+func (rot *APLRotation) newAPLValue(config *proto.APLValue) APLValue {
 	if config == nil {
 		return nil
 	}
+	defer rot.pushConfig(config)()
 
 	switch config.Value.(type) {
 	// Operators
 	case *proto.APLValue_Const:
-		return unit.newValueConst(config.GetConst())
+		return rot.newValueConst(config.GetConst())
 	case *proto.APLValue_And:
-		return unit.newValueAnd(config.GetAnd())
+		return rot.newValueAnd(config.GetAnd())
 	case *proto.APLValue_Or:
-		return unit.newValueOr(config.GetOr())
+		return rot.newValueOr(config.GetOr())
 	case *proto.APLValue_Not:
-		return unit.newValueNot(config.GetNot())
+		return rot.newValueNot(config.GetNot())
 	case *proto.APLValue_Cmp:
-		return unit.newValueCompare(config.GetCmp())
+		return rot.newValueCompare(config.GetCmp())
+	case *proto.APLValue_Math:
+		return rot.newValueMath(config.GetMath())
+	case *proto.APLValue_Max:
+		return rot.newValueMax(config.GetMax())
+	case *proto.APLValue_Min:
+		return rot.newValueMin(config.GetMin())
+
+	// Encounter
+	case *proto.APLValue_CurrentTime:
+		return rot.newValueCurrentTime(config.GetCurrentTime())
+	case *proto.APLValue_RemainingTime:
+		return rot.newValueRemainingTime(config.GetRemainingTime())
+	case *proto.APLValue_TargetHealthPercent:
+		return rot.newValueTargetHealthPercent(config.GetTargetHealthPercent())
+	case *proto.APLValue_NumberTargets:
+		return rot.newValueNumberTargets(config.GetNumberTargets())
+
+	// Resources
+	case *proto.APLValue_CurrentMana:
+		return rot.newValueCurrentMana(config.GetCurrentMana())
+	case *proto.APLValue_CurrentManaPercent:
+		return rot.newValueCurrentManaPercent(config.GetCurrentManaPercent())
+	case *proto.APLValue_CurrentRage:
+		return rot.newValueCurrentRage(config.GetCurrentRage())
+	case *proto.APLValue_CurrentEnergy:
+		return rot.newValueCurrentEnergy(config.GetCurrentEnergy())
+	case *proto.APLValue_CurrentFocus:
+		return rot.newValueCurrentFocus(config.GetCurrentFocus())
+	case *proto.APLValue_CurrentComboPoints:
+		return rot.newValueCurrentComboPoints(config.GetCurrentComboPoints())
+	case *proto.APLValue_CurrentRunicPower:
+		return rot.newValueCurrentRunicPower(config.GetCurrentRunicPower())
+	case *proto.APLValue_CurrentRuneCount:
+		return rot.newValueCurrentRuneCount(config.GetCurrentRuneCount())
+	case *proto.APLValue_CurrentHealthPercent:
+		return rot.newValueCurrentHealthPercent(config.GetCurrentHealthPercent())
+
+	// Spells
+	case *proto.APLValue_SpellIsReady:
+		return rot.newValueSpellIsReady(config.GetSpellIsReady())
+	case *proto.APLValue_SpellTimeToReady:
+		return rot.newValueSpellTimeToReady(config.GetSpellTimeToReady())
+	case *proto.APLValue_SpellCastTime:
+		return rot.newValueSpellCastTime(config.GetSpellCastTime())
+	case *proto.APLValue_SpellCanCast:
+		return rot.newValueSpellCanCast(config.GetSpellCanCast())
+
+	// Auras
+	case *proto.APLValue_AuraIsActive:
+		return rot.newValueAuraIsActive(config.GetAuraIsActive())
+	case *proto.APLValue_AuraNumStacks:
+		return rot.newValueAuraNumStacks(config.GetAuraNumStacks())
+	case *proto.APLValue_AuraRemainingTime:
+		return rot.newValueAuraRemainingTime(config.GetAuraRemainingTime())
+	case *proto.APLValue_AuraInternalCooldown:
+		return rot.newValueAuraInternalCooldown(config.GetAuraInternalCooldown())
 
 	// Dots
 	case *proto.APLValue_DotIsActive:
-		return unit.newValueDotIsActive(config.GetDotIsActive())
+		return rot.newValueDotIsActive(config.GetDotIsActive())
+	case *proto.APLValue_DotRemainingTime:
+		return rot.newValueDotRemainingTime(config.GetDotRemainingTime())
+	case *proto.APLValue_DotTicksRemaining:
+		return rot.newValueDotTicksRemaining(config.GetDotTicksRemaining())
+
+	// Variables
+	case *proto.APLValue_Variable:
+		return rot.newValueVariable(config.GetVariable())
 
 	default:
-		validationError("Unimplemented value type")
+		rot.validationError("Unimplemented value type")
 		return nil
 	}
 }
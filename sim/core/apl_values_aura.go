@@ -0,0 +1,154 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func (rot *APLRotation) aplGetAura(auraId *proto.ActionID, onTarget bool) *Aura {
+	auraUnit := rot.unit
+	if onTarget {
+		auraUnit = rot.unit.CurrentTarget
+	}
+
+	aura := auraUnit.GetAuraByID(ProtoToActionID(auraId))
+	if aura == nil {
+		rot.validationWarning("No aura found on %s for id: %s", auraUnit.Label, ProtoToActionID(auraId).String())
+		return nil
+	}
+	return aura
+}
+
+// An aura referenced by an APL value. Auras on the target are looked up on the
+// unit's current target each time, so they follow the unit when it retargets.
+type aplAuraRef struct {
+	unit        *Unit
+	aura        *Aura
+	targetAuras AuraArray
+}
+
+func (rot *APLRotation) aplGetAuraRef(auraId *proto.ActionID, onTarget bool) *aplAuraRef {
+	aura := rot.aplGetAura(auraId, onTarget)
+	if aura == nil {
+		return nil
+	}
+	if !onTarget {
+		return &aplAuraRef{aura: aura}
+	}
+
+	actionID := ProtoToActionID(auraId)
+	targetAuras := make(AuraArray, len(rot.unit.Env.AllUnits))
+	for _, unit := range rot.unit.Env.AllUnits {
+		targetAuras[unit.UnitIndex] = unit.GetAuraByID(actionID)
+	}
+	return &aplAuraRef{
+		unit:        rot.unit,
+		targetAuras: targetAuras,
+	}
+}
+
+// Returns the referenced aura, or nil if the current target doesn't have it.
+func (ref *aplAuraRef) Get() *Aura {
+	if ref.targetAuras == nil {
+		return ref.aura
+	}
+	return ref.targetAuras.Get(ref.unit.CurrentTarget)
+}
+
+type APLValueAuraIsActive struct {
+	defaultAPLValueImpl
+	aura *aplAuraRef
+}
+
+func (rot *APLRotation) newValueAuraIsActive(config *proto.APLValueAuraIsActive) APLValue {
+	aura := rot.aplGetAuraRef(config.AuraId, config.OnTarget)
+	if aura == nil {
+		return nil
+	}
+	return &APLValueAuraIsActive{
+		aura: aura,
+	}
+}
+func (value *APLValueAuraIsActive) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueAuraIsActive) GetBool(sim *Simulation) bool {
+	aura := value.aura.Get()
+	return aura != nil && aura.IsActive()
+}
+
+type APLValueAuraNumStacks struct {
+	defaultAPLValueImpl
+	aura *aplAuraRef
+}
+
+func (rot *APLRotation) newValueAuraNumStacks(config *proto.APLValueAuraNumStacks) APLValue {
+	aura := rot.aplGetAuraRef(config.AuraId, config.OnTarget)
+	if aura == nil {
+		return nil
+	}
+	return &APLValueAuraNumStacks{
+		aura: aura,
+	}
+}
+func (value *APLValueAuraNumStacks) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeInt
+}
+func (value *APLValueAuraNumStacks) GetInt(sim *Simulation) int32 {
+	aura := value.aura.Get()
+	if aura == nil {
+		return 0
+	}
+	return aura.GetStacks()
+}
+
+type APLValueAuraRemainingTime struct {
+	defaultAPLValueImpl
+	aura *aplAuraRef
+}
+
+func (rot *APLRotation) newValueAuraRemainingTime(config *proto.APLValueAuraRemainingTime) APLValue {
+	aura := rot.aplGetAuraRef(config.AuraId, config.OnTarget)
+	if aura == nil {
+		return nil
+	}
+	return &APLValueAuraRemainingTime{
+		aura: aura,
+	}
+}
+func (value *APLValueAuraRemainingTime) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueAuraRemainingTime) GetDuration(sim *Simulation) time.Duration {
+	aura := value.aura.Get()
+	if aura == nil || !aura.IsActive() {
+		return 0
+	}
+	return aura.RemainingDuration(sim)
+}
+
+type APLValueAuraInternalCooldown struct {
+	defaultAPLValueImpl
+	aura *Aura
+}
+
+func (rot *APLRotation) newValueAuraInternalCooldown(config *proto.APLValueAuraInternalCooldown) APLValue {
+	aura := rot.aplGetAura(config.AuraId, false)
+	if aura == nil {
+		return nil
+	}
+	if aura.Icd == nil {
+		rot.validationWarning("Aura %s has no internal cooldown", aura.ActionID)
+		return nil
+	}
+	return &APLValueAuraInternalCooldown{
+		aura: aura,
+	}
+}
+func (value *APLValueAuraInternalCooldown) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueAuraInternalCooldown) GetDuration(sim *Simulation) time.Duration {
+	return value.aura.Icd.TimeToReady(sim)
+}
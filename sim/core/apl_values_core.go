@@ -1,35 +1,106 @@
 package core
 
 import (
+	"time"
+
 	"github.com/wowsims/wotlk/sim/core/proto"
 )
 
-func (unit *Unit) aplGetDot(spellId *proto.ActionID) *Dot {
-	spell := unit.GetSpell(ProtoToActionID(spellId))
+// A dot referenced by an APL value. Single-target dots are looked up on the
+// unit's current target each time, so they follow the unit when it retargets.
+type aplDotRef struct {
+	spell *Spell
+}
+
+func (rot *APLRotation) aplGetDot(spellId *proto.ActionID) *aplDotRef {
+	spell := rot.aplGetSpell(spellId)
 	if spell == nil {
 		return nil
 	}
 
-	if spell.AOEDot() != nil {
-		return spell.AOEDot()
-	} else {
-		return spell.CurDot()
+	if spell.AOEDot() == nil && len(spell.dots) == 0 {
+		rot.validationWarning("Spell %s has no dot", spell.ActionID)
+		return nil
+	}
+	return &aplDotRef{spell: spell}
+}
+
+// Returns the referenced dot, or nil if it can't be applied to the current target.
+func (ref *aplDotRef) Get() *Dot {
+	if aoeDot := ref.spell.AOEDot(); aoeDot != nil {
+		return aoeDot
 	}
+	return ref.spell.CurDot()
 }
 
 type APLValueDotIsActive struct {
 	defaultAPLValueImpl
-	dot *Dot
+	dot *aplDotRef
 }
 
-func (unit *Unit) newValueDotIsActive(config *proto.APLValueDotIsActive) APLValue {
+func (rot *APLRotation) newValueDotIsActive(config *proto.APLValueDotIsActive) APLValue {
+	dot := rot.aplGetDot(config.SpellId)
+	if dot == nil {
+		return nil
+	}
 	return &APLValueDotIsActive{
-		dot: unit.aplGetDot(config.SpellId),
+		dot: dot,
 	}
 }
 func (value *APLValueDotIsActive) Type() proto.APLValueType {
 	return proto.APLValueType_ValueTypeBool
 }
 func (value *APLValueDotIsActive) GetBool(sim *Simulation) bool {
-	return value.dot.IsActive()
+	dot := value.dot.Get()
+	return dot != nil && dot.IsActive()
+}
+
+type APLValueDotRemainingTime struct {
+	defaultAPLValueImpl
+	dot *aplDotRef
+}
+
+func (rot *APLRotation) newValueDotRemainingTime(config *proto.APLValueDotRemainingTime) APLValue {
+	dot := rot.aplGetDot(config.SpellId)
+	if dot == nil {
+		return nil
+	}
+	return &APLValueDotRemainingTime{
+		dot: dot,
+	}
+}
+func (value *APLValueDotRemainingTime) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueDotRemainingTime) GetDuration(sim *Simulation) time.Duration {
+	dot := value.dot.Get()
+	if dot == nil || !dot.IsActive() {
+		return 0
+	}
+	return dot.RemainingDuration(sim)
+}
+
+type APLValueDotTicksRemaining struct {
+	defaultAPLValueImpl
+	dot *aplDotRef
+}
+
+func (rot *APLRotation) newValueDotTicksRemaining(config *proto.APLValueDotTicksRemaining) APLValue {
+	dot := rot.aplGetDot(config.SpellId)
+	if dot == nil {
+		return nil
+	}
+	return &APLValueDotTicksRemaining{
+		dot: dot,
+	}
+}
+func (value *APLValueDotTicksRemaining) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeInt
+}
+func (value *APLValueDotTicksRemaining) GetInt(sim *Simulation) int32 {
+	dot := value.dot.Get()
+	if dot == nil || !dot.IsActive() {
+		return 0
+	}
+	return dot.NumberOfTicks - dot.TickCount
 }
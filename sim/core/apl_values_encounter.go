@@ -0,0 +1,66 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+type APLValueCurrentTime struct {
+	defaultAPLValueImpl
+}
+
+func (rot *APLRotation) newValueCurrentTime(config *proto.APLValueCurrentTime) APLValue {
+	return &APLValueCurrentTime{}
+}
+func (value *APLValueCurrentTime) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueCurrentTime) GetDuration(sim *Simulation) time.Duration {
+	return sim.CurrentTime
+}
+
+type APLValueRemainingTime struct {
+	defaultAPLValueImpl
+}
+
+func (rot *APLRotation) newValueRemainingTime(config *proto.APLValueRemainingTime) APLValue {
+	return &APLValueRemainingTime{}
+}
+func (value *APLValueRemainingTime) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueRemainingTime) GetDuration(sim *Simulation) time.Duration {
+	return MaxDuration(0, sim.GetRemainingDuration())
+}
+
+type APLValueTargetHealthPercent struct {
+	defaultAPLValueImpl
+	unit *Unit
+}
+
+func (rot *APLRotation) newValueTargetHealthPercent(config *proto.APLValueTargetHealthPercent) APLValue {
+	return &APLValueTargetHealthPercent{
+		unit: rot.unit,
+	}
+}
+func (value *APLValueTargetHealthPercent) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueTargetHealthPercent) GetFloat(sim *Simulation) float64 {
+	return value.unit.CurrentTarget.estimatedHealthPercent(sim) * 100
+}
+
+type APLValueNumberTargets struct {
+	defaultAPLValueImpl
+}
+
+func (rot *APLRotation) newValueNumberTargets(config *proto.APLValueNumberTargets) APLValue {
+	return &APLValueNumberTargets{}
+}
+func (value *APLValueNumberTargets) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeInt
+}
+func (value *APLValueNumberTargets) GetInt(sim *Simulation) int32 {
+	return int32(len(sim.Encounter.ActiveTargets))
+}
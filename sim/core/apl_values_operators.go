@@ -18,7 +18,7 @@ type APLValueConst struct {
 	boolVal     bool
 }
 
-func (unit *Unit) newValueConst(config *proto.APLValueConst) APLValue {
+func (rot *APLRotation) newValueConst(config *proto.APLValueConst) APLValue {
 	result := &APLValueConst{
 		valType:   proto.APLValueType_ValueTypeString,
 		stringVal: config.Val,
@@ -27,6 +27,8 @@ func (unit *Unit) newValueConst(config *proto.APLValueConst) APLValue {
 
 	if durVal, err := time.ParseDuration(config.Val); err == nil {
 		result.durationVal = durVal
+		result.floatVal = durVal.Seconds()
+		result.intVal = int32(result.floatVal)
 		result.valType = proto.APLValueType_ValueTypeDuration
 		return result
 	}
@@ -159,7 +161,7 @@ func (value APLValueCoerced) GetString(sim *Simulation) string {
 }
 
 // Wraps a value so that it is converted into a Boolean.
-func (unit *Unit) coerceTo(value APLValue, newType proto.APLValueType) APLValue {
+func (rot *APLRotation) coerceTo(value APLValue, newType proto.APLValueType) APLValue {
 	if value == nil {
 		return nil
 	} else if value.Type() == newType {
@@ -189,14 +191,63 @@ var aplValueTypeOrder = []proto.APLValueType{
 }
 
 // Coerces 2 values into the same type, returning the two new values.
-func (unit *Unit) coerceToSameType(value1 APLValue, value2 APLValue) (APLValue, APLValue) {
+func (rot *APLRotation) coerceToSameType(value1 APLValue, value2 APLValue) (APLValue, APLValue) {
+	coerced := rot.coerceAllToSameType([]APLValue{value1, value2})
+	return coerced[0], coerced[1]
+}
+
+// Coerces any number of values into the same type, returning the new values.
+func (rot *APLRotation) coerceAllToSameType(values []APLValue) []APLValue {
 	var coercionType proto.APLValueType
 	for _, listType := range aplValueTypeOrder {
-		if value1.Type() == listType || value2.Type() == listType {
-			coercionType = listType
+		for _, value := range values {
+			if value.Type() == listType {
+				coercionType = listType
+			}
 		}
 	}
-	return unit.coerceTo(value1, coercionType), unit.coerceTo(value2, coercionType)
+	return MapSlice(values, func(value APLValue) APLValue {
+		return rot.coerceTo(value, coercionType)
+	})
+}
+
+func isNumericAPLValueType(valueType proto.APLValueType) bool {
+	return valueType == proto.APLValueType_ValueTypeInt ||
+		valueType == proto.APLValueType_ValueTypeFloat ||
+		valueType == proto.APLValueType_ValueTypeDuration
+}
+
+// Returns whether value only depends on constants, i.e. it can never change during a sim.
+func isConstAPLValue(value APLValue) bool {
+	switch value := value.(type) {
+	case *APLValueConst:
+		return true
+	case *APLValueCoerced:
+		return isConstAPLValue(value.inner)
+	case *APLValueNot:
+		return isConstAPLValue(value.val)
+	case *APLValueCompare:
+		return isConstAPLValue(value.lhs) && isConstAPLValue(value.rhs)
+	case *APLValueMath:
+		return isConstAPLValue(value.lhs) && isConstAPLValue(value.rhs)
+	case *APLValueAnd:
+		return allConstAPLValues(value.vals)
+	case *APLValueOr:
+		return allConstAPLValues(value.vals)
+	case *APLValueMax:
+		return allConstAPLValues(value.vals)
+	case *APLValueMin:
+		return allConstAPLValues(value.vals)
+	}
+	return false
+}
+func allConstAPLValues(values []APLValue) bool {
+	for _, value := range values {
+		if !isConstAPLValue(value) {
+			return false
+		}
+	}
+	return true
 }
 
 type APLValueCompare struct {
@@ -206,10 +257,18 @@ type APLValueCompare struct {
 	rhs APLValue
 }
 
-func (unit *Unit) newValueCompare(config *proto.APLValueCompare) APLValue {
-	lhs, rhs := unit.coerceToSameType(unit.newAPLValue(config.Lhs), unit.newAPLValue(config.Rhs))
+func (rot *APLRotation) newValueCompare(config *proto.APLValueCompare) APLValue {
+	lhs, rhs := rot.newAPLValue(config.Lhs), rot.newAPLValue(config.Rhs)
+	if lhs == nil || rhs == nil {
+		return nil
+	}
+	if lhs.Type() != rhs.Type() && !(isNumericAPLValueType(lhs.Type()) && isNumericAPLValueType(rhs.Type())) {
+		rot.validationWarning("Comparing mismatched types %s and %s", lhs.Type(), rhs.Type())
+	}
+
+	lhs, rhs = rot.coerceToSameType(lhs, rhs)
 	if lhs.Type() == proto.APLValueType_ValueTypeBool && !(config.Op == proto.APLValueCompare_OpEq || config.Op == proto.APLValueCompare_OpNe) {
-		validationError("Bool types only allow Equals and NotEquals comparisons!")
+		rot.validationError("Bool types only allow Equals and NotEquals comparisons!")
 	}
 	return &APLValueCompare{
 		op:  config.Op,
@@ -298,9 +357,9 @@ type APLValueAnd struct {
 	vals []APLValue
 }
 
-func (unit *Unit) newValueAnd(config *proto.APLValueAnd) APLValue {
+func (rot *APLRotation) newValueAnd(config *proto.APLValueAnd) APLValue {
 	vals := MapSlice(config.Vals, func(val *proto.APLValue) APLValue {
-		return unit.coerceTo(unit.newAPLValue(val), proto.APLValueType_ValueTypeBool)
+		return rot.coerceTo(rot.newAPLValue(val), proto.APLValueType_ValueTypeBool)
 	})
 	vals = FilterSlice(vals, func(val APLValue) bool { return val != nil })
 	if len(vals) == 0 {
@@ -327,9 +386,9 @@ type APLValueOr struct {
 	vals []APLValue
 }
 
-func (unit *Unit) newValueOr(config *proto.APLValueOr) APLValue {
+func (rot *APLRotation) newValueOr(config *proto.APLValueOr) APLValue {
 	vals := MapSlice(config.Vals, func(val *proto.APLValue) APLValue {
-		return unit.coerceTo(unit.newAPLValue(val), proto.APLValueType_ValueTypeBool)
+		return rot.coerceTo(rot.newAPLValue(val), proto.APLValueType_ValueTypeBool)
 	})
 	vals = FilterSlice(vals, func(val APLValue) bool { return val != nil })
 	if len(vals) == 0 {
@@ -356,8 +415,8 @@ type APLValueNot struct {
 	val APLValue
 }
 
-func (unit *Unit) newValueNot(config *proto.APLValueNot) APLValue {
-	val := unit.coerceTo(unit.newAPLValue(config.Val), proto.APLValueType_ValueTypeBool)
+func (rot *APLRotation) newValueNot(config *proto.APLValueNot) APLValue {
+	val := rot.coerceTo(rot.newAPLValue(config.Val), proto.APLValueType_ValueTypeBool)
 	if val == nil {
 		return nil
 	}
@@ -371,3 +430,208 @@ func (value *APLValueNot) Type() proto.APLValueType {
 func (value *APLValueNot) GetBool(sim *Simulation) bool {
 	return !value.val.GetBool(sim)
 }
+
+type APLValueMath struct {
+	defaultAPLValueImpl
+	op        proto.APLValueMath_MathOperator
+	lhs       APLValue
+	rhs       APLValue
+	valueType proto.APLValueType
+}
+
+func (rot *APLRotation) newValueMath(config *proto.APLValueMath) APLValue {
+	lhs, rhs := rot.newAPLValue(config.Lhs), rot.newAPLValue(config.Rhs)
+	if lhs == nil || rhs == nil {
+		return nil
+	}
+	if config.Op == proto.APLValueMath_OpUnknown {
+		rot.validationError("Math operator must be set!")
+	}
+	if !isNumericAPLValueType(lhs.Type()) || !isNumericAPLValueType(rhs.Type()) {
+		rot.validationError("Math operations are only allowed on numeric types!")
+	}
+
+	lhsIsDuration := lhs.Type() == proto.APLValueType_ValueTypeDuration
+	rhsIsDuration := rhs.Type() == proto.APLValueType_ValueTypeDuration
+
+	value := &APLValueMath{
+		op: config.Op,
+	}
+	switch config.Op {
+	case proto.APLValueMath_OpMul:
+		if lhsIsDuration && rhsIsDuration {
+			rot.validationError("Cannot multiply two durations!")
+		} else if lhsIsDuration {
+			// Scale a duration by a number, e.g. 2 * GCD.
+			value.lhs, value.rhs = lhs, rot.coerceTo(rhs, proto.APLValueType_ValueTypeFloat)
+			value.valueType = proto.APLValueType_ValueTypeDuration
+			return value
+		} else if rhsIsDuration {
+			value.lhs, value.rhs = rhs, rot.coerceTo(lhs, proto.APLValueType_ValueTypeFloat)
+			value.valueType = proto.APLValueType_ValueTypeDuration
+			return value
+		}
+	case proto.APLValueMath_OpDiv:
+		if rhsIsDuration && !lhsIsDuration {
+			rot.validationError("Cannot divide a number by a duration!")
+		} else if lhsIsDuration && !rhsIsDuration {
+			value.lhs, value.rhs = lhs, rot.coerceTo(rhs, proto.APLValueType_ValueTypeFloat)
+			value.valueType = proto.APLValueType_ValueTypeDuration
+			return value
+		}
+
+		// Division between 2 ints or 2 durations produces a ratio, so always use floats.
+		value.lhs, value.rhs = rot.coerceTo(lhs, proto.APLValueType_ValueTypeFloat), rot.coerceTo(rhs, proto.APLValueType_ValueTypeFloat)
+		value.valueType = proto.APLValueType_ValueTypeFloat
+		return value
+	}
+
+	value.lhs, value.rhs = rot.coerceToSameType(lhs, rhs)
+	value.valueType = value.lhs.Type()
+	return value
+}
+func (value *APLValueMath) Type() proto.APLValueType {
+	return value.valueType
+}
+func (value *APLValueMath) GetInt(sim *Simulation) int32 {
+	left, right := value.lhs.GetInt(sim), value.rhs.GetInt(sim)
+	switch value.op {
+	case proto.APLValueMath_OpAdd:
+		return left + right
+	case proto.APLValueMath_OpSub:
+		return left - right
+	case proto.APLValueMath_OpMul:
+		return left * right
+	}
+	return 0
+}
+func (value *APLValueMath) GetFloat(sim *Simulation) float64 {
+	left, right := value.lhs.GetFloat(sim), value.rhs.GetFloat(sim)
+	switch value.op {
+	case proto.APLValueMath_OpAdd:
+		return left + right
+	case proto.APLValueMath_OpSub:
+		return left - right
+	case proto.APLValueMath_OpMul:
+		return left * right
+	case proto.APLValueMath_OpDiv:
+		if right == 0 {
+			return 0
+		}
+		return left / right
+	}
+	return 0
+}
+func (value *APLValueMath) GetDuration(sim *Simulation) time.Duration {
+	switch value.op {
+	case proto.APLValueMath_OpAdd:
+		return value.lhs.GetDuration(sim) + value.rhs.GetDuration(sim)
+	case proto.APLValueMath_OpSub:
+		return value.lhs.GetDuration(sim) - value.rhs.GetDuration(sim)
+	case proto.APLValueMath_OpMul:
+		return time.Duration(float64(value.lhs.GetDuration(sim)) * value.rhs.GetFloat(sim))
+	case proto.APLValueMath_OpDiv:
+		right := value.rhs.GetFloat(sim)
+		if right == 0 {
+			return 0
+		}
+		return time.Duration(float64(value.lhs.GetDuration(sim)) / right)
+	}
+	return 0
+}
+
+type APLValueMax struct {
+	defaultAPLValueImpl
+	vals []APLValue
+}
+
+func (rot *APLRotation) newValueMax(config *proto.APLValueMax) APLValue {
+	vals := rot.newNumericAPLValues(config.Vals, "Max")
+	if len(vals) == 0 {
+		return nil
+	} else if len(vals) == 1 {
+		return vals[0]
+	}
+	return &APLValueMax{
+		vals: vals,
+	}
+}
+func (value *APLValueMax) Type() proto.APLValueType {
+	return value.vals[0].Type()
+}
+func (value *APLValueMax) GetInt(sim *Simulation) int32 {
+	result := value.vals[0].GetInt(sim)
+	for _, val := range value.vals[1:] {
+		result = MaxInt32(result, val.GetInt(sim))
+	}
+	return result
+}
+func (value *APLValueMax) GetFloat(sim *Simulation) float64 {
+	result := value.vals[0].GetFloat(sim)
+	for _, val := range value.vals[1:] {
+		result = MaxFloat(result, val.GetFloat(sim))
+	}
+	return result
+}
+func (value *APLValueMax) GetDuration(sim *Simulation) time.Duration {
+	result := value.vals[0].GetDuration(sim)
+	for _, val := range value.vals[1:] {
+		result = MaxDuration(result, val.GetDuration(sim))
+	}
+	return result
+}
+
+type APLValueMin struct {
+	defaultAPLValueImpl
+	vals []APLValue
+}
+
+func (rot *APLRotation) newValueMin(config *proto.APLValueMin) APLValue {
+	vals := rot.newNumericAPLValues(config.Vals, "Min")
+	if len(vals) == 0 {
+		return nil
+	} else if len(vals) == 1 {
+		return vals[0]
+	}
+	return &APLValueMin{
+		vals: vals,
+	}
+}
+func (value *APLValueMin) Type() proto.APLValueType {
+	return value.vals[0].Type()
+}
+func (value *APLValueMin) GetInt(sim *Simulation) int32 {
+	result := value.vals[0].GetInt(sim)
+	for _, val := range value.vals[1:] {
+		result = MinInt32(result, val.GetInt(sim))
+	}
+	return result
+}
+func (value *APLValueMin) GetFloat(sim *Simulation) float64 {
+	result := value.vals[0].GetFloat(sim)
+	for _, val := range value.vals[1:] {
+		result = MinFloat(result, val.GetFloat(sim))
+	}
+	return result
+}
+func (value *APLValueMin) GetDuration(sim *Simulation) time.Duration {
+	result := value.vals[0].GetDuration(sim)
+	for _, val := range value.vals[1:] {
+		result = MinDuration(result, val.GetDuration(sim))
+	}
+	return result
+}
+
+// Builds a list of values for Max/Min, coerced to a shared numeric type.
+func (rot *APLRotation) newNumericAPLValues(configs []*proto.APLValue, opName string) []APLValue {
+	vals := MapSlice(configs, func(val *proto.APLValue) APLValue {
+		return rot.newAPLValue(val)
+	})
+	vals = FilterSlice(vals, func(val APLValue) bool { return val != nil })
+	for _, val := range vals {
+		if !isNumericAPLValueType(val.Type()) {
+			rot.validationError("%s is only allowed on numeric types!", opName)
+		}
+	}
+	return rot.coerceAllToSameType(vals)
+}
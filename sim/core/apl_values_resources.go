@@ -0,0 +1,205 @@
+package core
+
+import (
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+type APLValueCurrentMana struct {
+	defaultAPLValueImpl
+	unit *Unit
+}
+
+func (rot *APLRotation) newValueCurrentMana(config *proto.APLValueCurrentMana) APLValue {
+	if !rot.unit.HasManaBar() {
+		rot.validationWarning("%s does not use Mana", rot.unit.Label)
+		return nil
+	}
+	return &APLValueCurrentMana{
+		unit: rot.unit,
+	}
+}
+func (value *APLValueCurrentMana) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueCurrentMana) GetFloat(sim *Simulation) float64 {
+	return value.unit.CurrentMana()
+}
+
+type APLValueCurrentManaPercent struct {
+	defaultAPLValueImpl
+	unit *Unit
+}
+
+func (rot *APLRotation) newValueCurrentManaPercent(config *proto.APLValueCurrentManaPercent) APLValue {
+	if !rot.unit.HasManaBar() {
+		rot.validationWarning("%s does not use Mana", rot.unit.Label)
+		return nil
+	}
+	return &APLValueCurrentManaPercent{
+		unit: rot.unit,
+	}
+}
+func (value *APLValueCurrentManaPercent) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueCurrentManaPercent) GetFloat(sim *Simulation) float64 {
+	return value.unit.CurrentManaPercent() * 100
+}
+
+type APLValueCurrentRage struct {
+	defaultAPLValueImpl
+	unit *Unit
+}
+
+func (rot *APLRotation) newValueCurrentRage(config *proto.APLValueCurrentRage) APLValue {
+	if !rot.unit.HasRageBar() {
+		rot.validationWarning("%s does not use Rage", rot.unit.Label)
+		return nil
+	}
+	return &APLValueCurrentRage{
+		unit: rot.unit,
+	}
+}
+func (value *APLValueCurrentRage) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueCurrentRage) GetFloat(sim *Simulation) float64 {
+	return value.unit.CurrentRage()
+}
+
+type APLValueCurrentEnergy struct {
+	defaultAPLValueImpl
+	unit *Unit
+}
+
+func (rot *APLRotation) newValueCurrentEnergy(config *proto.APLValueCurrentEnergy) APLValue {
+	if !rot.unit.HasEnergyBar() {
+		rot.validationWarning("%s does not use Energy", rot.unit.Label)
+		return nil
+	}
+	return &APLValueCurrentEnergy{
+		unit: rot.unit,
+	}
+}
+func (value *APLValueCurrentEnergy) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueCurrentEnergy) GetFloat(sim *Simulation) float64 {
+	return value.unit.CurrentEnergy()
+}
+
+type APLValueCurrentFocus struct {
+	defaultAPLValueImpl
+	unit *Unit
+}
+
+func (rot *APLRotation) newValueCurrentFocus(config *proto.APLValueCurrentFocus) APLValue {
+	if !rot.unit.HasFocusBar() {
+		rot.validationWarning("%s does not use Focus", rot.unit.Label)
+		return nil
+	}
+	return &APLValueCurrentFocus{
+		unit: rot.unit,
+	}
+}
+func (value *APLValueCurrentFocus) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueCurrentFocus) GetFloat(sim *Simulation) float64 {
+	return value.unit.CurrentFocus()
+}
+
+type APLValueCurrentComboPoints struct {
+	defaultAPLValueImpl
+	unit *Unit
+}
+
+func (rot *APLRotation) newValueCurrentComboPoints(config *proto.APLValueCurrentComboPoints) APLValue {
+	if !rot.unit.HasEnergyBar() {
+		rot.validationWarning("%s does not use Combo Points", rot.unit.Label)
+		return nil
+	}
+	return &APLValueCurrentComboPoints{
+		unit: rot.unit,
+	}
+}
+func (value *APLValueCurrentComboPoints) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeInt
+}
+func (value *APLValueCurrentComboPoints) GetInt(sim *Simulation) int32 {
+	return value.unit.ComboPoints()
+}
+
+type APLValueCurrentRunicPower struct {
+	defaultAPLValueImpl
+	unit *Unit
+}
+
+func (rot *APLRotation) newValueCurrentRunicPower(config *proto.APLValueCurrentRunicPower) APLValue {
+	if !rot.unit.HasRunicPowerBar() {
+		rot.validationWarning("%s does not use Runic Power", rot.unit.Label)
+		return nil
+	}
+	return &APLValueCurrentRunicPower{
+		unit: rot.unit,
+	}
+}
+func (value *APLValueCurrentRunicPower) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueCurrentRunicPower) GetFloat(sim *Simulation) float64 {
+	return value.unit.CurrentRunicPower()
+}
+
+type APLValueCurrentRuneCount struct {
+	defaultAPLValueImpl
+	unit     *Unit
+	runeType proto.APLValueCurrentRuneCount_RuneType
+}
+
+func (rot *APLRotation) newValueCurrentRuneCount(config *proto.APLValueCurrentRuneCount) APLValue {
+	if !rot.unit.HasRunicPowerBar() {
+		rot.validationWarning("%s does not use Runes", rot.unit.Label)
+		return nil
+	}
+	if config.RuneType == proto.APLValueCurrentRuneCount_RuneUnknown {
+		rot.validationError("Rune type must be set for rune count values")
+	}
+	return &APLValueCurrentRuneCount{
+		unit:     rot.unit,
+		runeType: config.RuneType,
+	}
+}
+func (value *APLValueCurrentRuneCount) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeInt
+}
+func (value *APLValueCurrentRuneCount) GetInt(sim *Simulation) int32 {
+	switch value.runeType {
+	case proto.APLValueCurrentRuneCount_RuneBlood:
+		return int32(value.unit.CurrentBloodRunes())
+	case proto.APLValueCurrentRuneCount_RuneFrost:
+		return int32(value.unit.CurrentFrostRunes())
+	case proto.APLValueCurrentRuneCount_RuneUnholy:
+		return int32(value.unit.CurrentUnholyRunes())
+	case proto.APLValueCurrentRuneCount_RuneDeath:
+		return int32(value.unit.CurrentDeathRunes())
+	}
+	return 0
+}
+
+type APLValueCurrentHealthPercent struct {
+	defaultAPLValueImpl
+	unit *Unit
+}
+
+func (rot *APLRotation) newValueCurrentHealthPercent(config *proto.APLValueCurrentHealthPercent) APLValue {
+	return &APLValueCurrentHealthPercent{
+		unit: rot.unit,
+	}
+}
+func (value *APLValueCurrentHealthPercent) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueCurrentHealthPercent) GetFloat(sim *Simulation) float64 {
+	return value.unit.CurrentHealthPercent() * 100
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func (rot *APLRotation) aplGetSpell(spellId *proto.ActionID) *Spell {
+	actionID := ProtoToActionID(spellId)
+
+	// Helper spells (e.g. individual weapon hits) often share the ID of the
+	// spell that is actually cast, so prefer the one meant for APL use.
+	for _, spell := range rot.unit.Spellbook {
+		if spell.ActionID.SameAction(actionID) && spell.Flags.Matches(SpellFlagAPL) {
+			return spell
+		}
+	}
+
+	spell := rot.unit.GetSpell(actionID)
+	if spell == nil {
+		rot.validationWarning("No spell found for id: %s", actionID.String())
+		return nil
+	}
+	return spell
+}
+
+type APLValueSpellIsReady struct {
+	defaultAPLValueImpl
+	spell *Spell
+}
+
+func (rot *APLRotation) newValueSpellIsReady(config *proto.APLValueSpellIsReady) APLValue {
+	spell := rot.aplGetSpell(config.SpellId)
+	if spell == nil {
+		return nil
+	}
+	return &APLValueSpellIsReady{
+		spell: spell,
+	}
+}
+func (value *APLValueSpellIsReady) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueSpellIsReady) GetBool(sim *Simulation) bool {
+	return value.spell.IsReady(sim)
+}
+
+type APLValueSpellTimeToReady struct {
+	defaultAPLValueImpl
+	spell *Spell
+}
+
+func (rot *APLRotation) newValueSpellTimeToReady(config *proto.APLValueSpellTimeToReady) APLValue {
+	spell := rot.aplGetSpell(config.SpellId)
+	if spell == nil {
+		return nil
+	}
+	return &APLValueSpellTimeToReady{
+		spell: spell,
+	}
+}
+func (value *APLValueSpellTimeToReady) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueSpellTimeToReady) GetDuration(sim *Simulation) time.Duration {
+	return value.spell.TimeToReady(sim)
+}
+
+type APLValueSpellCastTime struct {
+	defaultAPLValueImpl
+	spell *Spell
+}
+
+func (rot *APLRotation) newValueSpellCastTime(config *proto.APLValueSpellCastTime) APLValue {
+	spell := rot.aplGetSpell(config.SpellId)
+	if spell == nil {
+		return nil
+	}
+	return &APLValueSpellCastTime{
+		spell: spell,
+	}
+}
+func (value *APLValueSpellCastTime) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeDuration
+}
+func (value *APLValueSpellCastTime) GetDuration(sim *Simulation) time.Duration {
+	return value.spell.CastTime()
+}
+
+type APLValueSpellCanCast struct {
+	defaultAPLValueImpl
+	spell *Spell
+}
+
+func (rot *APLRotation) newValueSpellCanCast(config *proto.APLValueSpellCanCast) APLValue {
+	spell := rot.aplGetSpell(config.SpellId)
+	if spell == nil {
+		return nil
+	}
+	return &APLValueSpellCanCast{
+		spell: spell,
+	}
+}
+func (value *APLValueSpellCanCast) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeBool
+}
+func (value *APLValueSpellCanCast) GetBool(sim *Simulation) bool {
+	return value.spell.CanCast(sim, value.spell.Unit.CurrentTarget)
+}
@@ -5,33 +5,34 @@ import (
 	"time"
 
 	"github.com/wowsims/wotlk/sim/core/proto"
+	"github.com/wowsims/wotlk/sim/core/stats"
 )
 
 func TestValueConst(t *testing.T) {
 	sim := &Simulation{}
-	unit := &Unit{}
+	rot := &APLRotation{unit: &Unit{}}
 
-	stringVal := unit.newValueConst(&proto.APLValueConst{Val: "test str"})
+	stringVal := rot.newValueConst(&proto.APLValueConst{Val: "test str"})
 	if stringVal.GetString(sim) != "test str" {
 		t.Fatalf("Unexpected string value %s", stringVal.GetString(sim))
 	}
 
-	intVal := unit.newValueConst(&proto.APLValueConst{Val: "10"})
+	intVal := rot.newValueConst(&proto.APLValueConst{Val: "10"})
 	if intVal.GetInt(sim) != 10 {
 		t.Fatalf("Unexpected int value %d", intVal.GetInt(sim))
 	}
 
-	floatVal := unit.newValueConst(&proto.APLValueConst{Val: "10.123"})
+	floatVal := rot.newValueConst(&proto.APLValueConst{Val: "10.123"})
 	if floatVal.GetFloat(sim) != 10.123 {
 		t.Fatalf("Unexpected float value %f", floatVal.GetFloat(sim))
 	}
 
-	durVal := unit.newValueConst(&proto.APLValueConst{Val: "10.123s"})
+	durVal := rot.newValueConst(&proto.APLValueConst{Val: "10.123s"})
 	if durVal.GetDuration(sim) != time.Millisecond*10123 {
 		t.Fatalf("Unexpected duration value %s", durVal.GetDuration(sim))
 	}
 
-	coercedDurVal := unit.coerceTo(floatVal, proto.APLValueType_ValueTypeDuration)
+	coercedDurVal := rot.coerceTo(floatVal, proto.APLValueType_ValueTypeDuration)
 	if _, ok := coercedDurVal.(*APLValueConst); !ok {
 		t.Fatalf("Failed to skip coerce wrapper for duration value")
 	}
@@ -39,3 +40,325 @@ func TestValueConst(t *testing.T) {
 		t.Fatalf("Unexpected coerced duration value %s", coercedDurVal.GetDuration(sim))
 	}
 }
+
+func constAPLValue(val string) *proto.APLValue {
+	return &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: val}}}
+}
+
+func TestValueMath(t *testing.T) {
+	sim := &Simulation{}
+	rot := &APLRotation{unit: &Unit{}}
+
+	intSum := rot.newValueMath(&proto.APLValueMath{Op: proto.APLValueMath_OpAdd, Lhs: constAPLValue("2"), Rhs: constAPLValue("3")})
+	if intSum.Type() != proto.APLValueType_ValueTypeInt || intSum.GetInt(sim) != 5 {
+		t.Fatalf("Unexpected int sum %d", intSum.GetInt(sim))
+	}
+
+	floatDiff := rot.newValueMath(&proto.APLValueMath{Op: proto.APLValueMath_OpSub, Lhs: constAPLValue("2.5"), Rhs: constAPLValue("1")})
+	if floatDiff.Type() != proto.APLValueType_ValueTypeFloat || floatDiff.GetFloat(sim) != 1.5 {
+		t.Fatalf("Unexpected float difference %f", floatDiff.GetFloat(sim))
+	}
+
+	scaledDur := rot.newValueMath(&proto.APLValueMath{Op: proto.APLValueMath_OpMul, Lhs: constAPLValue("2"), Rhs: constAPLValue("1.5s")})
+	if scaledDur.Type() != proto.APLValueType_ValueTypeDuration || scaledDur.GetDuration(sim) != time.Second*3 {
+		t.Fatalf("Unexpected scaled duration %s", scaledDur.GetDuration(sim))
+	}
+
+	durRatio := rot.newValueMath(&proto.APLValueMath{Op: proto.APLValueMath_OpDiv, Lhs: constAPLValue("3s"), Rhs: constAPLValue("2s")})
+	if durRatio.Type() != proto.APLValueType_ValueTypeFloat || durRatio.GetFloat(sim) != 1.5 {
+		t.Fatalf("Unexpected duration ratio %f", durRatio.GetFloat(sim))
+	}
+}
+
+func TestValueMaxMin(t *testing.T) {
+	sim := &Simulation{}
+	rot := &APLRotation{unit: &Unit{}}
+
+	maxVal := rot.newValueMax(&proto.APLValueMax{Vals: []*proto.APLValue{constAPLValue("1"), constAPLValue("2.5s"), constAPLValue("2")}})
+	if maxVal.Type() != proto.APLValueType_ValueTypeDuration || maxVal.GetDuration(sim) != time.Millisecond*2500 {
+		t.Fatalf("Unexpected max value %s", maxVal.GetDuration(sim))
+	}
+
+	minVal := rot.newValueMin(&proto.APLValueMin{Vals: []*proto.APLValue{constAPLValue("4"), constAPLValue("3")}})
+	if minVal.Type() != proto.APLValueType_ValueTypeInt || minVal.GetInt(sim) != 3 {
+		t.Fatalf("Unexpected min value %d", minVal.GetInt(sim))
+	}
+}
+
+func TestValueMathRejectsStrings(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("Expected validation error for string math")
+		}
+	}()
+
+	rot := &APLRotation{unit: &Unit{}}
+	rot.newValueMath(&proto.APLValueMath{Op: proto.APLValueMath_OpAdd, Lhs: constAPLValue("abc"), Rhs: constAPLValue("1")})
+}
+
+func TestValueTargetAuraFollowsCurrentTarget(t *testing.T) {
+	sim := &Simulation{}
+	env := &Environment{}
+	player := &Unit{Type: PlayerUnit, Env: env, auraTracker: newAuraTracker()}
+	for i := int32(0); i < 2; i++ {
+		target := &Unit{Type: EnemyUnit, Index: i, UnitIndex: i + 1, Env: env, auraTracker: newAuraTracker()}
+		target.RegisterAura(Aura{Label: "Debuff", ActionID: ActionID{SpellID: 123}, Duration: NeverExpires})
+		env.AllUnits = append(env.AllUnits, target)
+	}
+	env.AllUnits = append([]*Unit{player}, env.AllUnits...)
+	first, second := env.AllUnits[1], env.AllUnits[2]
+	second.GetAura("Debuff").Activate(sim)
+
+	player.CurrentTarget = first
+	rot := &APLRotation{unit: player}
+	isActive := rot.newValueAuraIsActive(&proto.APLValueAuraIsActive{AuraId: ActionID{SpellID: 123}.ToProto(), OnTarget: true})
+	if isActive.GetBool(sim) {
+		t.Fatalf("Expected the debuff to be inactive on the first target")
+	}
+
+	player.CurrentTarget = second
+	if !isActive.GetBool(sim) {
+		t.Fatalf("Expected the debuff to be active on the second target after retargeting")
+	}
+}
+
+func TestValueEncounter(t *testing.T) {
+	sim := SetupFakeSim()
+	sim.CurrentTime = time.Second * 45
+	rot := &APLRotation{unit: &sim.Raid.Parties[0].Players[0].GetCharacter().Unit}
+
+	currentTime := rot.newValueCurrentTime(&proto.APLValueCurrentTime{})
+	if currentTime.GetDuration(sim) != time.Second*45 {
+		t.Fatalf("Unexpected current time %s", currentTime.GetDuration(sim))
+	}
+
+	remainingTime := rot.newValueRemainingTime(&proto.APLValueRemainingTime{})
+	if remainingTime.GetDuration(sim) != time.Second*135 {
+		t.Fatalf("Unexpected remaining time %s", remainingTime.GetDuration(sim))
+	}
+
+	// Without health, the target's health is estimated from the fight duration.
+	healthPercent := rot.newValueTargetHealthPercent(&proto.APLValueTargetHealthPercent{})
+	if !WithinToleranceFloat64(75, healthPercent.GetFloat(sim), 0.0001) {
+		t.Fatalf("Unexpected target health percent %f", healthPercent.GetFloat(sim))
+	}
+
+	numTargets := rot.newValueNumberTargets(&proto.APLValueNumberTargets{})
+	if numTargets.GetInt(sim) != 1 {
+		t.Fatalf("Unexpected number of targets %d", numTargets.GetInt(sim))
+	}
+
+	// Despawned targets aren't counted.
+	sim.Encounter.Targets[0].Despawn(sim)
+	if numTargets.GetInt(sim) != 0 {
+		t.Fatalf("Unexpected number of targets %d after despawning", numTargets.GetInt(sim))
+	}
+}
+
+func TestValueResources(t *testing.T) {
+	sim := &Simulation{}
+	unit := &Unit{Label: "Unit"}
+	unit.stats[stats.Mana] = 1000
+	unit.stats[stats.Health] = 2000
+	unit.healthBar = healthBar{unit: unit, currentHealth: 1500}
+	unit.manaBar = manaBar{unit: unit, currentMana: 250}
+	unit.rageBar = rageBar{unit: unit, currentRage: 40}
+	unit.energyBar = energyBar{unit: unit, currentEnergy: 60, comboPoints: 3}
+	unit.focusBar = focusBar{unit: unit, currentFocus: 80}
+	unit.RunicPowerBar = RunicPowerBar{unit: unit, currentRunicPower: 30, runeStates: baseRuneState}
+	rot := &APLRotation{unit: unit}
+
+	floatValues := []struct {
+		value    APLValue
+		expected float64
+	}{
+		{rot.newValueCurrentMana(&proto.APLValueCurrentMana{}), 250},
+		{rot.newValueCurrentManaPercent(&proto.APLValueCurrentManaPercent{}), 25},
+		{rot.newValueCurrentRage(&proto.APLValueCurrentRage{}), 40},
+		{rot.newValueCurrentEnergy(&proto.APLValueCurrentEnergy{}), 60},
+		{rot.newValueCurrentFocus(&proto.APLValueCurrentFocus{}), 80},
+		{rot.newValueCurrentRunicPower(&proto.APLValueCurrentRunicPower{}), 30},
+		{rot.newValueCurrentHealthPercent(&proto.APLValueCurrentHealthPercent{}), 75},
+	}
+	for i, tc := range floatValues {
+		if tc.value.GetFloat(sim) != tc.expected {
+			t.Fatalf("Unexpected value %f for resource %d, expected %f", tc.value.GetFloat(sim), i, tc.expected)
+		}
+	}
+
+	comboPoints := rot.newValueCurrentComboPoints(&proto.APLValueCurrentComboPoints{})
+	if comboPoints.GetInt(sim) != 3 {
+		t.Fatalf("Unexpected combo points %d", comboPoints.GetInt(sim))
+	}
+
+	// Both runes of each type start out ready, and none are death runes.
+	runeCounts := map[proto.APLValueCurrentRuneCount_RuneType]int32{
+		proto.APLValueCurrentRuneCount_RuneBlood:  2,
+		proto.APLValueCurrentRuneCount_RuneFrost:  2,
+		proto.APLValueCurrentRuneCount_RuneUnholy: 2,
+		proto.APLValueCurrentRuneCount_RuneDeath:  0,
+	}
+	for runeType, expected := range runeCounts {
+		runeCount := rot.newValueCurrentRuneCount(&proto.APLValueCurrentRuneCount{RuneType: runeType})
+		if runeCount.GetInt(sim) != expected {
+			t.Fatalf("Unexpected %s count %d", runeType, runeCount.GetInt(sim))
+		}
+	}
+}
+
+func TestValueResourcesMissingBar(t *testing.T) {
+	rot := &APLRotation{unit: &Unit{Label: "Unit"}}
+
+	values := []APLValue{
+		rot.newValueCurrentMana(&proto.APLValueCurrentMana{}),
+		rot.newValueCurrentManaPercent(&proto.APLValueCurrentManaPercent{}),
+		rot.newValueCurrentRage(&proto.APLValueCurrentRage{}),
+		rot.newValueCurrentEnergy(&proto.APLValueCurrentEnergy{}),
+		rot.newValueCurrentFocus(&proto.APLValueCurrentFocus{}),
+		rot.newValueCurrentComboPoints(&proto.APLValueCurrentComboPoints{}),
+		rot.newValueCurrentRunicPower(&proto.APLValueCurrentRunicPower{}),
+		rot.newValueCurrentRuneCount(&proto.APLValueCurrentRuneCount{RuneType: proto.APLValueCurrentRuneCount_RuneBlood}),
+	}
+	for i, value := range values {
+		if value != nil {
+			t.Fatalf("Expected no value for resource %d without a resource bar", i)
+		}
+	}
+	if warnings := rot.validationGroups[0].validations; len(warnings) != len(values) {
+		t.Fatalf("Expected %d warnings, got: %v", len(values), warnings)
+	}
+}
+
+func TestValueSpell(t *testing.T) {
+	sim := &Simulation{}
+	unit := &Unit{CastSpeed: 0.8}
+	spell := &Spell{
+		ActionID:           ActionID{SpellID: 42},
+		Unit:               unit,
+		CD:                 Cooldown{Timer: unit.NewTimer(), Duration: time.Second * 10},
+		DefaultCast:        Cast{CastTime: time.Second * 2},
+		CastTimeMultiplier: 1,
+	}
+	unit.Spellbook = append(unit.Spellbook, spell)
+	rot := &APLRotation{unit: unit}
+	spellId := spell.ActionID.ToProto()
+
+	isReady := rot.newValueSpellIsReady(&proto.APLValueSpellIsReady{SpellId: spellId})
+	timeToReady := rot.newValueSpellTimeToReady(&proto.APLValueSpellTimeToReady{SpellId: spellId})
+	if !isReady.GetBool(sim) || timeToReady.GetDuration(sim) != 0 {
+		t.Fatalf("Expected the spell to be ready")
+	}
+
+	spell.CD.Use(sim)
+	sim.CurrentTime = time.Second * 4
+	if isReady.GetBool(sim) || timeToReady.GetDuration(sim) != time.Second*6 {
+		t.Fatalf("Unexpected time to ready %s", timeToReady.GetDuration(sim))
+	}
+
+	castTime := rot.newValueSpellCastTime(&proto.APLValueSpellCastTime{SpellId: spellId})
+	if castTime.GetDuration(sim) != time.Millisecond*1600 {
+		t.Fatalf("Unexpected cast time %s", castTime.GetDuration(sim))
+	}
+
+	if unknown := rot.newValueSpellIsReady(&proto.APLValueSpellIsReady{SpellId: ActionID{SpellID: 43}.ToProto()}); unknown != nil {
+		t.Fatalf("Expected no value for an unknown spell")
+	}
+}
+
+func TestValueSpellCanCast(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	spell := fa.RegisterSpell(SpellConfig{
+		ActionID: ActionID{SpellID: 44},
+		ProcMask: ProcMaskEmpty,
+		Cast: CastConfig{
+			CD: Cooldown{Timer: fa.NewTimer(), Duration: time.Second * 10},
+		},
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {},
+	})
+	rot := &APLRotation{unit: &fa.Unit}
+
+	canCast := rot.newValueSpellCanCast(&proto.APLValueSpellCanCast{SpellId: spell.ActionID.ToProto()})
+	if !canCast.GetBool(sim) {
+		t.Fatalf("Expected the spell to be castable")
+	}
+
+	spell.CD.Use(sim)
+	if canCast.GetBool(sim) {
+		t.Fatalf("Expected the spell to not be castable while on cooldown")
+	}
+}
+
+func TestValueAura(t *testing.T) {
+	sim := &Simulation{}
+	unit := &Unit{auraTracker: newAuraTracker()}
+	aura := unit.RegisterAura(Aura{Label: "Buff", ActionID: ActionID{SpellID: 123}, Duration: time.Second * 10, MaxStacks: 5})
+	rot := &APLRotation{unit: unit}
+	auraId := aura.ActionID.ToProto()
+
+	isActive := rot.newValueAuraIsActive(&proto.APLValueAuraIsActive{AuraId: auraId})
+	numStacks := rot.newValueAuraNumStacks(&proto.APLValueAuraNumStacks{AuraId: auraId})
+	remainingTime := rot.newValueAuraRemainingTime(&proto.APLValueAuraRemainingTime{AuraId: auraId})
+	if isActive.GetBool(sim) || numStacks.GetInt(sim) != 0 || remainingTime.GetDuration(sim) != 0 {
+		t.Fatalf("Expected the aura to be inactive")
+	}
+
+	aura.Activate(sim)
+	aura.SetStacks(sim, 3)
+	sim.CurrentTime = time.Second * 4
+	if !isActive.GetBool(sim) || numStacks.GetInt(sim) != 3 || remainingTime.GetDuration(sim) != time.Second*6 {
+		t.Fatalf("Unexpected aura state: active %t, stacks %d, remaining %s", isActive.GetBool(sim), numStacks.GetInt(sim), remainingTime.GetDuration(sim))
+	}
+
+	if noIcd := rot.newValueAuraInternalCooldown(&proto.APLValueAuraInternalCooldown{AuraId: auraId}); noIcd != nil {
+		t.Fatalf("Expected no value for an aura without an internal cooldown")
+	}
+	if warnings := rot.validationGroups[0].validations; len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got: %v", warnings)
+	}
+
+	aura.Icd = &Cooldown{Timer: unit.NewTimer(), Duration: time.Second * 45}
+	icd := rot.newValueAuraInternalCooldown(&proto.APLValueAuraInternalCooldown{AuraId: auraId})
+	if icd.GetDuration(sim) != 0 {
+		t.Fatalf("Expected the internal cooldown to be ready")
+	}
+	aura.Icd.Use(sim)
+	sim.CurrentTime = time.Second * 10
+	if icd.GetDuration(sim) != time.Second*39 {
+		t.Fatalf("Unexpected internal cooldown %s", icd.GetDuration(sim))
+	}
+}
+
+func TestValueDot(t *testing.T) {
+	sim := SetupFakeSim()
+	fa := sim.Raid.Parties[0].Players[0].(*FakeAgent)
+	spell := fa.RegisterSpell(SpellConfig{
+		ActionID:    ActionID{SpellID: 43},
+		SpellSchool: SpellSchoolShadow,
+		ProcMask:    ProcMaskSpellDamage,
+		Dot: DotConfig{
+			Aura:          Aura{Label: "Dot"},
+			NumberOfTicks: 4,
+			TickLength:    time.Second * 2,
+			OnTick:        func(sim *Simulation, target *Unit, dot *Dot) {},
+		},
+	})
+	rot := &APLRotation{unit: &fa.Unit}
+	spellId := spell.ActionID.ToProto()
+
+	isActive := rot.newValueDotIsActive(&proto.APLValueDotIsActive{SpellId: spellId})
+	remainingTime := rot.newValueDotRemainingTime(&proto.APLValueDotRemainingTime{SpellId: spellId})
+	ticksRemaining := rot.newValueDotTicksRemaining(&proto.APLValueDotTicksRemaining{SpellId: spellId})
+	if isActive.GetBool(sim) || remainingTime.GetDuration(sim) != 0 || ticksRemaining.GetInt(sim) != 0 {
+		t.Fatalf("Expected the dot to be inactive")
+	}
+
+	spell.CurDot().Apply(sim)
+	if !isActive.GetBool(sim) || remainingTime.GetDuration(sim) != time.Second*8 || ticksRemaining.GetInt(sim) != 4 {
+		t.Fatalf("Unexpected dot state: active %t, remaining %s, ticks %d", isActive.GetBool(sim), remainingTime.GetDuration(sim), ticksRemaining.GetInt(sim))
+	}
+
+	if noDot := rot.newValueDotIsActive(&proto.APLValueDotIsActive{SpellId: fa.Spell.ActionID.ToProto()}); noDot != nil {
+		t.Fatalf("Expected no value for a spell without dots")
+	}
+}
@@ -664,6 +664,16 @@ func (aa *AutoAttacks) DelayMeleeBy(sim *Simulation, delay time.Duration) {
 	aa.resetAutoSwing(sim)
 }
 
+func (aa *AutoAttacks) DelayMeleeUntil(sim *Simulation, readyAt time.Duration) {
+	if readyAt > aa.MainhandSwingAt || (aa.IsDualWielding && readyAt > aa.OffhandSwingAt) {
+		aa.MainhandSwingAt = MaxDuration(aa.MainhandSwingAt, readyAt)
+		if aa.IsDualWielding {
+			aa.OffhandSwingAt = MaxDuration(aa.OffhandSwingAt, readyAt)
+		}
+		aa.resetAutoSwing(sim)
+	}
+}
+
 func (aa *AutoAttacks) DelayRangedUntil(sim *Simulation, readyAt time.Duration) {
 	if readyAt > aa.RangedSwingAt {
 		aa.RangedSwingAt = readyAt
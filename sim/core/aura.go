@@ -49,6 +49,10 @@ type Aura struct {
 
 	Duration time.Duration // Duration of aura, upon being applied.
 
+	// Internal cooldown of the proc which activates this aura, if any. Only used
+	// to expose the cooldown to APL rotations.
+	Icd *Cooldown
+
 	startTime time.Duration // Time at which the aura was applied.
 	expires   time.Duration // Time at which aura will be removed.
 
@@ -190,6 +194,9 @@ func (aura *Aura) SetStacks(sim *Simulation, newStacks int32) {
 		aura.Unit.Log(sim, "%s stacks: %d --> %d", aura.ActionID, oldStacks, newStacks)
 	}
 	aura.stacks = newStacks
+	if sim.recordingEvents && !aura.ActionID.IsEmptyAction() {
+		sim.recordAuraEvent(aura, proto.AuraEvent_StacksChanged)
+	}
 	if aura.OnStacksChange != nil {
 		aura.OnStacksChange(aura, sim, oldStacks, newStacks)
 	}
@@ -294,6 +301,13 @@ type auraTracker struct {
 	onHealTakenAuras           []*Aura
 	onPeriodicHealDealtAuras   []*Aura
 	onPeriodicHealTakenAuras   []*Aura
+
+	// See SimOptions.timeline_bucket_seconds, 0 when timelines are disabled.
+	timelineBucketWidth time.Duration
+	// Fight time in each bucket, over all iterations.
+	timelineExposure timeline
+
+	auraOverlaps []*auraOverlap
 }
 
 func newAuraTracker() auraTracker {
@@ -323,6 +337,14 @@ func (at *auraTracker) GetAura(label string) *Aura {
 	}
 	return nil
 }
+func (at *auraTracker) GetAuraByID(actionID ActionID) *Aura {
+	for _, aura := range at.auras {
+		if aura.ActionID.SameAction(actionID) {
+			return aura
+		}
+	}
+	return nil
+}
 func (at *auraTracker) HasAura(label string) bool {
 	aura := at.GetAura(label)
 	return aura != nil
@@ -454,6 +476,14 @@ func (at *auraTracker) reset(sim *Simulation) {
 	at.onPeriodicHealDealtAuras = at.onPeriodicHealDealtAuras[:0]
 	at.onPeriodicHealTakenAuras = at.onPeriodicHealTakenAuras[:0]
 
+	at.timelineBucketWidth = DurationFromSeconds(sim.Options.TimelineBucketSeconds)
+	if at.auraOverlaps == nil {
+		at.auraOverlaps = newAuraOverlaps(sim.Options.AuraOverlaps)
+	}
+	for _, overlap := range at.auraOverlaps {
+		overlap.reset()
+	}
+
 	for _, resetEffect := range at.resetEffects {
 		resetEffect(sim)
 	}
@@ -500,6 +530,13 @@ func (at *auraTracker) doneIteration(sim *Simulation) {
 		aura.doneIteration(sim)
 	}
 
+	for _, overlap := range at.auraOverlaps {
+		overlap.doneIteration()
+	}
+	if at.timelineBucketWidth > 0 {
+		at.timelineExposure.addInterval(at.timelineBucketWidth, 0, sim.CurrentTime)
+	}
+
 	// Add metrics for any auras that are still active.
 	for _, aura := range at.auras {
 		aura.metrics.doneIteration()
@@ -541,6 +578,12 @@ func (aura *Aura) Activate(sim *Simulation) {
 	aura.startTime = sim.CurrentTime
 	aura.Refresh(sim)
 
+	if !aura.ActionID.IsEmptyAction() {
+		for _, overlap := range aura.Unit.auraOverlaps {
+			overlap.onAuraChange(aura.ActionID, true, sim.CurrentTime)
+		}
+	}
+
 	if aura.Duration != NeverExpires {
 		aura.activeIndex = int32(len(aura.Unit.activeAuras))
 		aura.Unit.activeAuras = append(aura.Unit.activeAuras, aura)
@@ -594,6 +637,9 @@ func (aura *Aura) Activate(sim *Simulation) {
 	if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
 		aura.Unit.Log(sim, "Aura gained: %s", aura.ActionID)
 	}
+	if sim.recordingEvents && !aura.ActionID.IsEmptyAction() {
+		sim.recordAuraEvent(aura, proto.AuraEvent_Gained)
+	}
 
 	if aura.OnGain != nil {
 		aura.OnGain(aura, sim)
@@ -620,16 +666,22 @@ func (aura *Aura) Deactivate(sim *Simulation) {
 	}
 
 	if !aura.ActionID.IsEmptyAction() {
-		if sim.CurrentTime > aura.expires {
-			aura.metrics.Uptime += aura.expires - aura.startTime
-		} else {
-			aura.metrics.Uptime += sim.CurrentTime - aura.startTime
+		endTime := MinDuration(sim.CurrentTime, aura.expires)
+		aura.metrics.Uptime += endTime - aura.startTime
+		if aura.Unit.timelineBucketWidth > 0 {
+			aura.metrics.timeline.addInterval(aura.Unit.timelineBucketWidth, aura.startTime, endTime)
+		}
+		for _, overlap := range aura.Unit.auraOverlaps {
+			overlap.onAuraChange(aura.ActionID, false, endTime)
 		}
 	}
 
 	if sim.Log != nil && !aura.ActionID.IsEmptyAction() {
 		aura.Unit.Log(sim, "Aura faded: %s", aura.ActionID)
 	}
+	if sim.recordingEvents && !aura.ActionID.IsEmptyAction() {
+		sim.recordAuraEvent(aura, proto.AuraEvent_Faded)
+	}
 
 	aura.expires = 0
 	if aura.activeIndex != Inactive {
@@ -808,18 +860,53 @@ func (at *auraTracker) OnPeriodicHealTaken(sim *Simulation, spell *Spell, result
 	}
 }
 
+// Adds the aura metrics of other, which must be the tracker of the same unit in
+// another Simulation. Auras are matched by label.
+func (at *auraTracker) mergeMetrics(other *auraTracker) {
+	for i, otherAura := range other.auras {
+		var aura *Aura
+		if i < len(at.auras) && at.auras[i].Label == otherAura.Label {
+			aura = at.auras[i]
+		} else {
+			aura = at.GetAura(otherAura.Label)
+		}
+		if aura != nil {
+			aura.metrics.merge(&otherAura.metrics)
+		}
+	}
+
+	at.timelineExposure.merge(other.timelineExposure)
+	for i, overlap := range at.auraOverlaps {
+		overlap.merge(other.auraOverlaps[i])
+	}
+}
+
 func (at *auraTracker) GetMetricsProto() []*proto.AuraMetrics {
 	metrics := make([]*proto.AuraMetrics, 0, len(at.auras))
 
 	for _, aura := range at.auras {
 		if !aura.metrics.ID.IsEmptyAction() {
-			metrics = append(metrics, aura.metrics.ToProto())
+			auraMetrics := aura.metrics.ToProto()
+			if at.timelineBucketWidth > 0 {
+				auraMetrics.UptimeTimeline = aura.metrics.timeline.averages(at.timelineExposure)
+			}
+			metrics = append(metrics, auraMetrics)
 		}
 	}
 
 	return metrics
 }
 
+func (at *auraTracker) getOverlapMetricsProto() []*proto.AuraOverlapMetrics {
+	var metrics []*proto.AuraOverlapMetrics
+	for _, overlap := range at.auraOverlaps {
+		if overlap.hasFirst && overlap.hasSecond {
+			metrics = append(metrics, overlap.ToProto())
+		}
+	}
+	return metrics
+}
+
 type AuraArray []*Aura
 
 func (auras AuraArray) Get(target *Unit) *Aura {
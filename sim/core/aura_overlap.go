@@ -0,0 +1,112 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Tracks the time during which two auras of a unit were active together, for
+// a pair in SimOptions.aura_overlaps.
+type auraOverlap struct {
+	first  ActionID
+	second ActionID
+
+	// Number of currently active auras matching first / second, since e.g.
+	// Bloodlust from different casters are separate auras.
+	firstActive  int32
+	secondActive int32
+
+	// Whether an aura matching first / second was ever active.
+	hasFirst  bool
+	hasSecond bool
+
+	startTime time.Duration
+
+	// Metrics for the current iteration.
+	uptime time.Duration
+
+	// Aggregate values. These are updated after each iteration.
+	n           int
+	uptimeSum   float64
+	uptimeSumSq float64
+}
+
+func newAuraOverlaps(pairs []*proto.AuraPair) []*auraOverlap {
+	overlaps := make([]*auraOverlap, 0, len(pairs))
+	for _, pair := range pairs {
+		if pair.First == nil || pair.Second == nil {
+			continue
+		}
+		overlaps = append(overlaps, &auraOverlap{
+			first:  ProtoToActionID(pair.First),
+			second: ProtoToActionID(pair.Second),
+		})
+	}
+	return overlaps
+}
+
+func auraOverlapMatches(configured ActionID, actionID ActionID) bool {
+	if configured.Tag == 0 {
+		return configured.SameActionIgnoreTag(actionID)
+	}
+	return configured.SameAction(actionID)
+}
+
+// Should be called whenever an aura with an ActionID is activated or deactivated.
+func (overlap *auraOverlap) onAuraChange(actionID ActionID, active bool, at time.Duration) {
+	matchesFirst := auraOverlapMatches(overlap.first, actionID)
+	matchesSecond := auraOverlapMatches(overlap.second, actionID)
+	if !matchesFirst && !matchesSecond {
+		return
+	}
+
+	wasActive := overlap.firstActive > 0 && overlap.secondActive > 0
+	delta := TernaryInt32(active, 1, -1)
+	if matchesFirst {
+		overlap.hasFirst = true
+		overlap.firstActive += delta
+	}
+	if matchesSecond {
+		overlap.hasSecond = true
+		overlap.secondActive += delta
+	}
+	isActive := overlap.firstActive > 0 && overlap.secondActive > 0
+
+	if !wasActive && isActive {
+		overlap.startTime = at
+	} else if wasActive && !isActive {
+		overlap.uptime += at - overlap.startTime
+	}
+}
+
+func (overlap *auraOverlap) reset() {
+	overlap.uptime = 0
+}
+
+func (overlap *auraOverlap) doneIteration() {
+	overlap.n++
+	overlap.uptimeSum += overlap.uptime.Seconds()
+	overlap.uptimeSumSq += math.Pow(overlap.uptime.Seconds(), 2)
+}
+
+func (overlap *auraOverlap) merge(other *auraOverlap) {
+	overlap.hasFirst = overlap.hasFirst || other.hasFirst
+	overlap.hasSecond = overlap.hasSecond || other.hasSecond
+	overlap.n += other.n
+	overlap.uptimeSum += other.uptimeSum
+	overlap.uptimeSumSq += other.uptimeSumSq
+}
+
+func (overlap *auraOverlap) ToProto() *proto.AuraOverlapMetrics {
+	mean, stdev := calcMeanAndStdevFromSums(overlap.n, overlap.uptimeSum, overlap.uptimeSumSq)
+
+	return &proto.AuraOverlapMetrics{
+		First:  overlap.first.ToProto(),
+		Second: overlap.second.ToProto(),
+
+		UptimeSecondsAvg:   mean,
+		UptimeSecondsStdev: stdev,
+	}
+}
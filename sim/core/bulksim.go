@@ -35,8 +35,10 @@ type bulkSimRunner struct {
 
 func BulkSim(ctx context.Context, request *proto.BulkSimRequest, progress chan *proto.ProgressMetrics) *proto.BulkSimResult {
 	bulk := &bulkSimRunner{
-		SingleRaidSimRunner: runSim,
-		Request:             request,
+		SingleRaidSimRunner: func(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool) *proto.RaidSimResult {
+			return runSim(ctx, rsr, progress, skipPresim)
+		},
+		Request: request,
 	}
 
 	result, err := bulk.Run(ctx, progress)
@@ -352,6 +354,8 @@ func (b *bulkSimRunner) getRankedResults(pctx context.Context, validCombos []sin
 			go func(sub singleBulkSim) {
 				// overwrite the requests iterations with the input for this function.
 				sub.req.SimOptions.Iterations = int32(iterations)
+				// Combos already run in parallel.
+				sub.req.SimOptions.Concurrency = 0
 				results <- &itemSubstitutionSimResult{
 					Request:      sub.req,
 					Result:       b.SingleRaidSimRunner(sub.req, singleSimProgress, false),
@@ -373,6 +377,10 @@ func (b *bulkSimRunner) getRankedResults(pctx context.Context, validCombos []sin
 			cancel() // cancel reporter
 			return nil, nil, errors.New("simulation failed: " + result.Result.ErrorResult)
 		}
+		if result.Result.Cancelled {
+			cancel() // cancel reporter
+			return nil, nil, errors.New("simulation cancelled")
+		}
 		if !result.Substitution.HasItemReplacements() {
 			baseResult = result
 		}
@@ -17,6 +17,9 @@ type Hardcast struct {
 	ActionID   ActionID
 	OnComplete func(*Simulation, *Unit)
 	Target     *Unit
+
+	// The spell being cast or channeled, if any.
+	spell *Spell
 }
 
 // Input for constructing the CastSpell function for a spell.
@@ -74,13 +77,14 @@ type CastSuccessFunc func(*Simulation, *Unit) bool
 func (spell *Spell) makeCastFunc(config CastConfig, onCastComplete CastFunc) CastSuccessFunc {
 	return spell.wrapCastFuncInit(config,
 		spell.wrapCastFuncExtraCond(config,
-			spell.wrapCastFuncCDsReady(config,
-				spell.wrapCastFuncResources(config,
-					spell.wrapCastFuncHaste(config,
-						spell.wrapCastFuncGCD(config,
-							spell.wrapCastFuncCooldown(config,
-								spell.wrapCastFuncSharedCooldown(config,
-									spell.makeCastFuncWait(config, onCastComplete)))))))))
+			spell.wrapCastFuncMovement(config,
+				spell.wrapCastFuncCDsReady(config,
+					spell.wrapCastFuncResources(config,
+						spell.wrapCastFuncHaste(config,
+							spell.wrapCastFuncGCD(config,
+								spell.wrapCastFuncCooldown(config,
+									spell.wrapCastFuncSharedCooldown(config,
+										spell.makeCastFuncWait(config, onCastComplete))))))))))
 }
 
 func (spell *Spell) ApplyCostModifiers(cost float64) float64 {
@@ -133,6 +137,22 @@ func (spell *Spell) wrapCastFuncExtraCond(config CastConfig, onCastComplete Cast
 	}
 }
 
+func (spell *Spell) wrapCastFuncMovement(config CastConfig, onCastComplete CastSuccessFunc) CastSuccessFunc {
+	if spell.DefaultCast.CastTime == 0 && spell.DefaultCast.ChannelTime == 0 {
+		return onCastComplete
+	}
+
+	return func(sim *Simulation, target *Unit) bool {
+		if spell.castBlockedByMovement(&spell.CurCast) {
+			if sim.Log != nil {
+				sim.Log("Failed cast because of movement")
+			}
+			return false
+		}
+		return onCastComplete(sim, target)
+	}
+}
+
 func (spell *Spell) wrapCastFuncCDsReady(config CastConfig, onCastComplete CastSuccessFunc) CastSuccessFunc {
 	if spell.Unit.PseudoStats.GracefulCastCDFailures {
 		return func(sim *Simulation, target *Unit) bool {
@@ -283,12 +303,16 @@ func (spell *Spell) makeCastFuncWait(config CastConfig, onCastComplete CastFunc)
 
 	if spell.DefaultCast.ChannelTime > 0 {
 		return func(sim *Simulation, target *Unit) {
-			spell.Unit.Hardcast = Hardcast{Expires: sim.CurrentTime + spell.CurCast.ChannelTime, ActionID: spell.ActionID}
+			spell.Unit.Hardcast = Hardcast{Expires: sim.CurrentTime + spell.CurCast.ChannelTime, ActionID: spell.ActionID, spell: spell}
 			if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
 				spell.Unit.Log(sim, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
 					spell.ActionID, MaxFloat(0, spell.CurCast.Cost), spell.CurCast.CastTime, spell.CurCast.EffectiveTime())
 				spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 			}
+			if sim.recordingEvents && !spell.Flags.Matches(SpellFlagNoLogs) {
+				sim.recordCastStart(spell, target)
+				sim.recordCastComplete(spell, target)
+			}
 			onCastComplete(sim, target)
 		}
 	}
@@ -303,6 +327,10 @@ func (spell *Spell) makeCastFuncWait(config CastConfig, onCastComplete CastFunc)
 						spell.ActionID, MaxFloat(0, spell.CurCast.Cost), spell.CurCast.CastTime, spell.CurCast.EffectiveTime())
 					spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 				}
+				if sim.recordingEvents {
+					sim.recordCastStart(spell, target)
+					sim.recordCastComplete(spell, target)
+				}
 				onCastComplete(sim, target)
 			}
 		}
@@ -313,6 +341,9 @@ func (spell *Spell) makeCastFuncWait(config CastConfig, onCastComplete CastFunc)
 				if sim.Log != nil && !spell.Flags.Matches(SpellFlagNoLogs) {
 					spell.Unit.Log(sim, "Completed cast %s", spell.ActionID)
 				}
+				if sim.recordingEvents {
+					sim.recordCastComplete(spell, target)
+				}
 				oldOnCastComplete3(sim, target)
 			}
 		}
@@ -322,6 +353,9 @@ func (spell *Spell) makeCastFuncWait(config CastConfig, onCastComplete CastFunc)
 				spell.Unit.Log(sim, "Casting %s (Cost = %0.03f, Cast Time = %s, Effective Time = %s)",
 					spell.ActionID, MaxFloat(0, spell.CurCast.Cost), spell.CurCast.CastTime, spell.CurCast.EffectiveTime())
 			}
+			if sim.recordingEvents && !spell.Flags.Matches(SpellFlagNoLogs) {
+				sim.recordCastStart(spell, target)
+			}
 
 			// For instant-cast spells we can skip creating an aura.
 			if spell.CurCast.CastTime == 0 {
@@ -332,6 +366,7 @@ func (spell *Spell) makeCastFuncWait(config CastConfig, onCastComplete CastFunc)
 					ActionID:   spell.ActionID,
 					OnComplete: onCastComplete,
 					Target:     target,
+					spell:      spell,
 				}
 
 				// If hardcast and GCD happen at the same time then we don't need a separate action.
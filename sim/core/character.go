@@ -397,6 +397,18 @@ func (character *Character) initialize(agent Agent) {
 				agent.OnGCDReady(sim)
 
 				if !character.doNothing && character.GCD.IsReady(sim) && (!character.IsWaiting() && !character.IsWaitingForMana()) {
+					if character.moving {
+						// Everything the rotation tried to cast needs the character to stand still.
+						character.WaitUntil(sim, character.movementAction.NextActionAt)
+						character.doNothing = false
+						return
+					}
+					if targetableAt := character.Env.Encounter.targetableAt(character.CurrentTarget); targetableAt > sim.CurrentTime {
+						// Legacy rotations may be locked onto a target which can't be attacked right now.
+						character.WaitUntil(sim, targetableAt)
+						character.doNothing = false
+						return
+					}
 					msg := fmt.Sprintf("Character `%s` did not perform any actions. Either this is a bug or agent should use 'WaitUntil' or 'WaitForMana' to explicitly wait.\n\tIf character has no action to perform use 'DoNothing'.", character.Label)
 					panic(msg)
 				}
@@ -446,10 +458,18 @@ func (character *Character) FillPlayerStats(playerStats *proto.PlayerStats) {
 	playerStats.Auras = MapSlice(aplAuras, func(aura *Aura) *proto.ActionID {
 		return aura.ActionID.ToProto()
 	})
+
+	if character.Rotation != nil {
+		playerStats.RotationValidations = character.Rotation.validations
+	}
 }
 
 func (character *Character) init(sim *Simulation, agent Agent) {
 	character.Unit.init(sim)
+
+	if character.Rotation != nil {
+		character.Rotation.init(sim)
+	}
 }
 
 func (character *Character) reset(sim *Simulation, agent Agent) {
@@ -457,6 +477,14 @@ func (character *Character) reset(sim *Simulation, agent Agent) {
 	character.majorCooldownManager.reset(sim)
 	character.ItemSwap.reset(sim)
 	character.CurrentTarget = character.defaultTarget
+	// The default target may start despawned, e.g. when it's in a later add wave.
+	if character.CurrentTarget != nil && character.CurrentTarget.Type == EnemyUnit && !character.CurrentTarget.IsTargetable() && len(sim.Encounter.ActiveTargetUnits) > 0 {
+		character.CurrentTarget = sim.Encounter.ActiveTargetUnits[0]
+	}
+
+	if character.Rotation != nil {
+		character.Rotation.reset(sim)
+	}
 
 	agent.Reset(sim)
 
@@ -580,6 +608,10 @@ func (character *Character) doneIteration(sim *Simulation) {
 	}
 
 	character.Unit.doneIteration(sim)
+
+	if character.Rotation != nil {
+		character.Rotation.doneIteration(sim)
+	}
 }
 
 func (character *Character) GetPseudoStatsProto() []float64 {
@@ -600,10 +632,21 @@ func (character *Character) GetMetricsProto() *proto.UnitMetrics {
 	metrics.Name = character.Name
 	metrics.UnitIndex = character.UnitIndex
 	metrics.Auras = character.auraTracker.GetMetricsProto()
+	metrics.AuraOverlaps = character.auraTracker.getOverlapMetricsProto()
+	if character.Rotation != nil {
+		metrics.AplListItems = character.Rotation.getMetricsProto()
+	}
 
 	metrics.Pets = []*proto.UnitMetrics{}
 	for _, petAgent := range character.Pets {
-		metrics.Pets = append(metrics.Pets, petAgent.GetPet().GetMetricsProto())
+		petMetrics := petAgent.GetPet().GetMetricsProto()
+		// Like dps, the dps timeline includes pets.
+		for i, petDps := range petMetrics.DpsTimeline {
+			if i < len(metrics.DpsTimeline) {
+				metrics.DpsTimeline[i] += petDps
+			}
+		}
+		metrics.Pets = append(metrics.Pets, petMetrics)
 	}
 
 	return metrics
@@ -0,0 +1,514 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Parser for the 3.3.5 WoWCombatLog.txt format, which has lines like:
+//
+//	9/23 21:08:01.123  SPELL_DAMAGE,0x0600000000A1B2C3,"Player",0x514,0xF130007E8A00001E,"Boss",0xa48,47809,"Shadow Bolt",0x20,5234,0,32,0,0,0,1,nil,nil
+//
+// i.e. a timestamp followed by the event type, source and destination units
+// (GUID, name, flags), spell fields for SPELL_* / RANGE_* events, and event
+// specific suffix fields.
+
+// Unit flag for player characters.
+const combatLogFlagTypePlayer = 0x400
+
+const combatLogTimestampLayout = "1/2 15:04:05.000"
+
+// Auto Shot, which is logged as a RANGE_DAMAGE spell.
+const combatLogAutoShotSpellID = 75
+
+type combatLogUnit struct {
+	guid  string
+	name  string
+	flags uint64
+}
+
+type combatLogEvent struct {
+	timestamp time.Duration // Since the first event in the log.
+	eventType string
+	source    combatLogUnit
+	dest      combatLogUnit
+
+	actionID  ActionID
+	spellName string
+
+	amount   float64
+	critical bool
+	glancing bool
+	missType string
+}
+
+// A parsed combat log.
+type CombatLog struct {
+	events []combatLogEvent
+
+	// Pet GUID to owner GUID, from SPELL_SUMMON events.
+	petOwners map[string]string
+}
+
+// Summary of a single player's activity in a combat log.
+type CombatLogPlayer struct {
+	// Actions, auras and DPS in the same shape as a single sim iteration.
+	Metrics *proto.UnitMetrics
+
+	// From the player's first to last event, which is used as fight length.
+	Duration time.Duration
+
+	// Spell names from the log, for display.
+	ActionNames map[ActionID]string
+}
+
+func ParseCombatLog(r io.Reader) (*CombatLog, error) {
+	combatLog := &CombatLog{
+		petOwners: make(map[string]string),
+	}
+
+	var firstTimestamp time.Time
+	var lastTimestamp time.Time
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		timestamp, event, err := parseCombatLogLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if event == nil {
+			continue
+		}
+
+		if firstTimestamp.IsZero() {
+			firstTimestamp = timestamp
+		}
+		// Timestamps have no year, so handle logs spanning new year's eve.
+		for timestamp.Before(lastTimestamp.Add(-time.Hour)) {
+			timestamp = timestamp.AddDate(1, 0, 0)
+		}
+		lastTimestamp = timestamp
+		event.timestamp = timestamp.Sub(firstTimestamp)
+
+		if event.eventType == "SPELL_SUMMON" {
+			combatLog.petOwners[event.dest.guid] = event.source.guid
+		}
+		combatLog.events = append(combatLog.events, *event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(combatLog.events) == 0 {
+		return nil, fmt.Errorf("no combat log events found")
+	}
+	return combatLog, nil
+}
+
+// Parses a single line, returning a nil event for event types we don't use.
+func parseCombatLogLine(line string) (time.Time, *combatLogEvent, error) {
+	timestampStr, eventStr, found := strings.Cut(line, "  ")
+	if !found {
+		return time.Time{}, nil, fmt.Errorf("missing timestamp")
+	}
+	timestamp, err := time.Parse(combatLogTimestampLayout, timestampStr)
+	if err != nil {
+		return time.Time{}, nil, fmt.Errorf("invalid timestamp %q: %w", timestampStr, err)
+	}
+
+	fields := splitCombatLogFields(eventStr)
+	if len(fields) < 7 {
+		return timestamp, nil, nil
+	}
+
+	event := &combatLogEvent{
+		eventType: fields[0],
+		source:    parseCombatLogUnit(fields[1:4]),
+		dest:      parseCombatLogUnit(fields[4:7]),
+	}
+
+	// Spell prefix fields.
+	suffix := fields[7:]
+	switch {
+	case strings.HasPrefix(event.eventType, "SWING_"):
+		event.actionID = ActionID{OtherID: proto.OtherAction_OtherActionAttack}
+		event.spellName = "Melee"
+	case strings.HasPrefix(event.eventType, "SPELL_"), strings.HasPrefix(event.eventType, "RANGE_"), strings.HasPrefix(event.eventType, "DAMAGE_SHIELD"):
+		if len(suffix) < 3 {
+			return timestamp, nil, fmt.Errorf("%s: missing spell fields", event.eventType)
+		}
+		spellID, err := strconv.Atoi(suffix[0])
+		if err != nil {
+			return timestamp, nil, fmt.Errorf("%s: invalid spell ID %q", event.eventType, suffix[0])
+		}
+		if spellID == combatLogAutoShotSpellID {
+			event.actionID = ActionID{OtherID: proto.OtherAction_OtherActionShoot}
+		} else {
+			event.actionID = ActionID{SpellID: int32(spellID)}
+		}
+		event.spellName = suffix[1]
+		suffix = suffix[3:]
+	default:
+		return timestamp, nil, nil
+	}
+
+	// Suffix fields.
+	switch {
+	case strings.HasSuffix(event.eventType, "_DAMAGE") || event.eventType == "DAMAGE_SHIELD":
+		// amount, overkill, school, resisted, blocked, absorbed, critical, glancing, crushing
+		if len(suffix) < 9 {
+			return timestamp, nil, fmt.Errorf("%s: expected 9 damage fields, got %d", event.eventType, len(suffix))
+		}
+		event.amount, err = strconv.ParseFloat(suffix[0], 64)
+		if err != nil {
+			return timestamp, nil, fmt.Errorf("%s: invalid amount %q", event.eventType, suffix[0])
+		}
+		event.critical = combatLogBool(suffix[6])
+		event.glancing = combatLogBool(suffix[7])
+	case strings.HasSuffix(event.eventType, "_MISSED"):
+		if len(suffix) < 1 {
+			return timestamp, nil, fmt.Errorf("%s: missing miss type", event.eventType)
+		}
+		event.missType = suffix[0]
+	case strings.HasSuffix(event.eventType, "_HEAL"):
+		// amount, overhealing, absorbed, critical
+		if len(suffix) < 1 {
+			return timestamp, nil, fmt.Errorf("%s: missing amount", event.eventType)
+		}
+		event.amount, err = strconv.ParseFloat(suffix[0], 64)
+		if err != nil {
+			return timestamp, nil, fmt.Errorf("%s: invalid amount %q", event.eventType, suffix[0])
+		}
+		if len(suffix) >= 4 {
+			event.critical = combatLogBool(suffix[3])
+		}
+	}
+
+	return timestamp, event, nil
+}
+
+// Splits comma separated fields, keeping commas within quoted names.
+func splitCombatLogFields(s string) []string {
+	var fields []string
+	var sb strings.Builder
+	inQuotes := false
+	for _, c := range s {
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == ',' && !inQuotes:
+			fields = append(fields, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteRune(c)
+		}
+	}
+	return append(fields, sb.String())
+}
+
+func parseCombatLogUnit(fields []string) combatLogUnit {
+	flags, _ := strconv.ParseUint(strings.TrimPrefix(fields[2], "0x"), 16, 64)
+	return combatLogUnit{
+		guid:  fields[0],
+		name:  fields[1],
+		flags: flags,
+	}
+}
+
+func combatLogBool(field string) bool {
+	return field == "1"
+}
+
+// Returns the names of all players which have events as a source.
+func (combatLog *CombatLog) PlayerNames() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, event := range combatLog.events {
+		if event.source.flags&combatLogFlagTypePlayer != 0 && !seen[event.source.name] {
+			seen[event.source.name] = true
+			names = append(names, event.source.name)
+		}
+	}
+	return names
+}
+
+// Builds the metrics for the player with the given name, including pets
+// summoned during the log (permanent pets summoned before the log started
+// can't be attributed to their owner).
+func (combatLog *CombatLog) Player(name string) (*CombatLogPlayer, error) {
+	playerGUID := ""
+	for _, event := range combatLog.events {
+		if event.source.flags&combatLogFlagTypePlayer != 0 && strings.EqualFold(event.source.name, name) {
+			playerGUID = event.source.guid
+			break
+		}
+	}
+	if playerGUID == "" {
+		return nil, fmt.Errorf("no events from player %q in combat log, found: %s", name, strings.Join(combatLog.PlayerNames(), ", "))
+	}
+
+	summary := &CombatLogPlayer{
+		ActionNames: make(map[ActionID]string),
+	}
+
+	// Fight length is the time between the player's first and last actions.
+	var start, end time.Duration
+	found := false
+	for _, event := range combatLog.events {
+		if combatLog.isPlayerOrPet(event.source.guid, playerGUID) {
+			if !found {
+				start = event.timestamp
+				found = true
+			}
+			end = event.timestamp
+		}
+	}
+	summary.Duration = end - start
+	if summary.Duration <= 0 {
+		return nil, fmt.Errorf("player %q has no events spanning any time", name)
+	}
+
+	player := newCombatLogUnitMetrics(name)
+	pets := make(map[string]*combatLogUnitMetrics)
+	var petOrder []string
+	targetIndices := make(map[string]int32)
+
+	for _, event := range combatLog.events {
+		if event.timestamp < start || event.timestamp > end {
+			continue
+		}
+
+		// Auras on the player.
+		if event.dest.guid == playerGUID && strings.HasPrefix(event.eventType, "SPELL_AURA_") {
+			summary.ActionNames[event.actionID] = event.spellName
+			player.addAuraEvent(event, start)
+			continue
+		}
+
+		var unit *combatLogUnitMetrics
+		if event.source.guid == playerGUID {
+			unit = player
+		} else if combatLog.petOwners[event.source.guid] == playerGUID {
+			unit = pets[event.source.guid]
+			if unit == nil {
+				unit = newCombatLogUnitMetrics(event.source.name)
+				pets[event.source.guid] = unit
+				petOrder = append(petOrder, event.source.guid)
+			}
+		} else {
+			continue
+		}
+
+		targetIndex, ok := targetIndices[event.dest.guid]
+		if !ok {
+			targetIndex = int32(len(targetIndices))
+			targetIndices[event.dest.guid] = targetIndex
+		}
+		if unit.addActionEvent(event, targetIndex) {
+			summary.ActionNames[event.actionID] = event.spellName
+		}
+	}
+
+	// Like in the sim, the owner's DPS includes that of their pets.
+	var petMetrics []*proto.UnitMetrics
+	for _, guid := range petOrder {
+		petMetrics = append(petMetrics, pets[guid].toProto(start, end))
+		player.damage += pets[guid].damage
+	}
+	summary.Metrics = player.toProto(start, end)
+	summary.Metrics.Pets = petMetrics
+	return summary, nil
+}
+
+func (combatLog *CombatLog) isPlayerOrPet(guid string, playerGUID string) bool {
+	return guid == playerGUID || combatLog.petOwners[guid] == playerGUID
+}
+
+type combatLogAuraMetrics struct {
+	procs     int32
+	active    bool
+	startTime time.Duration
+	uptime    time.Duration
+	sawEvent  bool
+}
+
+type combatLogActionMetrics struct {
+	isMelee bool
+
+	// Whether any SPELL_CAST_SUCCESS events were logged for this action, if not
+	// casts are counted from damage / heal events (e.g. for procs).
+	hasCastEvents bool
+
+	targets map[int32]*proto.TargetedActionMetrics
+}
+
+type combatLogUnitMetrics struct {
+	name        string
+	damage      float64
+	healing     float64
+	actions     map[ActionID]*combatLogActionMetrics
+	actionOrder []ActionID
+	auras       map[ActionID]*combatLogAuraMetrics
+	auraOrder   []ActionID
+}
+
+func newCombatLogUnitMetrics(name string) *combatLogUnitMetrics {
+	return &combatLogUnitMetrics{
+		name:    name,
+		actions: make(map[ActionID]*combatLogActionMetrics),
+		auras:   make(map[ActionID]*combatLogAuraMetrics),
+	}
+}
+
+func (unit *combatLogUnitMetrics) addAuraEvent(event combatLogEvent, start time.Duration) {
+	aura := unit.auras[event.actionID]
+	if aura == nil {
+		aura = &combatLogAuraMetrics{}
+		unit.auras[event.actionID] = aura
+		unit.auraOrder = append(unit.auraOrder, event.actionID)
+	}
+
+	switch event.eventType {
+	case "SPELL_AURA_APPLIED":
+		aura.procs++
+		if !aura.active {
+			aura.active = true
+			aura.startTime = event.timestamp
+		}
+	case "SPELL_AURA_REFRESH":
+		aura.procs++
+	case "SPELL_AURA_REMOVED":
+		if aura.active {
+			aura.uptime += event.timestamp - aura.startTime
+			aura.active = false
+		} else if !aura.sawEvent {
+			// Already active when the player's first event happened.
+			aura.uptime += event.timestamp - start
+		}
+	}
+	aura.sawEvent = true
+}
+
+// Returns whether the event was used.
+func (unit *combatLogUnitMetrics) addActionEvent(event combatLogEvent, targetIndex int32) bool {
+	isCast := event.eventType == "SPELL_CAST_SUCCESS"
+	isDamage := strings.HasSuffix(event.eventType, "_DAMAGE") || event.eventType == "DAMAGE_SHIELD"
+	isHeal := strings.HasSuffix(event.eventType, "_HEAL")
+	isMiss := strings.HasSuffix(event.eventType, "_MISSED")
+	if !isCast && !isDamage && !isHeal && !isMiss {
+		return false
+	}
+
+	action := unit.actions[event.actionID]
+	if action == nil {
+		action = &combatLogActionMetrics{
+			isMelee: strings.HasPrefix(event.eventType, "SWING_") || strings.HasPrefix(event.eventType, "RANGE_"),
+			targets: make(map[int32]*proto.TargetedActionMetrics),
+		}
+		unit.actions[event.actionID] = action
+		unit.actionOrder = append(unit.actionOrder, event.actionID)
+	}
+	target := action.targets[targetIndex]
+	if target == nil {
+		target = &proto.TargetedActionMetrics{UnitIndex: targetIndex}
+		action.targets[targetIndex] = target
+	}
+
+	if isCast {
+		if !action.hasCastEvents {
+			// Discard casts counted from damage events so far.
+			action.hasCastEvents = true
+			for _, t := range action.targets {
+				t.Casts = 0
+			}
+		}
+		target.Casts++
+		return true
+	}
+
+	isPeriodic := strings.Contains(event.eventType, "_PERIODIC_")
+	if !action.hasCastEvents && !isPeriodic {
+		target.Casts++
+	}
+
+	switch {
+	case isMiss:
+		switch event.missType {
+		case "DODGE":
+			target.Dodges++
+		case "PARRY":
+			target.Parries++
+		case "BLOCK":
+			target.Blocks++
+		default:
+			target.Misses++
+		}
+	case event.critical:
+		target.Crits++
+	default:
+		target.Hits++
+	}
+
+	if isDamage {
+		if event.glancing {
+			target.Glances++
+		}
+		target.Damage += event.amount
+		unit.damage += event.amount
+	} else if isHeal {
+		target.Healing += event.amount
+		unit.healing += event.amount
+	}
+	return true
+}
+
+func (unit *combatLogUnitMetrics) toProto(start time.Duration, end time.Duration) *proto.UnitMetrics {
+	seconds := (end - start).Seconds()
+	metrics := &proto.UnitMetrics{
+		Name: unit.name,
+		Dps:  &proto.DistributionMetrics{Avg: unit.damage / seconds, Max: unit.damage / seconds, Min: unit.damage / seconds},
+		Hps:  &proto.DistributionMetrics{Avg: unit.healing / seconds, Max: unit.healing / seconds, Min: unit.healing / seconds},
+	}
+
+	for _, actionID := range unit.actionOrder {
+		action := unit.actions[actionID]
+		actionMetrics := &proto.ActionMetrics{
+			Id:      actionID.ToProto(),
+			IsMelee: action.isMelee,
+		}
+		for _, target := range action.targets {
+			actionMetrics.Targets = append(actionMetrics.Targets, target)
+		}
+		sort.Slice(actionMetrics.Targets, func(i, j int) bool {
+			return actionMetrics.Targets[i].UnitIndex < actionMetrics.Targets[j].UnitIndex
+		})
+		metrics.Actions = append(metrics.Actions, actionMetrics)
+	}
+
+	for _, actionID := range unit.auraOrder {
+		aura := unit.auras[actionID]
+		uptime := aura.uptime
+		if aura.active {
+			uptime += end - aura.startTime
+		}
+		metrics.Auras = append(metrics.Auras, &proto.AuraMetrics{
+			Id:               actionID.ToProto(),
+			UptimeSecondsAvg: MinDuration(uptime, end-start).Seconds(),
+			ProcsAvg:         float64(aura.procs),
+		})
+	}
+	return metrics
+}
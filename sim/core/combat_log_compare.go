@@ -0,0 +1,114 @@
+package core
+
+import (
+	"sort"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Per fight cast count and DPS of an action, in a combat log and a sim.
+type CombatLogActionDelta struct {
+	ActionID ActionID
+
+	LogCasts float64
+	SimCasts float64
+	LogDPS   float64
+	SimDPS   float64
+}
+
+// Uptime of an aura as a fraction of the fight, in a combat log and a sim.
+type CombatLogAuraDelta struct {
+	ActionID ActionID
+
+	LogUptime float64
+	SimUptime float64
+}
+
+type CombatLogComparison struct {
+	LogDPS float64
+	SimDPS float64
+
+	Actions []CombatLogActionDelta
+	Auras   []CombatLogAuraDelta
+}
+
+// Compares a player from a combat log with the metrics of the same player from
+// a sim result. Actions and auras are matched ignoring tags, since the log
+// doesn't distinguish e.g. main hand and off hand swings.
+func CompareCombatLog(logPlayer *CombatLogPlayer, simMetrics *proto.UnitMetrics, simIterations int32, simDuration time.Duration) *CombatLogComparison {
+	comparison := &CombatLogComparison{
+		LogDPS: logPlayer.Metrics.Dps.GetAvg(),
+		SimDPS: simMetrics.Dps.GetAvg(),
+	}
+
+	actions := make(map[ActionID]*CombatLogActionDelta)
+	getAction := func(actionID ActionID) *CombatLogActionDelta {
+		actionID = actionID.WithTag(0)
+		if actions[actionID] == nil {
+			actions[actionID] = &CombatLogActionDelta{ActionID: actionID}
+		}
+		return actions[actionID]
+	}
+	for _, action := range logPlayer.Metrics.Actions {
+		delta := getAction(ProtoToActionID(action.Id))
+		for _, target := range action.Targets {
+			delta.LogCasts += float64(target.Casts)
+			delta.LogDPS += target.Damage / logPlayer.Duration.Seconds()
+		}
+	}
+	for _, action := range simMetrics.Actions {
+		delta := getAction(ProtoToActionID(action.Id))
+		for _, target := range action.Targets {
+			delta.SimCasts += float64(target.Casts) / float64(simIterations)
+			delta.SimDPS += target.Damage / float64(simIterations) / simDuration.Seconds()
+		}
+	}
+	for _, delta := range actions {
+		// Skip actions which don't do anything in either, e.g. unused spells.
+		if delta.LogCasts+delta.SimCasts+delta.LogDPS+delta.SimDPS == 0 {
+			continue
+		}
+		comparison.Actions = append(comparison.Actions, *delta)
+	}
+	sort.Slice(comparison.Actions, func(i, j int) bool {
+		a, b := comparison.Actions[i], comparison.Actions[j]
+		if a.LogDPS+a.SimDPS != b.LogDPS+b.SimDPS {
+			return a.LogDPS+a.SimDPS > b.LogDPS+b.SimDPS
+		}
+		if a.LogCasts+a.SimCasts != b.LogCasts+b.SimCasts {
+			return a.LogCasts+a.SimCasts > b.LogCasts+b.SimCasts
+		}
+		return a.ActionID.String() < b.ActionID.String()
+	})
+
+	auras := make(map[ActionID]*CombatLogAuraDelta)
+	getAura := func(actionID ActionID) *CombatLogAuraDelta {
+		actionID = actionID.WithTag(0)
+		if auras[actionID] == nil {
+			auras[actionID] = &CombatLogAuraDelta{ActionID: actionID}
+		}
+		return auras[actionID]
+	}
+	for _, aura := range logPlayer.Metrics.Auras {
+		getAura(ProtoToActionID(aura.Id)).LogUptime += aura.UptimeSecondsAvg / logPlayer.Duration.Seconds()
+	}
+	for _, aura := range simMetrics.Auras {
+		getAura(ProtoToActionID(aura.Id)).SimUptime += aura.UptimeSecondsAvg / simDuration.Seconds()
+	}
+	for _, delta := range auras {
+		// Tagged auras can overlap, e.g. for multiple procs of the same trinket.
+		delta.LogUptime = MinFloat(delta.LogUptime, 1)
+		delta.SimUptime = MinFloat(delta.SimUptime, 1)
+		comparison.Auras = append(comparison.Auras, *delta)
+	}
+	sort.Slice(comparison.Auras, func(i, j int) bool {
+		a, b := comparison.Auras[i], comparison.Auras[j]
+		if a.LogUptime+a.SimUptime != b.LogUptime+b.SimUptime {
+			return a.LogUptime+a.SimUptime > b.LogUptime+b.SimUptime
+		}
+		return a.ActionID.String() < b.ActionID.String()
+	})
+
+	return comparison
+}
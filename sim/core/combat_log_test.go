@@ -0,0 +1,132 @@
+package core
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+const testCombatLog = `10/14 20:00:00.000  SPELL_AURA_REMOVED,0x0600000000000001,"Lock",0x511,0x0600000000000001,"Lock",0x511,47893,"Fel Armor",0x20,BUFF
+10/14 20:00:00.000  SPELL_SUMMON,0x0600000000000001,"Lock",0x511,0xF140000000000002,"Imp",0x1111,688,"Summon Imp",0x20
+10/14 20:00:02.000  SPELL_CAST_SUCCESS,0x0600000000000001,"Lock",0x511,0xF130007E8A00001E,"Boss, the Big",0xa48,47809,"Shadow Bolt",0x20
+10/14 20:00:02.500  SPELL_DAMAGE,0x0600000000000001,"Lock",0x511,0xF130007E8A00001E,"Boss, the Big",0xa48,47809,"Shadow Bolt",0x20,3000,0,32,0,0,0,nil,nil,nil
+10/14 20:00:03.000  SPELL_AURA_APPLIED,0x0600000000000001,"Lock",0x511,0x0600000000000001,"Lock",0x511,17941,"Shadow Trance",0x20,BUFF
+10/14 20:00:04.000  SPELL_CAST_SUCCESS,0x0600000000000001,"Lock",0x511,0xF130007E8A00001E,"Boss, the Big",0xa48,47809,"Shadow Bolt",0x20
+10/14 20:00:04.000  SPELL_AURA_REMOVED,0x0600000000000001,"Lock",0x511,0x0600000000000001,"Lock",0x511,17941,"Shadow Trance",0x20,BUFF
+10/14 20:00:04.500  SPELL_DAMAGE,0x0600000000000001,"Lock",0x511,0xF130007E8A00001E,"Boss, the Big",0xa48,47809,"Shadow Bolt",0x20,6000,0,32,0,0,0,1,nil,nil
+10/14 20:00:05.000  SPELL_DAMAGE,0x0600000000000001,"Lock",0x511,0xF130007E8A00001E,"Boss, the Big",0xa48,60488,"Shadow Bolt",0x20,1000,0,32,0,0,0,nil,nil,nil
+10/14 20:00:06.000  SPELL_MISSED,0x0600000000000001,"Lock",0x511,0xF130007E8A00001E,"Boss, the Big",0xa48,60488,"Shadow Bolt",0x20,RESIST
+10/14 20:00:06.000  SWING_DAMAGE,0xF140000000000002,"Imp",0x1111,0xF130007E8A00001E,"Boss, the Big",0xa48,500,0,1,0,0,0,nil,1,nil
+10/14 20:00:07.000  SWING_MISSED,0xF140000000000002,"Imp",0x1111,0xF130007E8A00001E,"Boss, the Big",0xa48,DODGE
+10/14 20:00:08.000  SPELL_DAMAGE,0x0600000000000003,"Other",0x512,0xF130007E8A00001E,"Boss, the Big",0xa48,47809,"Shadow Bolt",0x20,9999,0,32,0,0,0,nil,nil,nil
+10/14 20:00:10.000  SPELL_PERIODIC_DAMAGE,0x0600000000000001,"Lock",0x511,0xF130007E8A00001E,"Boss, the Big",0xa48,47813,"Corruption",0x20,500,0,32,0,0,0,nil,nil,nil
+`
+
+func TestParseCombatLog(t *testing.T) {
+	combatLog, err := ParseCombatLog(strings.NewReader(testCombatLog))
+	if err != nil {
+		t.Fatalf("Failed to parse combat log: %s", err)
+	}
+	if names := combatLog.PlayerNames(); len(names) != 2 || names[0] != "Lock" || names[1] != "Other" {
+		t.Fatalf("Unexpected player names: %v", names)
+	}
+
+	player, err := combatLog.Player("lock")
+	if err != nil {
+		t.Fatalf("Failed to get player: %s", err)
+	}
+	if player.Duration != 10*time.Second {
+		t.Fatalf("Expected a duration of 10s, got %s", player.Duration)
+	}
+	// 10500 damage from the player and 500 from the pet.
+	if dps := player.Metrics.Dps.Avg; math.Abs(dps-1100) > 1e-9 {
+		t.Fatalf("Expected 1100 DPS, got %f", dps)
+	}
+
+	actions := make(map[ActionID]*proto.TargetedActionMetrics)
+	for _, action := range player.Metrics.Actions {
+		if len(action.Targets) != 1 {
+			t.Fatalf("Expected a single target, got %d", len(action.Targets))
+		}
+		actions[ProtoToActionID(action.Id)] = action.Targets[0]
+	}
+
+	shadowBolt := actions[ActionID{SpellID: 47809}]
+	if shadowBolt.Casts != 2 || shadowBolt.Hits != 1 || shadowBolt.Crits != 1 || shadowBolt.Damage != 9000 {
+		t.Fatalf("Unexpected Shadow Bolt metrics: %v", shadowBolt)
+	}
+	// No cast events, so casts are counted from damage events.
+	proc := actions[ActionID{SpellID: 60488}]
+	if proc.Casts != 2 || proc.Hits != 1 || proc.Misses != 1 {
+		t.Fatalf("Unexpected proc metrics: %v", proc)
+	}
+	// Periodic damage doesn't count as a cast.
+	if corruption := actions[ActionID{SpellID: 47813}]; corruption.Casts != 0 || corruption.Hits != 1 {
+		t.Fatalf("Unexpected Corruption metrics: %v", corruption)
+	}
+
+	auras := make(map[ActionID]*proto.AuraMetrics)
+	for _, aura := range player.Metrics.Auras {
+		auras[ProtoToActionID(aura.Id)] = aura
+	}
+	if trance := auras[ActionID{SpellID: 17941}]; trance.UptimeSecondsAvg != 1 || trance.ProcsAvg != 1 {
+		t.Fatalf("Unexpected Shadow Trance metrics: %v", trance)
+	}
+
+	if len(player.Metrics.Pets) != 1 {
+		t.Fatalf("Expected 1 pet, got %d", len(player.Metrics.Pets))
+	}
+	pet := player.Metrics.Pets[0]
+	melee := pet.Actions[0].Targets[0]
+	if pet.Name != "Imp" || melee.Casts != 2 || melee.Hits != 1 || melee.Glances != 1 || melee.Dodges != 1 {
+		t.Fatalf("Unexpected pet melee metrics: %v", melee)
+	}
+
+	if _, err := combatLog.Player("Nobody"); err == nil {
+		t.Fatalf("Expected an error for an unknown player")
+	}
+}
+
+func TestCompareCombatLog(t *testing.T) {
+	combatLog, err := ParseCombatLog(strings.NewReader(testCombatLog))
+	if err != nil {
+		t.Fatalf("Failed to parse combat log: %s", err)
+	}
+	player, err := combatLog.Player("Lock")
+	if err != nil {
+		t.Fatalf("Failed to get player: %s", err)
+	}
+
+	// 2 iterations of 20s each.
+	simMetrics := &proto.UnitMetrics{
+		Dps: &proto.DistributionMetrics{Avg: 1000},
+		Actions: []*proto.ActionMetrics{
+			{
+				Id:      ActionID{SpellID: 47809}.ToProto(),
+				Targets: []*proto.TargetedActionMetrics{{Casts: 6, Damage: 30000}},
+			},
+		},
+		Auras: []*proto.AuraMetrics{
+			{Id: ActionID{SpellID: 17941}.ToProto(), UptimeSecondsAvg: 4},
+		},
+	}
+	comparison := CompareCombatLog(player, simMetrics, 2, 20*time.Second)
+
+	if comparison.LogDPS != 1100 || comparison.SimDPS != 1000 {
+		t.Fatalf("Unexpected DPS: %f and %f", comparison.LogDPS, comparison.SimDPS)
+	}
+	shadowBolt := comparison.Actions[0]
+	if shadowBolt.ActionID != (ActionID{SpellID: 47809}) || shadowBolt.LogCasts != 2 || shadowBolt.SimCasts != 3 ||
+		shadowBolt.LogDPS != 900 || shadowBolt.SimDPS != 750 {
+		t.Fatalf("Unexpected Shadow Bolt comparison: %+v", shadowBolt)
+	}
+
+	for _, aura := range comparison.Auras {
+		if aura.ActionID == (ActionID{SpellID: 17941}) && (aura.LogUptime != 0.1 || aura.SimUptime != 0.2) {
+			t.Fatalf("Unexpected Shadow Trance comparison: %+v", aura)
+		}
+	}
+}
@@ -40,6 +40,10 @@ func (unit *Unit) EnableEnergyBar(maxEnergy float64, onEnergyGain OnEnergyGain)
 		unit:      unit,
 		maxEnergy: MaxFloat(100, maxEnergy),
 		onEnergyGain: func(sim *Simulation) {
+			if unit.IsUsingAPL() {
+				unit.Rotation.recheckIfIdling(sim)
+				return
+			}
 			if !sim.Options.Interactive && (!unit.IsWaitingForEnergy() || unit.DoneWaitingForEnergy(sim)) {
 				onEnergyGain(sim)
 			}
@@ -69,6 +73,10 @@ func (eb *energyBar) addEnergyInternal(sim *Simulation, amount float64, metrics
 
 	newEnergy := MinFloat(eb.currentEnergy+amount, eb.maxEnergy)
 	metrics.AddEvent(amount, newEnergy-eb.currentEnergy)
+	if sim.recordingEvents {
+		sim.recordResourceEvent(eb.unit, metrics, amount, newEnergy-eb.currentEnergy, newEnergy)
+	}
+	eb.unit.Metrics.timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeEnergy, newEnergy)
 
 	if sim.Log != nil {
 		eb.unit.Log(sim, "Gained %0.3f energy from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, eb.currentEnergy, newEnergy)
@@ -88,6 +96,10 @@ func (eb *energyBar) SpendEnergy(sim *Simulation, amount float64, metrics *Resou
 
 	newEnergy := eb.currentEnergy - amount
 	metrics.AddEvent(-amount, -amount)
+	if sim.recordingEvents {
+		sim.recordResourceEvent(eb.unit, metrics, -amount, -amount, newEnergy)
+	}
+	eb.unit.Metrics.timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeEnergy, newEnergy)
 
 	if sim.Log != nil {
 		eb.unit.Log(sim, "Spent %0.3f energy from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, eb.currentEnergy, newEnergy)
@@ -114,6 +126,9 @@ func (eb *energyBar) ResetEnergyTick(sim *Simulation) {
 func (eb *energyBar) AddComboPoints(sim *Simulation, pointsToAdd int32, metrics *ResourceMetrics) {
 	newComboPoints := MinInt32(eb.comboPoints+pointsToAdd, 5)
 	metrics.AddEvent(float64(pointsToAdd), float64(newComboPoints-eb.comboPoints))
+	if sim.recordingEvents {
+		sim.recordResourceEvent(eb.unit, metrics, float64(pointsToAdd), float64(newComboPoints-eb.comboPoints), float64(newComboPoints))
+	}
 
 	if sim.Log != nil {
 		eb.unit.Log(sim, "Gained %d combo points from %s (%d --> %d)", pointsToAdd, metrics.ActionID, eb.comboPoints, newComboPoints)
@@ -127,6 +142,9 @@ func (eb *energyBar) SpendComboPoints(sim *Simulation, metrics *ResourceMetrics)
 		eb.unit.Log(sim, "Spent %d combo points from %s (%d --> %d).", eb.comboPoints, metrics.ActionID, eb.comboPoints, 0)
 	}
 	metrics.AddEvent(float64(-eb.comboPoints), float64(-eb.comboPoints))
+	if sim.recordingEvents {
+		sim.recordResourceEvent(eb.unit, metrics, float64(-eb.comboPoints), float64(-eb.comboPoints), 0)
+	}
 	eb.comboPoints = 0
 }
 
@@ -66,6 +66,14 @@ func (env *Environment) construct(raidProto *proto.Raid, encounterProto *proto.E
 
 	env.Raid.updatePlayersAndPets()
 
+	for _, windowConfig := range encounterProto.UntargetableWindows {
+		env.Encounter.untargetableWindows = append(env.Encounter.untargetableWindows, newUntargetableWindow(windowConfig, &env.Encounter))
+	}
+
+	for _, windowConfig := range encounterProto.MovementWindows {
+		env.Encounter.movementWindows = append(env.Encounter.movementWindows, newMovementWindow(windowConfig, env.Raid))
+	}
+
 	env.AllUnits = append(env.Encounter.TargetUnits, env.Raid.AllUnits...)
 
 	for unitIndex, unit := range env.AllUnits {
@@ -84,6 +92,10 @@ func (env *Environment) construct(raidProto *proto.Raid, encounterProto *proto.E
 		}
 	}
 
+	for _, waveConfig := range encounterProto.AddWaves {
+		env.Encounter.addWaves = append(env.Encounter.addWaves, newAddWave(waveConfig, &env.Encounter))
+	}
+
 	// Assign target or target using Tanks field.
 	for _, target := range env.Encounter.Targets {
 		if target.Index < int32(len(encounterProto.Targets)) {
@@ -105,6 +117,9 @@ func (env *Environment) construct(raidProto *proto.Raid, encounterProto *proto.E
 
 // The initialization phase.
 func (env *Environment) initialize(raidProto *proto.Raid, encounterProto *proto.Encounter) *proto.RaidStats {
+	// Registered before the targets, whose scripts may deal it.
+	env.Encounter.registerRaidDamage(encounterProto.RaidDamage)
+
 	for _, target := range env.Encounter.Targets {
 		if target.Index < int32(len(encounterProto.Targets)) {
 			target.initialize(encounterProto.Targets[target.Index])
@@ -112,7 +127,6 @@ func (env *Environment) initialize(raidProto *proto.Raid, encounterProto *proto.
 			target.initialize(nil)
 		}
 	}
-
 	for _, party := range env.Raid.Parties {
 		for _, playerOrPet := range party.PlayersAndPets {
 			playerOrPet.GetCharacter().initialize(playerOrPet)
@@ -202,14 +216,28 @@ func (env *Environment) IsFinalized() bool {
 func (env *Environment) reset(sim *Simulation) {
 	// Reset primary targets damage taken for tracking health fights.
 	env.Encounter.DamageTaken = 0
+	env.Encounter.restoreRetargetedUnits()
 
 	// Targets need to be reset before the raid, so that players can check for
 	// the presence of permanent target auras in their Reset handlers.
 	for _, target := range env.Encounter.Targets {
 		target.Reset(sim)
 	}
+	env.Encounter.updateActiveTargets()
+	env.Encounter.resetAddWaves(sim)
+
+	// AIs are reset once every target and add wave is, since their scripts act on them.
+	for _, target := range env.Encounter.Targets {
+		if target.AI != nil {
+			target.AI.Reset(sim)
+		}
+	}
 
 	env.Raid.reset(sim)
+
+	env.Encounter.scheduleMovementWindows(sim)
+	env.Encounter.scheduleUntargetableWindows(sim)
+	env.Encounter.scheduleRaidDamage(sim)
 }
 
 // The maximum possible duration for any iteration.
@@ -201,6 +201,7 @@ const (
 	SpellFlagAPL                                            // Indicates this spell can be used from an APL rotation.
 	SpellFlagMCD                                            // Indicates this spell is a MajorCooldown.
 	SpellFlagNoOnDamageDealt                                // Disables OnSpellHitDealt and OnPeriodicDamageDealt aura callbacks for this spell.
+	SpellFlagCastWhileMoving                                // Spell with a cast time which can still be cast while moving.
 
 	// Used to let agents categorize their spells.
 	SpellFlagAgentReserved1
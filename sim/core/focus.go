@@ -51,6 +51,9 @@ func (fb *focusBar) AddFocus(sim *Simulation, amount float64, actionID ActionID)
 	if sim.Log != nil {
 		fb.unit.Log(sim, "Gained %0.3f focus from %s (%0.3f --> %0.3f).", amount, actionID, fb.currentFocus, newFocus)
 	}
+	if sim.recordingEvents {
+		sim.recordResourceEvent(fb.unit, &ResourceMetrics{ActionID: actionID, Type: proto.ResourceType_ResourceTypeFocus}, amount, newFocus-fb.currentFocus, newFocus)
+	}
 
 	fb.currentFocus = newFocus
 
@@ -66,6 +69,9 @@ func (fb *focusBar) SpendFocus(sim *Simulation, amount float64, metrics *Resourc
 
 	newFocus := fb.currentFocus - amount
 	metrics.AddEvent(-amount, -amount)
+	if sim.recordingEvents {
+		sim.recordResourceEvent(fb.unit, metrics, -amount, -amount, newFocus)
+	}
 
 	if sim.Log != nil {
 		fb.unit.Log(sim, "Spent %0.3f focus from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, fb.currentFocus, newFocus)
@@ -120,6 +120,7 @@ func (unit *Unit) HardcastWaitUntil(sim *Simulation, readyTime time.Duration, on
 
 	unit.Hardcast.Expires = readyTime
 	unit.Hardcast.OnComplete = onComplete
+	unit.Hardcast.spell = nil
 	unit.newHardcastAction(sim)
 }
 
@@ -45,6 +45,16 @@ func (hb *healthBar) CurrentHealthPercent() float64 {
 	return hb.currentHealth / hb.unit.stats[stats.Health]
 }
 
+// Returns the unit's health as a value from 0-1. Most targets don't track
+// health, so estimate it from the fight progress the same way the execute
+// phases do.
+func (unit *Unit) estimatedHealthPercent(sim *Simulation) float64 {
+	if unit.HasHealthBar() {
+		return unit.CurrentHealthPercent()
+	}
+	return sim.GetRemainingDurationPercent()
+}
+
 func (hb *healthBar) GainHealth(sim *Simulation, amount float64, metrics *ResourceMetrics) {
 	if amount < 0 {
 		panic("Trying to gain negative health!")
@@ -53,6 +63,10 @@ func (hb *healthBar) GainHealth(sim *Simulation, amount float64, metrics *Resour
 	oldHealth := hb.currentHealth
 	newHealth := MinFloat(oldHealth+amount, hb.unit.MaxHealth())
 	metrics.AddEvent(amount, newHealth-oldHealth)
+	if sim.recordingEvents {
+		sim.recordResourceEvent(hb.unit, metrics, amount, newHealth-oldHealth, newHealth)
+	}
+	hb.unit.Metrics.timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeHealth, newHealth)
 
 	if sim.Log != nil {
 		hb.unit.Log(sim, "Gained %0.3f health from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, oldHealth, newHealth)
@@ -70,6 +84,10 @@ func (hb *healthBar) RemoveHealth(sim *Simulation, amount float64) {
 	newHealth := MaxFloat(oldHealth-amount, 0)
 	metrics := hb.DamageTakenHealthMetrics
 	metrics.AddEvent(-amount, newHealth-oldHealth)
+	if sim.recordingEvents {
+		sim.recordResourceEvent(hb.unit, metrics, -amount, newHealth-oldHealth, newHealth)
+	}
+	hb.unit.Metrics.timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeHealth, newHealth)
 
 	// TMI calculations need timestamps and Max HP information for each damage taken event
 	if hb.unit.Metrics.isTanking {
@@ -96,15 +114,22 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 			character.Unit.Metrics.isTanking = true
 		}
 	}
-	if !character.Unit.Metrics.isTanking {
+	// Without raid damage only tanks take damage, and their health is only
+	// tracked when there is a healing model to keep them alive. With raid
+	// damage everyone tracks health, and the healing model is an optional
+	// override for the healing done by the raid's healers.
+	damagesRaid := character.Env.Encounter.damagesRaid
+	if !character.Unit.Metrics.isTanking && !damagesRaid {
 		return
 	}
 
-	if healingModel == nil {
+	if healingModel == nil && !damagesRaid {
 		return
 	}
 
-	character.Unit.Metrics.tmiBin = healingModel.BurstWindow
+	if healingModel != nil {
+		character.Unit.Metrics.tmiBin = healingModel.BurstWindow
+	}
 
 	character.RegisterAura(Aura{
 		Label:    ChanceOfDeathAuraLabel,
@@ -138,7 +163,7 @@ func (character *Character) trackChanceOfDeath(healingModel *proto.HealingModel)
 		},
 	})
 
-	if healingModel.Hps != 0 {
+	if healingModel != nil && healingModel.Hps != 0 {
 		character.applyHealingModel(healingModel)
 	}
 }
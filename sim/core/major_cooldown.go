@@ -388,6 +388,16 @@ func (mcdm *majorCooldownManager) AddMajorCooldown(mcd MajorCooldown) {
 	mcdm.initialMajorCooldowns = append(mcdm.initialMajorCooldowns, mcd)
 }
 
+// Stops the MCD for the given spell, if there is one, from being used by
+// TryUseCooldowns. Used when a rotation casts the spell explicitly.
+func (mcdm *majorCooldownManager) disableAutoUse(spell *Spell) {
+	for i := range mcdm.initialMajorCooldowns {
+		if mcdm.initialMajorCooldowns[i].Spell == spell {
+			mcdm.initialMajorCooldowns[i].disabled = true
+		}
+	}
+}
+
 func (mcdm *majorCooldownManager) GetInitialMajorCooldown(actionID ActionID) MajorCooldown {
 	for _, mcd := range mcdm.initialMajorCooldowns {
 		if mcd.Spell.SameAction(actionID) {
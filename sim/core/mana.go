@@ -96,6 +96,10 @@ func (unit *Unit) AddMana(sim *Simulation, amount float64, metrics *ResourceMetr
 	oldMana := unit.CurrentMana()
 	newMana := MinFloat(oldMana+amount, unit.MaxMana())
 	metrics.AddEvent(amount, newMana-oldMana)
+	if sim.recordingEvents {
+		sim.recordResourceEvent(unit, metrics, amount, newMana-oldMana, newMana)
+	}
+	unit.Metrics.timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeMana, newMana)
 
 	if sim.Log != nil {
 		unit.Log(sim, "Gained %0.3f mana from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, oldMana, newMana)
@@ -112,6 +116,10 @@ func (unit *Unit) SpendMana(sim *Simulation, amount float64, metrics *ResourceMe
 
 	newMana := unit.CurrentMana() - amount
 	metrics.AddEvent(-amount, -amount)
+	if sim.recordingEvents {
+		sim.recordResourceEvent(unit, metrics, -amount, -amount, newMana)
+	}
+	unit.Metrics.timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeMana, newMana)
 
 	if sim.Log != nil {
 		unit.Log(sim, "Spent %0.3f mana from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, unit.CurrentMana(), newMana)
@@ -262,7 +270,9 @@ func (sim *Simulation) initManaTickAction() {
 		for _, player := range playersWithManaBars {
 			char := player.GetCharacter()
 			char.ManaTick(sim)
-			if char.OnManaTick != nil {
+			if char.IsUsingAPL() {
+				char.Rotation.recheckIfIdling(sim)
+			} else if char.OnManaTick != nil {
 				char.OnManaTick(sim)
 			}
 		}
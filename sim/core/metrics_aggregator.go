@@ -25,6 +25,7 @@ type DistributionMetrics struct {
 	maxSeed int64
 	minSeed int64
 	hist    map[int32]int32 // rounded DPS to count
+	sketch  quantileSketch
 	sample  []float64
 }
 
@@ -41,6 +42,7 @@ func (distMetrics *DistributionMetrics) doneIteration(sim *Simulation) {
 	dps := distMetrics.Total / encounterDurationSeconds
 	distMetrics.sum += dps
 	distMetrics.sumSq += dps * dps
+	distMetrics.sketch.add(dps)
 	if sim.Options.SaveAllValues {
 		distMetrics.sample = append(distMetrics.sample, dps)
 	}
@@ -60,6 +62,12 @@ func (distMetrics *DistributionMetrics) doneIteration(sim *Simulation) {
 
 func (distMetrics *DistributionMetrics) ToProto() *proto.DistributionMetrics {
 	mean, stdev := calcMeanAndStdevFromSums(distMetrics.n, distMetrics.sum, distMetrics.sumSq)
+	stderr := distMetrics.standardError()
+
+	// Sketch estimates can fall slightly outside of the actual range.
+	percentile := func(q float64) float64 {
+		return math.Max(distMetrics.min, math.Min(distMetrics.max, distMetrics.sketch.quantile(q)))
+	}
 
 	return &proto.DistributionMetrics{
 		Avg:       mean,
@@ -70,13 +78,61 @@ func (distMetrics *DistributionMetrics) ToProto() *proto.DistributionMetrics {
 		MinSeed:   distMetrics.minSeed,
 		Hist:      distMetrics.hist,
 		AllValues: distMetrics.sample,
+
+		Stderr:   stderr,
+		Ci95Low:  mean - 1.96*stderr,
+		Ci95High: mean + 1.96*stderr,
+
+		P5:  percentile(0.05),
+		P25: percentile(0.25),
+		P50: percentile(0.50),
+		P75: percentile(0.75),
+		P95: percentile(0.95),
 	}
 }
 
+// Adds the aggregate values of other, which must have run later iterations.
+func (distMetrics *DistributionMetrics) merge(other *DistributionMetrics) {
+	distMetrics.n += other.n
+	distMetrics.sum += other.sum
+	distMetrics.sumSq += other.sumSq
+	distMetrics.sample = append(distMetrics.sample, other.sample...)
+
+	if other.n > 0 && other.max > distMetrics.max {
+		distMetrics.max = other.max
+		distMetrics.maxSeed = other.maxSeed
+	}
+	if other.n > 0 && (other.min <= distMetrics.min || distMetrics.min < 0) {
+		distMetrics.min = other.min
+		distMetrics.minSeed = other.minSeed
+	}
+
+	for dps, count := range other.hist {
+		distMetrics.hist[dps] += count
+	}
+	distMetrics.sketch.merge(&other.sketch)
+}
+
+// Standard error of the mean, over the iterations so far. Uses the sample
+// stdev, so it needs at least 2 iterations.
+func (distMetrics *DistributionMetrics) standardError() float64 {
+	if distMetrics.n < 2 {
+		return 0
+	}
+	n := float64(distMetrics.n)
+	variance := (distMetrics.sumSq - distMetrics.sum*distMetrics.sum/n) / (n - 1)
+	return math.Sqrt(math.Max(variance, 0) / n)
+}
+
+func (distMetrics *DistributionMetrics) mean() float64 {
+	return distMetrics.sum / float64(distMetrics.n)
+}
+
 func NewDistributionMetrics() DistributionMetrics {
 	return DistributionMetrics{
-		hist: make(map[int32]int32),
-		min:  -1,
+		hist:   make(map[int32]int32),
+		sketch: newQuantileSketch(),
+		min:    -1,
 	}
 }
 
@@ -96,10 +152,16 @@ type UnitMetrics struct {
 	CharacterIterationMetrics
 
 	// Aggregate values. These are updated after each iteration.
-	numItersDead int32
-	oomTimeSum   float64
-	actions      map[ActionID]*ActionMetrics
-	resources    []*ResourceMetrics
+	numItersDead  int32
+	oomTimeSum    float64
+	movingTimeSum float64
+	activeTimeSum float64
+	damageLostSum float64
+	actions       map[ActionID]*ActionMetrics
+	resources     []*ResourceMetrics
+
+	// Only tracked when timelines are enabled.
+	timelines unitTimelines
 }
 
 // Metrics for the current iteration, for 1 agent. Keep this as a separate
@@ -113,6 +175,12 @@ type CharacterIterationMetrics struct {
 
 	OOMTime time.Duration // time spent not casting and waiting for regen.
 
+	MovingTime time.Duration // time spent moving, see Unit.MoveUntil.
+
+	ActiveTime time.Duration // for targets, time spent spawned.
+
+	DamageLost float64 // damage dealt to or taken while untargetable.
+
 	FirstOOMTimestamp time.Duration // Timestamp at which unit first went OOM.
 }
 
@@ -181,6 +249,22 @@ type TargetedActionMetrics struct {
 	CastTime  time.Duration
 }
 
+func (tam *TargetedActionMetrics) merge(other *TargetedActionMetrics) {
+	tam.Casts += other.Casts
+	tam.Hits += other.Hits
+	tam.Crits += other.Crits
+	tam.Misses += other.Misses
+	tam.Dodges += other.Dodges
+	tam.Parries += other.Parries
+	tam.Blocks += other.Blocks
+	tam.Glances += other.Glances
+	tam.Damage += other.Damage
+	tam.Threat += other.Threat
+	tam.Healing += other.Healing
+	tam.Shielding += other.Shielding
+	tam.CastTime += other.CastTime
+}
+
 func (tam *TargetedActionMetrics) ToProto() *proto.TargetedActionMetrics {
 	return &proto.TargetedActionMetrics{
 		UnitIndex: tam.UnitIndex,
@@ -419,13 +503,70 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 	unitMetrics.tmi.doneIteration(sim)
 	unitMetrics.hps.doneIteration(sim)
 	unitMetrics.tto.doneIteration(sim)
+	unitMetrics.timelines.doneIteration(sim)
 
 	unitMetrics.oomTimeSum += unitMetrics.OOMTime.Seconds()
+	if unit.moving {
+		unitMetrics.MovingTime += sim.CurrentTime - unit.movementStart
+	}
+	unitMetrics.movingTimeSum += unitMetrics.MovingTime.Seconds()
+	unitMetrics.activeTimeSum += unitMetrics.ActiveTime.Seconds()
+	unitMetrics.damageLostSum += unitMetrics.DamageLost
 	if unitMetrics.Died {
 		unitMetrics.numItersDead++
 	}
 }
 
+// Adds the aggregate values of other, which must be the metrics of the same
+// unit in another Simulation that ran later iterations.
+func (unitMetrics *UnitMetrics) merge(other *UnitMetrics) {
+	unitMetrics.dps.merge(&other.dps)
+	unitMetrics.dpasp.merge(&other.dpasp)
+	unitMetrics.threat.merge(&other.threat)
+	unitMetrics.dtps.merge(&other.dtps)
+	unitMetrics.tmi.merge(&other.tmi)
+	unitMetrics.hps.merge(&other.hps)
+	unitMetrics.tto.merge(&other.tto)
+	unitMetrics.timelines.merge(&other.timelines)
+
+	unitMetrics.numItersDead += other.numItersDead
+	unitMetrics.oomTimeSum += other.oomTimeSum
+	unitMetrics.movingTimeSum += other.movingTimeSum
+	unitMetrics.activeTimeSum += other.activeTimeSum
+	unitMetrics.damageLostSum += other.damageLostSum
+
+	for actionID, otherAction := range other.actions {
+		action, ok := unitMetrics.actions[actionID]
+		if !ok || len(action.Targets) == 0 {
+			unitMetrics.actions[actionID] = &ActionMetrics{
+				IsMelee: otherAction.IsMelee,
+				Targets: append([]TargetedActionMetrics{}, otherAction.Targets...),
+			}
+			continue
+		}
+		for i := range otherAction.Targets {
+			action.Targets[i].merge(&otherAction.Targets[i])
+		}
+	}
+
+	// Resource metrics may be created during the sim, so match them by key.
+	for _, otherResource := range other.resources {
+		var resource *ResourceMetrics
+		for _, r := range unitMetrics.resources {
+			if r.ActionID == otherResource.ActionID && r.Type == otherResource.Type {
+				resource = r
+				break
+			}
+		}
+		if resource == nil {
+			resource = unitMetrics.NewResourceMetrics(otherResource.ActionID, otherResource.Type)
+		}
+		resource.Events += otherResource.Events
+		resource.Gain += otherResource.Gain
+		resource.ActualGain += otherResource.ActualGain
+	}
+}
+
 func (unitMetrics *UnitMetrics) calculateTMI(unit *Unit, sim *Simulation) float64 {
 
 	if unit.Metrics.tmiList == nil || unitMetrics.tmiBin == 0 {
@@ -502,15 +643,18 @@ func (unitMetrics *UnitMetrics) calculateTMI(unit *Unit, sim *Simulation) float6
 func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 	n := float64(unitMetrics.dps.n)
 	protoMetrics := &proto.UnitMetrics{
-		Dps:           unitMetrics.dps.ToProto(),
-		Dpasp:         unitMetrics.dpasp.ToProto(),
-		Threat:        unitMetrics.threat.ToProto(),
-		Dtps:          unitMetrics.dtps.ToProto(),
-		Tmi:           unitMetrics.tmi.ToProto(),
-		Hps:           unitMetrics.hps.ToProto(),
-		Tto:           unitMetrics.tto.ToProto(),
-		SecondsOomAvg: unitMetrics.oomTimeSum / n,
-		ChanceOfDeath: float64(unitMetrics.numItersDead) / n,
+		Dps:              unitMetrics.dps.ToProto(),
+		Dpasp:            unitMetrics.dpasp.ToProto(),
+		Threat:           unitMetrics.threat.ToProto(),
+		Dtps:             unitMetrics.dtps.ToProto(),
+		Tmi:              unitMetrics.tmi.ToProto(),
+		Hps:              unitMetrics.hps.ToProto(),
+		Tto:              unitMetrics.tto.ToProto(),
+		SecondsOomAvg:    unitMetrics.oomTimeSum / n,
+		SecondsMovingAvg: unitMetrics.movingTimeSum / n,
+		SecondsActiveAvg: unitMetrics.activeTimeSum / n,
+		DamageLostAvg:    unitMetrics.damageLostSum / n,
+		ChanceOfDeath:    float64(unitMetrics.numItersDead) / n,
 	}
 
 	for actionID, action := range unitMetrics.actions {
@@ -521,6 +665,7 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 			protoMetrics.Resources = append(protoMetrics.Resources, resource.ToProto())
 		}
 	}
+	unitMetrics.timelines.applyToProto(protoMetrics)
 
 	return protoMetrics
 }
@@ -537,6 +682,9 @@ type AuraMetrics struct {
 	uptimeSum   float64
 	uptimeSumSq float64
 	procsSum    int32
+
+	// Seconds active per bucket, only tracked when timelines are enabled.
+	timeline timeline
 }
 
 func (auraMetrics *AuraMetrics) reset() {
@@ -552,6 +700,14 @@ func (auraMetrics *AuraMetrics) doneIteration() {
 	auraMetrics.procsSum += auraMetrics.Procs
 }
 
+func (auraMetrics *AuraMetrics) merge(other *AuraMetrics) {
+	auraMetrics.n += other.n
+	auraMetrics.uptimeSum += other.uptimeSum
+	auraMetrics.uptimeSumSq += other.uptimeSumSq
+	auraMetrics.procsSum += other.procsSum
+	auraMetrics.timeline.merge(other.timeline)
+}
+
 func (auraMetrics *AuraMetrics) ToProto() *proto.AuraMetrics {
 	mean, stdev := calcMeanAndStdevFromSums(auraMetrics.n, auraMetrics.uptimeSum, auraMetrics.uptimeSumSq)
 
@@ -0,0 +1,23 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestDistributionStandardError(t *testing.T) {
+	distMetrics := NewDistributionMetrics()
+	if stderr := distMetrics.standardError(); stderr != 0 {
+		t.Fatalf("Expected no error without iterations, got %f", stderr)
+	}
+
+	distMetrics.n, distMetrics.sum, distMetrics.sumSq = 1, 100, 100*100
+	if stderr := distMetrics.standardError(); stderr != 0 {
+		t.Fatalf("Expected no error after one iteration, got %f", stderr)
+	}
+
+	// Values 100, 102, 104, 106: sample stdev of sqrt(20/3), over sqrt(4) iterations.
+	distMetrics.n, distMetrics.sum, distMetrics.sumSq = 4, 412, 100*100+102*102+104*104+106*106
+	if stderr := distMetrics.standardError(); !WithinToleranceFloat64(1.290994, stderr, 0.000001) {
+		t.Fatalf("Unexpected standard error %f", stderr)
+	}
+}
@@ -0,0 +1,143 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"golang.org/x/exp/slices"
+)
+
+// An encounter window during which some players have to move.
+type movementWindow struct {
+	start    time.Duration
+	duration time.Duration
+	interval time.Duration
+
+	units []*Unit
+}
+
+func newMovementWindow(config *proto.MovementWindow, raid *Raid) movementWindow {
+	window := movementWindow{
+		start:    DurationFromSeconds(config.Start),
+		duration: DurationFromSeconds(config.Duration),
+		interval: DurationFromSeconds(config.Interval),
+	}
+	for _, unit := range raid.AllUnits {
+		if unit.Type == PlayerUnit && (len(config.RaidIndices) == 0 || slices.Contains(config.RaidIndices, unit.Index)) {
+			window.units = append(window.units, unit)
+		}
+	}
+	return window
+}
+
+func (encounter *Encounter) scheduleMovementWindows(sim *Simulation) {
+	for _, window := range encounter.movementWindows {
+		window := window
+		if window.duration <= 0 || len(window.units) == 0 {
+			continue
+		}
+
+		pa := &PendingAction{
+			NextActionAt: window.start,
+		}
+		pa.OnAction = func(sim *Simulation) {
+			for _, unit := range window.units {
+				unit.MoveUntil(sim, sim.CurrentTime+window.duration)
+			}
+			if window.interval > 0 {
+				pa.NextActionAt = sim.CurrentTime + window.interval
+				sim.AddPendingAction(pa)
+			}
+		}
+		sim.AddPendingAction(pa)
+	}
+}
+
+func (unit *Unit) IsMoving() bool {
+	return unit.moving
+}
+
+// Makes the unit move until the given time, extending any movement already in
+// progress. Casts and channels in progress are interrupted, spells with a cast
+// time can't be started unless flagged SpellFlagCastWhileMoving, and auto
+// attacks pause until the movement ends.
+func (unit *Unit) MoveUntil(sim *Simulation, until time.Duration) {
+	if until <= sim.CurrentTime {
+		return
+	}
+
+	if unit.moving {
+		if until <= unit.movementAction.NextActionAt {
+			return
+		}
+		unit.movementAction.Cancel(sim)
+	} else {
+		unit.moving = true
+		unit.movementStart = sim.CurrentTime
+		if sim.Log != nil {
+			unit.Log(sim, "Moving until %s", until)
+		}
+		if unit.Hardcast.Expires > sim.CurrentTime {
+			unit.interruptHardcast(sim)
+		}
+	}
+
+	unit.AutoAttacks.DelayMeleeUntil(sim, until)
+	unit.AutoAttacks.DelayRangedUntil(sim, until)
+
+	unit.movementAction = StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     until,
+		OnAction: unit.stopMoving,
+	})
+}
+
+func (unit *Unit) stopMoving(sim *Simulation) {
+	unit.moving = false
+	unit.Metrics.MovingTime += sim.CurrentTime - unit.movementStart
+	if sim.Log != nil {
+		unit.Log(sim, "Stopped moving")
+	}
+
+	if unit.Rotation != nil {
+		unit.Rotation.recheckIfIdling(sim)
+	}
+}
+
+// Cancels the spell being cast or channeled because the unit started moving.
+func (unit *Unit) interruptHardcast(sim *Simulation) {
+	spell := unit.Hardcast.spell
+	if spell == nil || spell.Flags.Matches(SpellFlagCastWhileMoving) {
+		return
+	}
+
+	if spell.DefaultCast.ChannelTime > 0 {
+		dot := spell.AOEDot()
+		if dot == nil {
+			dot = spell.CurDot()
+		}
+		if dot != nil && dot.IsActive() {
+			dot.Cancel(sim)
+		}
+	}
+
+	if sim.Log != nil {
+		unit.Log(sim, "Movement interrupted cast of %s", spell.ActionID)
+	}
+	// The GCD triggered by the interrupted cast still runs its course.
+	gcdReadyAt := sim.CurrentTime
+	if cast := spell.CurCast; cast.GCD != 0 {
+		castStart := unit.Hardcast.Expires - cast.CastTime - cast.ChannelTime
+		gcdReadyAt = MaxDuration(gcdReadyAt, castStart+MaxDuration(GCDMin, cast.GCD))
+	}
+
+	unit.Hardcast = Hardcast{Expires: sim.CurrentTime}
+	unit.SetGCDTimer(sim, gcdReadyAt)
+}
+
+// Whether the unit is moving and the given cast of this spell can't be done
+// while moving.
+func (spell *Spell) castBlockedByMovement(cast *Cast) bool {
+	return spell.Unit.moving &&
+		(cast.CastTime > 0 || cast.ChannelTime > 0) &&
+		!spell.Flags.Matches(SpellFlagCastWhileMoving)
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func TestMovementWindows(t *testing.T) {
+	request := fakeCasterRequest(&proto.SimOptions{Iterations: 5, RandomSeed: 101})
+	baseline := RunRaidSim(request)
+	if baseline.ErrorResult != "" {
+		t.Fatalf("Sim failed: %s", baseline.ErrorResult)
+	}
+
+	request.Encounter.MovementWindows = []*proto.MovementWindow{{
+		Start:    20,
+		Duration: 10,
+		Interval: 30,
+	}}
+	result := RunRaidSim(request)
+	if result.ErrorResult != "" {
+		t.Fatalf("Sim failed: %s", result.ErrorResult)
+	}
+
+	// Ten windows at 20s, 50s, ..., 290s; the last one ends with the fight.
+	player := result.RaidMetrics.Parties[0].Players[0]
+	if expected := 10 * 10.0; math.Abs(player.SecondsMovingAvg-expected) > 1e-6 {
+		t.Fatalf("Expected %f seconds moving, got %f", expected, player.SecondsMovingAvg)
+	}
+	if pet := player.Pets[0]; pet.SecondsMovingAvg != 0 {
+		t.Fatalf("Expected pets not to move, got %f seconds", pet.SecondsMovingAvg)
+	}
+
+	baselineDps := baseline.RaidMetrics.Parties[0].Players[0].Dps.Avg
+	if player.Dps.Avg >= baselineDps {
+		t.Fatalf("Expected movement to lower dps, got %f vs %f without movement", player.Dps.Avg, baselineDps)
+	}
+}
+
+func TestMovementInterruptsCast(t *testing.T) {
+	sim := NewSim(fakeCasterRequest(&proto.SimOptions{RandomSeed: 101}))
+	sim.Reset()
+	fc := sim.Raid.Parties[0].Players[0].(*FakeCaster)
+
+	fc.Nuke.Cast(sim, fc.CurrentTarget)
+	sim.CurrentTime = time.Millisecond * 500
+	fc.MoveUntil(sim, time.Second*3)
+
+	if fc.Hardcast.Expires != sim.CurrentTime {
+		t.Fatalf("Expected the cast to be interrupted, still casting until %s", fc.Hardcast.Expires)
+	}
+	// The GCD started by the interrupted cast isn't refunded.
+	if readyAt := fc.GCD.ReadyAt(); readyAt != time.Millisecond*1500 {
+		t.Fatalf("Expected the GCD to be ready at 1.5s, got %s", readyAt)
+	}
+
+	// Once the GCD is ready, only instants can be used until the movement ends.
+	sim.CurrentTime = time.Millisecond * 1500
+	if fc.Nuke.CanCast(sim, fc.CurrentTarget) || !fc.Dot.CanCast(sim, fc.CurrentTarget) {
+		t.Fatalf("Expected only instants to be castable while moving")
+	}
+}
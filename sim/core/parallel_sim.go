@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Returns how many Simulations to split the iterations of a raid sim across.
+func parallelWorkers(options *proto.SimOptions) int {
+	// Logs can only be collected from a single Simulation.
+	if options.Debug || options.DebugFirstIteration || options.Interactive {
+		return 1
+	}
+
+	workers := int(options.Concurrency)
+	if workers < 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > int(options.Iterations) {
+		workers = int(options.Iterations)
+	}
+	if workers < 1 {
+		return 1
+	}
+	return workers
+}
+
+// Splits the iterations across the given number of workers, each with its own
+// Simulation, and merges their metrics back into sim.
+//
+// Each worker runs a contiguous range of iterations with the same seeds a
+// single Simulation would use for them, and metrics are merged in worker
+// order, so results only depend on the seed and the number of workers.
+func (sim *Simulation) runParallel(ctx context.Context, rsr *proto.RaidSimRequest, workers int, skipPresim bool) *proto.RaidSimResult {
+	options := sim.Options
+	baseSeed := options.RandomSeed
+	if baseSeed == 0 {
+		baseSeed = sim.rseed
+	}
+	report := sim.ProgressReport
+
+	sims := make([]*Simulation, workers)
+	totals := make([]iterationTotals, workers)
+	panics := make([]interface{}, workers)
+
+	var progressMux sync.Mutex
+	completedByWorker := make([]int32, workers)
+	reportProgress := func(worker int, progress *proto.ProgressMetrics) {
+		// The final result is reported once all workers are merged.
+		if progress.FinalRaidResult != nil {
+			return
+		}
+		progressMux.Lock()
+		defer progressMux.Unlock()
+
+		completedByWorker[worker] = progress.CompletedIterations
+		completed := int32(0)
+		for _, c := range completedByWorker {
+			completed += c
+		}
+		report(&proto.ProgressMetrics{TotalIterations: options.Iterations, CompletedIterations: completed, Dps: progress.Dps, Hps: progress.Hps})
+	}
+
+	var waitGroup sync.WaitGroup
+	start := int32(0)
+	for w := 0; w < workers; w++ {
+		workerOptions := googleProto.Clone(options).(*proto.SimOptions)
+		workerOptions.Iterations = options.Iterations / int32(workers)
+		if int32(w) < options.Iterations%int32(workers) {
+			workerOptions.Iterations++
+		}
+		workerOptions.RandomSeed = baseSeed + int64(start)
+		workerOptions.Concurrency = 0
+		start += workerOptions.Iterations
+
+		// Each worker only runs its share of the iterations, so scale the target
+		// such that the merged error still meets it.
+		workerOptions.TargetError *= math.Sqrt(float64(workers))
+		workerOptions.MinIterations = (minIterations(options) + int32(workers) - 1) / int32(workers)
+
+		waitGroup.Add(1)
+		go func(w int, workerOptions *proto.SimOptions) {
+			defer waitGroup.Done()
+			defer func() {
+				if err := recover(); err != nil {
+					panics[w] = err
+				}
+			}()
+
+			workerSim := sim
+			if w == 0 {
+				sim.Options = workerOptions
+			} else {
+				workerRequest := googleProto.Clone(rsr).(*proto.RaidSimRequest)
+				workerRequest.SimOptions = workerOptions
+				workerSim = NewSim(workerRequest)
+
+				// Presims use a fixed seed, so this gives the same results as for sim.
+				if !skipPresim {
+					workerSim.usePresimDuration(workerSim.runPresims(ctx, workerRequest))
+				}
+			}
+			workerSim.firstIteration = int32(workerOptions.RandomSeed - baseSeed)
+			sims[w] = workerSim
+
+			if report != nil {
+				workerSim.ProgressReport = func(progress *proto.ProgressMetrics) {
+					reportProgress(w, progress)
+				}
+			}
+			totals[w] = workerSim.runIterations(ctx)
+		}(w, workerOptions)
+	}
+	waitGroup.Wait()
+
+	for _, err := range panics {
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	sim.Options = options
+	sim.ProgressReport = report
+
+	merged := totals[0]
+	for w := 1; w < workers; w++ {
+		sim.mergeMetrics(sims[w])
+		sim.events = append(sim.events, sims[w].events...)
+		merged.completed += totals[w].completed
+		merged.totalDuration += totals[w].totalDuration
+		merged.cancelled = merged.cancelled || totals[w].cancelled
+	}
+	return sim.finishRun(merged, "")
+}
+
+// Adds the metrics of other, which must have been created from the same
+// request, to those of sim.
+func (sim *Simulation) mergeMetrics(other *Simulation) {
+	sim.Raid.dpsMetrics.merge(&other.Raid.dpsMetrics)
+	sim.Raid.hpsMetrics.merge(&other.Raid.hpsMetrics)
+	for i, party := range sim.Raid.Parties {
+		party.dpsMetrics.merge(&other.Raid.Parties[i].dpsMetrics)
+		party.hpsMetrics.merge(&other.Raid.Parties[i].hpsMetrics)
+	}
+
+	for i, unit := range sim.AllUnits {
+		otherUnit := other.AllUnits[i]
+		unit.Metrics.merge(&otherUnit.Metrics)
+		unit.auraTracker.mergeMetrics(&otherUnit.auraTracker)
+		if unit.Rotation != nil && otherUnit.Rotation != nil {
+			unit.Rotation.mergeMetrics(otherUnit.Rotation)
+		}
+	}
+}
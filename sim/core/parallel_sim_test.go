@@ -0,0 +1,46 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func TestParallelIterations(t *testing.T) {
+	runWithConcurrency := func(concurrency int32) *proto.RaidSimResult {
+		result := RunRaidSim(fakeCasterRequest(&proto.SimOptions{
+			Iterations:  50,
+			RandomSeed:  101,
+			Concurrency: concurrency,
+		}))
+		if result.ErrorResult != "" {
+			t.Fatalf("Sim failed: %s", result.ErrorResult)
+		}
+		return result
+	}
+	player := func(result *proto.RaidSimResult) *proto.UnitMetrics {
+		return result.RaidMetrics.Parties[0].Players[0]
+	}
+
+	// Every iteration uses the same seed regardless of the number of workers, so
+	// only the order of floating point sums differs.
+	single := runWithConcurrency(1)
+	parallel := runWithConcurrency(4)
+	if parallel.Iterations != 50 {
+		t.Fatalf("Expected 50 iterations, got %d", parallel.Iterations)
+	}
+	if math.Abs(player(single).Dps.Avg-player(parallel).Dps.Avg) > 1e-6 ||
+		math.Abs(player(single).Pets[0].Dps.Avg-player(parallel).Pets[0].Dps.Avg) > 1e-6 {
+		t.Fatalf("Expected the same DPS, got %f and %f", player(single).Dps.Avg, player(parallel).Dps.Avg)
+	}
+	if len(player(single).Auras) != len(player(parallel).Auras) || len(player(single).Resources) != len(player(parallel).Resources) {
+		t.Fatalf("Expected the same aura and resource metrics")
+	}
+
+	// With the same number of workers, results are identical.
+	again := runWithConcurrency(4)
+	if player(again).Dps.Avg != player(parallel).Dps.Avg || player(again).Dps.Stdev != player(parallel).Dps.Stdev || player(again).Dps.P50 != player(parallel).Dps.P50 {
+		t.Fatalf("Expected identical results, got %v and %v", player(again).Dps, player(parallel).Dps)
+	}
+}
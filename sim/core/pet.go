@@ -185,6 +185,9 @@ func (pet *Pet) Enable(sim *Simulation, petAgent PetAgent) {
 		pet.Log(sim, "Pet inherited stats: %s", pet.ApplyStatDependencies(pet.inheritedStats))
 		pet.Log(sim, "Pet summoned")
 	}
+	if sim.recordingEvents {
+		sim.recordPetEvent(pet, true)
+	}
 }
 func (pet *Pet) Disable(sim *Simulation) {
 	if !pet.enabled {
@@ -222,6 +225,10 @@ func (pet *Pet) Disable(sim *Simulation) {
 		pet.OnPetDisable(sim)
 	}
 
+	if sim.recordingEvents {
+		sim.recordPetEvent(pet, false)
+	}
+
 	if sim.Log != nil {
 		pet.Log(sim, "Pet dismissed")
 
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"time"
 
 	"github.com/wowsims/wotlk/sim/core/proto"
@@ -31,7 +32,7 @@ type PresimOptions struct {
 	OnPresimResult func(presimResult *proto.UnitMetrics, iterations int32, duration time.Duration) bool
 }
 
-func (sim *Simulation) runPresims(request *proto.RaidSimRequest) *proto.RaidSimResult {
+func (sim *Simulation) runPresims(ctx context.Context, request *proto.RaidSimRequest) *proto.RaidSimResult {
 	const numPresimIterations = 100
 
 	// Run presims if requested.
@@ -70,6 +71,9 @@ func (sim *Simulation) runPresims(request *proto.RaidSimRequest) *proto.RaidSimR
 	presimRequest.SimOptions.Debug = false
 	presimRequest.SimOptions.DebugFirstIteration = false
 	presimRequest.SimOptions.Iterations = numPresimIterations
+	presimRequest.SimOptions.TargetError = 0
+	presimRequest.SimOptions.EventIterations = nil
+	presimRequest.SimOptions.ReplaySeed = 0
 	duration := DurationFromSeconds(presimRequest.Encounter.Duration)
 
 	var lastResult *proto.RaidSimResult
@@ -94,10 +98,10 @@ func (sim *Simulation) runPresims(request *proto.RaidSimRequest) *proto.RaidSimR
 		}
 
 		// Run the presim.
-		presimResult := runSim(presimRequest, nil, true)
+		presimResult := runSim(ctx, presimRequest, nil, true)
 		lastResult = presimResult
 
-		if presimResult.ErrorResult != "" {
+		if presimResult.ErrorResult != "" || presimResult.Cancelled {
 			break
 		}
 
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"math"
+	"sort"
+)
+
+// Relative accuracy of quantiles reported by a quantileSketch, e.g. 0.001
+// means a p50 of 5000 DPS is accurate to within 5 DPS.
+const quantileSketchRelativeAccuracy = 0.001
+
+// Values closer to 0 than this are counted as exactly 0.
+const quantileSketchMinValue = 1e-9
+
+var quantileSketchGamma = (1 + quantileSketchRelativeAccuracy) / (1 - quantileSketchRelativeAccuracy)
+var quantileSketchLogGamma = math.Log(quantileSketchGamma)
+
+// Streaming quantile estimator with bounded relative error (a DDSketch), so
+// percentiles can be computed without keeping every sample around.
+//
+// Values are counted in logarithmically sized buckets, which makes the sketch
+// small, mergeable and deterministic regardless of insertion order.
+type quantileSketch struct {
+	count    int
+	zeros    int
+	positive map[int32]int
+	negative map[int32]int
+}
+
+func newQuantileSketch() quantileSketch {
+	return quantileSketch{
+		positive: make(map[int32]int),
+		negative: make(map[int32]int),
+	}
+}
+
+func quantileSketchKey(value float64) int32 {
+	return int32(math.Ceil(math.Log(value) / quantileSketchLogGamma))
+}
+
+// Returns the value in the middle of the bucket, relative error wise.
+func quantileSketchValue(key int32) float64 {
+	return 2 * math.Pow(quantileSketchGamma, float64(key)) / (quantileSketchGamma + 1)
+}
+
+func (qs *quantileSketch) add(value float64) {
+	qs.count++
+	if value > quantileSketchMinValue {
+		qs.positive[quantileSketchKey(value)]++
+	} else if value < -quantileSketchMinValue {
+		qs.negative[quantileSketchKey(-value)]++
+	} else {
+		qs.zeros++
+	}
+}
+
+func (qs *quantileSketch) merge(other *quantileSketch) {
+	qs.count += other.count
+	qs.zeros += other.zeros
+	for key, count := range other.positive {
+		qs.positive[key] += count
+	}
+	for key, count := range other.negative {
+		qs.negative[key] += count
+	}
+}
+
+// Returns the estimated value at quantile q, which must be in [0, 1].
+func (qs *quantileSketch) quantile(q float64) float64 {
+	if qs.count == 0 {
+		return 0
+	}
+
+	rank := q * float64(qs.count-1)
+	seen := 0
+
+	// Negative values, from most to least negative.
+	negativeKeys := sortedQuantileSketchKeys(qs.negative)
+	for i := len(negativeKeys) - 1; i >= 0; i-- {
+		seen += qs.negative[negativeKeys[i]]
+		if float64(seen) > rank {
+			return -quantileSketchValue(negativeKeys[i])
+		}
+	}
+
+	seen += qs.zeros
+	if float64(seen) > rank {
+		return 0
+	}
+
+	positiveKeys := sortedQuantileSketchKeys(qs.positive)
+	for _, key := range positiveKeys {
+		seen += qs.positive[key]
+		if float64(seen) > rank {
+			return quantileSketchValue(key)
+		}
+	}
+	return quantileSketchValue(positiveKeys[len(positiveKeys)-1])
+}
+
+func sortedQuantileSketchKeys(buckets map[int32]int) []int32 {
+	keys := make([]int32, 0, len(buckets))
+	for key := range buckets {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuantileSketchAccuracy(t *testing.T) {
+	sketch := newQuantileSketch()
+	for i := 1; i <= 10000; i++ {
+		sketch.add(float64(i))
+	}
+
+	for _, q := range []float64{0.05, 0.25, 0.5, 0.75, 0.95} {
+		expected := 1 + q*9999
+		actual := sketch.quantile(q)
+		if math.Abs(actual-expected) > expected*quantileSketchRelativeAccuracy+1 {
+			t.Fatalf("Quantile %f: expected %f, got %f", q, expected, actual)
+		}
+	}
+}
+
+func TestQuantileSketchNegativeAndZero(t *testing.T) {
+	sketch := newQuantileSketch()
+	for _, v := range []float64{-100, -10, 0, 0, 10} {
+		sketch.add(v)
+	}
+
+	if v := sketch.quantile(0); math.Abs(v+100) > 0.1 {
+		t.Fatalf("Expected min of -100, got %f", v)
+	}
+	if v := sketch.quantile(0.5); v != 0 {
+		t.Fatalf("Expected median of 0, got %f", v)
+	}
+	if v := sketch.quantile(1); math.Abs(v-10) > 0.01 {
+		t.Fatalf("Expected max of 10, got %f", v)
+	}
+}
+
+func TestQuantileSketchMerge(t *testing.T) {
+	all := newQuantileSketch()
+	a := newQuantileSketch()
+	b := newQuantileSketch()
+	for i := 1; i <= 1000; i++ {
+		all.add(float64(i))
+		if i%2 == 0 {
+			a.add(float64(i))
+		} else {
+			b.add(float64(i))
+		}
+	}
+	a.merge(&b)
+
+	for _, q := range []float64{0.05, 0.5, 0.95} {
+		if a.quantile(q) != all.quantile(q) {
+			t.Fatalf("Quantile %f: merged sketch returned %f, expected %f", q, a.quantile(q), all.quantile(q))
+		}
+	}
+}
@@ -132,13 +132,19 @@ func (rb *rageBar) AddRage(sim *Simulation, amount float64, metrics *ResourceMet
 
 	newRage := MinFloat(rb.currentRage+amount, MaxRage)
 	metrics.AddEvent(amount, newRage-rb.currentRage)
+	if sim.recordingEvents {
+		sim.recordResourceEvent(rb.unit, metrics, amount, newRage-rb.currentRage, newRage)
+	}
+	rb.unit.Metrics.timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeRage, newRage)
 
 	if sim.Log != nil {
 		rb.unit.Log(sim, "Gained %0.3f rage from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, rb.currentRage, newRage)
 	}
 
 	rb.currentRage = newRage
-	if !sim.Options.Interactive {
+	if rb.unit.IsUsingAPL() {
+		rb.unit.Rotation.recheckIfIdling(sim)
+	} else if !sim.Options.Interactive {
 		rb.onRageGain(sim)
 	}
 }
@@ -150,6 +156,10 @@ func (rb *rageBar) SpendRage(sim *Simulation, amount float64, metrics *ResourceM
 
 	newRage := rb.currentRage - amount
 	metrics.AddEvent(-amount, -amount)
+	if sim.recordingEvents {
+		sim.recordResourceEvent(rb.unit, metrics, -amount, -amount, newRage)
+	}
+	rb.unit.Metrics.timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeRage, newRage)
 
 	if sim.Log != nil {
 		rb.unit.Log(sim, "Spent %0.3f rage from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, rb.currentRage, newRage)
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Periodic encounter damage against the raid, see proto.RaidDamage.
+type raidDamage struct {
+	config   *proto.RaidDamage
+	spell    *Spell
+	interval time.Duration
+
+	candidates []*Unit
+}
+
+// Whether any part of the encounter damages players other than the tanks.
+func encounterDamagesRaid(options *proto.Encounter) bool {
+	return len(options.RaidDamage) > 0
+}
+
+// Registers the raid damage spells on the first target.
+func (encounter *Encounter) registerRaidDamage(configs []*proto.RaidDamage) {
+	caster := encounter.Targets[0]
+	for i, config := range configs {
+		config := config
+		actionID := ActionID{SpellID: config.SpellId}
+		if config.SpellId == 0 {
+			actionID = ActionID{OtherID: proto.OtherAction_OtherActionDamageTaken}
+		}
+
+		rd := &raidDamage{
+			config:   config,
+			interval: DurationFromSeconds(config.Interval),
+		}
+		rd.spell = caster.RegisterSpell(SpellConfig{
+			ActionID:    actionID.WithTag(int32(i + 1)),
+			SpellSchool: SpellSchoolFromProto(config.School),
+			ProcMask:    ProcMaskSpellDamage,
+
+			DamageMultiplier: 1,
+			CritMultiplier:   1,
+
+			ApplyEffects: func(sim *Simulation, _ *Unit, spell *Spell) {
+				for _, unit := range rd.pickTargets(sim) {
+					baseDamage := sim.Roll(config.Damage-config.DamageVariation, config.Damage+config.DamageVariation)
+					spell.CalcAndDealDamage(sim, unit, MaxFloat(baseDamage, 0), spell.OutcomeAlwaysHit)
+				}
+			},
+		})
+		encounter.raidDamage = append(encounter.raidDamage, rd)
+	}
+}
+
+func (encounter *Encounter) scheduleRaidDamage(sim *Simulation) {
+	for _, rd := range encounter.raidDamage {
+		rd := rd
+		if rd.interval <= 0 {
+			continue
+		}
+
+		pa := &PendingAction{
+			NextActionAt: rd.nextInterval(sim),
+		}
+		pa.OnAction = func(sim *Simulation) {
+			rd.hit(sim)
+			pa.NextActionAt = sim.CurrentTime + rd.nextInterval(sim)
+			sim.AddPendingAction(pa)
+		}
+		sim.AddPendingAction(pa)
+	}
+}
+
+func (rd *raidDamage) hit(sim *Simulation) {
+	if sim.Log != nil {
+		sim.Log("Raid damage: %s", rd.config.Name)
+	}
+	// The players hit are picked in ApplyEffects.
+	rd.spell.Cast(sim, sim.Raid.AllUnits[0])
+}
+
+func (rd *raidDamage) nextInterval(sim *Simulation) time.Duration {
+	if rd.config.IntervalVariation == 0 {
+		return rd.interval
+	}
+	seconds := sim.RollWithLabel(rd.config.Interval-rd.config.IntervalVariation, rd.config.Interval+rd.config.IntervalVariation, "Raid Damage Interval")
+	return MaxDuration(DurationFromSeconds(seconds), time.Millisecond*100)
+}
+
+// Returns the units hit by the next cast: the whole raid, or num_targets
+// random players.
+func (rd *raidDamage) pickTargets(sim *Simulation) []*Unit {
+	candidates := rd.candidates[:0]
+	for _, unit := range sim.Raid.AllUnits {
+		if unit.IsEnabled() && (rd.config.NumTargets == 0 || unit.Type == PlayerUnit) {
+			candidates = append(candidates, unit)
+		}
+	}
+	rd.candidates = candidates
+
+	if rd.config.NumTargets == 0 || int(rd.config.NumTargets) >= len(candidates) {
+		return candidates
+	}
+
+	// Partial Fisher-Yates shuffle.
+	for i := 0; i < int(rd.config.NumTargets); i++ {
+		j := i + int(sim.RandomFloat("Raid Damage Target")*float64(len(candidates)-i))
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	}
+	return candidates[:rd.config.NumTargets]
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func TestRaidDamage(t *testing.T) {
+	request := fakeCasterRequest(&proto.SimOptions{Iterations: 5, RandomSeed: 101})
+	request.Encounter.RaidDamage = []*proto.RaidDamage{{
+		Name:            "Shadow Pulse",
+		SpellId:         12345,
+		School:          proto.SpellSchool_SpellSchoolShadow,
+		Damage:          2000,
+		DamageVariation: 500,
+		Interval:        5,
+	}}
+
+	// Raid-wide damage hits pets too, and kills a player nobody heals.
+	result := RunRaidSim(request)
+	if result.ErrorResult != "" {
+		t.Fatalf("Sim failed: %s", result.ErrorResult)
+	}
+	player := result.RaidMetrics.Parties[0].Players[0]
+	if player.ChanceOfDeath != 1 {
+		t.Fatalf("Expected the player to die, got chance of death %f", player.ChanceOfDeath)
+	}
+	if player.Pets[0].Dtps.Avg == 0 {
+		t.Fatalf("Expected the pet to take damage")
+	}
+
+	// Random-target damage only hits players, and a healing model keeps them alive.
+	request.Encounter.RaidDamage[0].NumTargets = 1
+	request.Raid.Parties[0].Players[0].HealingModel = &proto.HealingModel{
+		Hps:            1000,
+		CadenceSeconds: 1,
+	}
+	result = RunRaidSim(request)
+	if result.ErrorResult != "" {
+		t.Fatalf("Sim failed: %s", result.ErrorResult)
+	}
+	player = result.RaidMetrics.Parties[0].Players[0]
+	if player.ChanceOfDeath != 0 {
+		t.Fatalf("Expected the player to survive, got chance of death %f", player.ChanceOfDeath)
+	}
+	if expected := 2000.0 / 5; math.Abs(player.Dtps.Avg-expected) > 50 {
+		t.Fatalf("Expected dtps of about %f, got %f", expected, player.Dtps.Avg)
+	}
+	if player.Pets[0].Dtps.Avg != 0 {
+		t.Fatalf("Expected the pet not to take damage, got dtps %f", player.Pets[0].Dtps.Avg)
+	}
+}
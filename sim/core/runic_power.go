@@ -190,6 +190,10 @@ func (rp *RunicPowerBar) addRunicPowerInterval(sim *Simulation, amount float64,
 
 	if !rp.isACopy {
 		metrics.AddEvent(amount, newRunicPower-rp.currentRunicPower)
+		if sim.recordingEvents {
+			sim.recordResourceEvent(rp.unit, metrics, amount, newRunicPower-rp.currentRunicPower, newRunicPower)
+		}
+		rp.unit.Metrics.timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeRunicPower, newRunicPower)
 
 		if sim.Log != nil {
 			rp.unit.Log(sim, "Gained %0.3f runic power from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, rp.currentRunicPower, newRunicPower)
@@ -202,7 +206,11 @@ func (rp *RunicPowerBar) addRunicPowerInterval(sim *Simulation, amount float64,
 func (rp *RunicPowerBar) AddRunicPower(sim *Simulation, amount float64, metrics *ResourceMetrics) {
 	rp.addRunicPowerInterval(sim, amount, metrics)
 	if !rp.isACopy {
-		rp.onRunicPowerGain(sim)
+		if rp.unit.IsUsingAPL() {
+			rp.unit.Rotation.recheckIfIdling(sim)
+		} else {
+			rp.onRunicPowerGain(sim)
+		}
 	}
 }
 
@@ -215,6 +223,10 @@ func (rp *RunicPowerBar) spendRunicPower(sim *Simulation, amount float64, metric
 
 	if !rp.isACopy {
 		metrics.AddEvent(-amount, -amount)
+		if sim.recordingEvents {
+			sim.recordResourceEvent(rp.unit, metrics, -amount, -amount, newRunicPower)
+		}
+		rp.unit.Metrics.timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeRunicPower, newRunicPower)
 
 		if sim.Log != nil {
 			rp.unit.Log(sim, "Spent %0.3f runic power from %s (%0.3f --> %0.3f).", amount, metrics.ActionID, rp.currentRunicPower, newRunicPower)
@@ -547,6 +559,31 @@ func (rp *RunicPowerBar) CurrentDeathRunes() int8 {
 	return count
 }
 
+// Returns the current number of runes tracked by rune metrics of the given type.
+func (rp *RunicPowerBar) currentRunesOfType(resourceType proto.ResourceType) int8 {
+	switch resourceType {
+	case proto.ResourceType_ResourceTypeDeathRune:
+		return rp.CurrentDeathRunes()
+	case proto.ResourceType_ResourceTypeBloodRune:
+		return rp.CurrentBloodRunes()
+	case proto.ResourceType_ResourceTypeFrostRune:
+		return rp.CurrentFrostRunes()
+	case proto.ResourceType_ResourceTypeUnholyRune:
+		return rp.CurrentUnholyRunes()
+	}
+	return 0
+}
+
+// Rune gains and spends can convert runes to or from death runes, so update
+// the timelines of all rune types.
+func (rp *RunicPowerBar) updateRuneTimelines(sim *Simulation) {
+	timelines := &rp.unit.Metrics.timelines
+	timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeBloodRune, float64(rp.CurrentBloodRunes()))
+	timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeFrostRune, float64(rp.CurrentFrostRunes()))
+	timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeUnholyRune, float64(rp.CurrentUnholyRunes()))
+	timelines.setResourceLevel(sim, proto.ResourceType_ResourceTypeDeathRune, float64(rp.CurrentDeathRunes()))
+}
+
 func (rp *RunicPowerBar) DeathRunesInFU() int8 {
 	var count int8
 	for i := 2; i < len(rp.runeMeta); i++ {
@@ -740,6 +777,10 @@ func (rp *RunicPowerBar) SpendRuneCost(sim *Simulation, spell *Spell, cost RuneC
 func (rp *RunicPowerBar) GainRuneMetrics(sim *Simulation, metrics *ResourceMetrics, gainAmount int8) {
 	if !rp.isACopy {
 		metrics.AddEvent(float64(gainAmount), float64(gainAmount))
+		if sim.recordingEvents {
+			sim.recordResourceEvent(rp.unit, metrics, float64(gainAmount), float64(gainAmount), float64(rp.currentRunesOfType(metrics.Type)))
+		}
+		rp.updateRuneTimelines(sim)
 
 		if sim.Log != nil {
 			var name string
@@ -771,6 +812,10 @@ func (rp *RunicPowerBar) GainRuneMetrics(sim *Simulation, metrics *ResourceMetri
 func (rp *RunicPowerBar) SpendRuneMetrics(sim *Simulation, metrics *ResourceMetrics, spendAmount int8) {
 	if !rp.isACopy {
 		metrics.AddEvent(-float64(spendAmount), -float64(spendAmount))
+		if sim.recordingEvents {
+			sim.recordResourceEvent(rp.unit, metrics, -float64(spendAmount), -float64(spendAmount), float64(rp.currentRunesOfType(metrics.Type)))
+		}
+		rp.updateRuneTimelines(sim)
 
 		if sim.Log != nil {
 			var name string
@@ -941,6 +986,9 @@ func (rp *RunicPowerBar) launchPA(sim *Simulation, at time.Duration) {
 		if !pa.cancelled {
 			// regenerate and revert
 			rp.Advance(sim, sim.CurrentTime)
+			if rp.unit.IsUsingAPL() {
+				rp.unit.Rotation.recheckIfIdling(sim)
+			}
 
 			// Check when we need next check
 			pa.NextActionAt = MinDuration(rp.AnySpentRuneReadyAt(), rp.DeathRuneRevertAt())
@@ -113,6 +113,10 @@ func (rp *RunicPowerBar) GainDeathRuneMetrics(sim *Simulation, spell *Spell, cur
 	if !rp.isACopy {
 		metrics := rp.deathRuneGainMetrics
 		metrics.AddEvent(1, float64(newRunes)-float64(currRunes))
+		if sim.recordingEvents {
+			sim.recordResourceEvent(rp.unit, metrics, 1, float64(newRunes)-float64(currRunes), float64(newRunes))
+		}
+		rp.updateRuneTimelines(sim)
 
 		if sim.Log != nil {
 			rp.unit.Log(sim, "Gained 1.000 death rune from %s (%d --> %d).", metrics.ActionID, currRunes, newRunes)
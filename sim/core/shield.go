@@ -23,6 +23,9 @@ func (shield *Shield) Apply(sim *Simulation, shieldAmount float64) {
 	threat := 0.0 // TODO
 	shield.Spell.SpellMetrics[target.UnitIndex].TotalThreat += threat
 	shield.Spell.SpellMetrics[target.UnitIndex].TotalShielding += shieldAmount
+	if !caster.IsOpponent(target) {
+		caster.Metrics.timelines.addHealing(sim, shieldAmount)
+	}
 	shield.Spell.SpellMetrics[target.UnitIndex].Hits++
 
 	if sim.Log != nil {
@@ -1,7 +1,9 @@
 package core
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"math/rand"
 	"runtime"
 	"runtime/debug"
@@ -10,6 +12,7 @@ import (
 	"time"
 
 	"github.com/wowsims/wotlk/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
 )
 
 type Simulation struct {
@@ -34,6 +37,12 @@ type Simulation struct {
 
 	Log func(string, ...interface{})
 
+	// Structured events, see sim_events.go.
+	firstIteration  int32 // Index of the first iteration run by this Simulation.
+	eventIteration  int32
+	recordingEvents bool
+	events          []*proto.SimEvent
+
 	executePhase20Begins  time.Duration
 	executePhase25Begins  time.Duration
 	executePhase35Begins  time.Duration
@@ -44,10 +53,16 @@ type Simulation struct {
 }
 
 func RunSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics) *proto.RaidSimResult {
-	return runSim(rsr, progress, false)
+	return runSim(context.Background(), rsr, progress, false)
+}
+
+// Like RunSim, but stops between iterations once ctx is cancelled or its
+// deadline passes, returning a partial result flagged as cancelled.
+func RunSimWithContext(ctx context.Context, rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics) *proto.RaidSimResult {
+	return runSim(ctx, rsr, progress, false)
 }
 
-func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool) (result *proto.RaidSimResult) {
+func runSim(ctx context.Context, rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, skipPresim bool) (result *proto.RaidSimResult) {
 	defer func() {
 		if err := recover(); err != nil {
 			errStr := ""
@@ -73,6 +88,9 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 		}
 	}()
 
+	if rsr.SimOptions.GetReplaySeed() != 0 {
+		rsr = replayRequest(rsr)
+	}
 	sim := NewSim(rsr)
 
 	if !skipPresim {
@@ -83,7 +101,7 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 			}
 			runtime.Gosched() // allow time for message to make it back out.
 		}
-		presimResult := sim.runPresims(rsr)
+		presimResult := sim.runPresims(ctx, rsr)
 		if presimResult != nil && presimResult.ErrorResult != "" {
 			if progress != nil {
 				progress <- &proto.ProgressMetrics{
@@ -103,20 +121,46 @@ func runSim(rsr *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, ski
 			}
 			runtime.Gosched() // allow time for message to make it back out.
 		}
-		// Use pre-sim as estimate for length of fight (when using health fight)
-		if sim.Encounter.EndFightAtHealth > 0 && presimResult != nil {
-			sim.BaseDuration = time.Duration(presimResult.AvgIterationDuration) * time.Second
-			sim.Duration = time.Duration(presimResult.AvgIterationDuration) * time.Second
-			sim.Encounter.DurationIsEstimate = false // we now have a pretty good value for duration
-		}
+		sim.usePresimDuration(presimResult)
 	}
 
 	// using a variable here allows us to mutate it in the deferred recover, sending out error info
-	result = sim.run()
+	if workers := parallelWorkers(sim.Options); workers > 1 {
+		result = sim.runParallel(ctx, rsr, workers, skipPresim)
+	} else {
+		result = sim.run(ctx)
+	}
 
 	return result
 }
 
+// Use pre-sim as estimate for length of fight (when using health fight)
+func (sim *Simulation) usePresimDuration(presimResult *proto.RaidSimResult) {
+	if sim.Encounter.EndFightAtHealth > 0 && presimResult != nil {
+		sim.BaseDuration = time.Duration(presimResult.AvgIterationDuration) * time.Second
+		sim.Duration = time.Duration(presimResult.AvgIterationDuration) * time.Second
+		sim.Encounter.DurationIsEstimate = false // we now have a pretty good value for duration
+	}
+}
+
+// Returns a copy of rsr which runs the iteration of SimOptions.replay_seed.
+//
+// Iteration i of a sim is seeded with random_seed + i by reseedRands, and the
+// first iteration with random_seed itself, so running a single iteration with
+// random_seed set to the replayed seed gives the same RNG rolls. Presims use a
+// fixed seed, so fight durations are also unchanged.
+func replayRequest(rsr *proto.RaidSimRequest) *proto.RaidSimRequest {
+	rsr = googleProto.Clone(rsr).(*proto.RaidSimRequest)
+	options := rsr.SimOptions
+	options.RandomSeed = options.ReplaySeed
+	options.Iterations = 1
+	options.TargetError = 0
+	options.Concurrency = 0
+	options.Debug = true
+	options.EventIterations = []int32{0}
+	return rsr
+}
+
 func NewSim(rsr *proto.RaidSimRequest) *Simulation {
 	simOptions := rsr.SimOptions
 	rseed := simOptions.RandomSeed
@@ -261,7 +305,7 @@ func (sim *Simulation) reset() {
 
 // Run runs the simulation for the configured number of iterations, and
 // collects all the metrics together.
-func (sim *Simulation) run() *proto.RaidSimResult {
+func (sim *Simulation) run(ctx context.Context) *proto.RaidSimResult {
 	logsBuffer := &strings.Builder{}
 	if sim.Options.Debug || sim.Options.DebugFirstIteration {
 		sim.Log = func(message string, vals ...interface{}) {
@@ -275,8 +319,24 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 	// 	fmt.Printf(fmt.Sprintf("[%0.1f] "+message+"\n", append([]interface{}{sim.CurrentTime.Seconds()}, vals...)...))
 	// }
 
+	totals := sim.runIterations(ctx)
+	return sim.finishRun(totals, logsBuffer.String())
+}
+
+// Totals over the iterations run by a single Simulation.
+type iterationTotals struct {
+	completed              int32
+	firstIterationDuration time.Duration
+	totalDuration          time.Duration
+	cancelled              bool
+}
+
+// Runs the configured number of iterations, stopping early if ctx is cancelled
+// or the target error is reached.
+func (sim *Simulation) runIterations(ctx context.Context) iterationTotals {
 	sim.Init()
 
+	sim.startEventIteration(0)
 	sim.runOnce()
 	firstIterationDuration := sim.Duration
 	if sim.Encounter.EndFightAtHealth != 0 {
@@ -289,7 +349,16 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 	}
 
 	var st time.Time
+	completedIterations := int32(1)
+	cancelled := false
 	for i := int32(1); i < sim.Options.Iterations; i++ {
+		if ctx.Err() != nil {
+			cancelled = true
+			break
+		}
+		if sim.targetErrorReached(i) {
+			break
+		}
 		// fmt.Printf("Iteration: %d\n", i)
 		if sim.ProgressReport != nil && time.Since(st) > time.Millisecond*100 {
 			metrics := sim.Raid.GetMetrics()
@@ -300,6 +369,7 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 
 		// Before each iteration, reset state to seed+iterations
 		sim.reseedRands(int64(i))
+		sim.startEventIteration(i)
 
 		sim.runOnce()
 		iterDuration := sim.Duration
@@ -307,24 +377,87 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 			iterDuration = sim.CurrentTime
 		}
 		totalDuration += iterDuration
+		completedIterations++
 	}
+	sim.recordingEvents = false
+
+	return iterationTotals{
+		completed:              completedIterations,
+		firstIterationDuration: firstIterationDuration,
+		totalDuration:          totalDuration,
+		cancelled:              cancelled,
+	}
+}
+
+// Collects the metrics of all iterations into the result, and sends the final
+// progress report.
+func (sim *Simulation) finishRun(totals iterationTotals, logs string) *proto.RaidSimResult {
 	result := &proto.RaidSimResult{
 		RaidMetrics:      sim.Raid.GetMetrics(),
 		EncounterMetrics: sim.Encounter.GetMetricsProto(),
 
-		Logs:                   logsBuffer.String(),
-		FirstIterationDuration: firstIterationDuration.Seconds(),
-		AvgIterationDuration:   totalDuration.Seconds() / float64(sim.Options.Iterations),
+		Logs:                   logs,
+		FirstIterationDuration: totals.firstIterationDuration.Seconds(),
+		AvgIterationDuration:   totals.totalDuration.Seconds() / float64(totals.completed),
+
+		// The first iteration always runs, so a cancelled sim still has partial metrics.
+		Cancelled: totals.cancelled,
+
+		Iterations: totals.completed,
+		DpsError:   sim.dpsError(),
+
+		Events: sim.events,
 	}
 
 	// Final progress report
 	if sim.ProgressReport != nil {
-		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: sim.Options.Iterations, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
+		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: totals.completed, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
 	}
 
 	return result
 }
 
+// Returns the largest DPS standard error among players, relative to their
+// average DPS if the target error is relative.
+func (sim *Simulation) dpsError() float64 {
+	maxError := 0.0
+	for _, party := range sim.Raid.Parties {
+		for _, player := range party.Players {
+			dpsMetrics := &player.GetCharacter().Metrics.dps
+			dpsError := dpsMetrics.standardError()
+			if sim.Options.TargetErrorRelative {
+				if mean := dpsMetrics.mean(); mean > 0 {
+					dpsError /= mean
+				} else {
+					dpsError = 0
+				}
+			}
+			maxError = math.Max(maxError, dpsError)
+		}
+	}
+	return maxError
+}
+
+// Iterations to run before checking the target error, when not set in SimOptions.
+const defaultMinIterations = 100
+
+// Whether adaptive iterations are enabled and the target error has been
+// reached after the given number of iterations.
+func (sim *Simulation) targetErrorReached(iterations int32) bool {
+	if sim.Options.TargetError <= 0 {
+		return false
+	}
+	return iterations >= minIterations(sim.Options) && sim.dpsError() <= sim.Options.TargetError
+}
+
+func minIterations(options *proto.SimOptions) int32 {
+	if options.MinIterations <= 0 {
+		return defaultMinIterations
+	}
+	// The error can't be estimated from a single iteration.
+	return MaxInt32(options.MinIterations, 2)
+}
+
 func (sim *Simulation) runPendingActions(max time.Duration) {
 	for {
 		finished := sim.Step(max)
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"golang.org/x/exp/slices"
+)
+
+// Structured events are recorded for the iterations listed in
+// SimOptions.EventIterations. Callers check sim.recordingEvents before
+// calling any of the record functions below, like they do with sim.Log.
+
+// Starts or stops recording events, before running the given iteration.
+func (sim *Simulation) startEventIteration(iteration int32) {
+	sim.eventIteration = sim.firstIteration + iteration
+	sim.recordingEvents = slices.Contains(sim.Options.EventIterations, sim.eventIteration)
+}
+
+func (sim *Simulation) recordEvent(unit *Unit, actionID ActionID, event *proto.SimEvent) {
+	event.Iteration = sim.eventIteration
+	event.Timestamp = sim.CurrentTime.Seconds()
+	event.UnitIndex = unit.UnitIndex
+	event.ActionId = actionID.ToProto()
+	sim.events = append(sim.events, event)
+}
+
+func (sim *Simulation) recordCastStart(spell *Spell, target *Unit) {
+	sim.recordEvent(spell.Unit, spell.ActionID, &proto.SimEvent{
+		Event: &proto.SimEvent_CastStart{CastStart: spell.castEvent(target)},
+	})
+}
+
+func (sim *Simulation) recordCastComplete(spell *Spell, target *Unit) {
+	sim.recordEvent(spell.Unit, spell.ActionID, &proto.SimEvent{
+		Event: &proto.SimEvent_CastComplete{CastComplete: spell.castEvent(target)},
+	})
+}
+
+func (spell *Spell) castEvent(target *Unit) *proto.CastEvent {
+	event := &proto.CastEvent{
+		Cost:     MaxFloat(0, spell.CurCast.Cost),
+		CastTime: spell.CurCast.CastTime.Seconds(),
+	}
+	if target != nil {
+		event.TargetUnitIndex = target.UnitIndex
+	}
+	return event
+}
+
+func (sim *Simulation) recordSpellResult(spell *Spell, result *SpellResult, isPeriodic bool, isHealing bool) {
+	outcome := result.Outcome
+	sim.recordEvent(spell.Unit, spell.ActionID, &proto.SimEvent{
+		Event: &proto.SimEvent_SpellResult{SpellResult: &proto.SpellResultEvent{
+			TargetUnitIndex: result.Target.UnitIndex,
+			Periodic:        isPeriodic,
+			Healing:         isHealing,
+			Amount:          result.Damage,
+			Threat:          result.Threat,
+
+			Hit:                  outcome.Matches(OutcomeHit),
+			Crit:                 outcome.Matches(OutcomeCrit),
+			Miss:                 outcome.Matches(OutcomeMiss),
+			Dodge:                outcome.Matches(OutcomeDodge),
+			Parry:                outcome.Matches(OutcomeParry),
+			Block:                outcome.Matches(OutcomeBlock),
+			Glance:               outcome.Matches(OutcomeGlance),
+			Crush:                outcome.Matches(OutcomeCrush),
+			PartialResistPercent: 10 * int32(outcome>>OutcomePartialOffset),
+		}},
+	})
+}
+
+func (sim *Simulation) recordAuraEvent(aura *Aura, eventType proto.AuraEvent_Type) {
+	sim.recordEvent(aura.Unit, aura.ActionID, &proto.SimEvent{
+		Event: &proto.SimEvent_Aura{Aura: &proto.AuraEvent{
+			Type:   eventType,
+			Stacks: aura.stacks,
+		}},
+	})
+}
+
+func (sim *Simulation) recordResourceEvent(unit *Unit, metrics *ResourceMetrics, gain float64, actualGain float64, value float64) {
+	sim.recordEvent(unit, metrics.ActionID, &proto.SimEvent{
+		Event: &proto.SimEvent_Resource{Resource: &proto.ResourceEvent{
+			Type:       metrics.Type,
+			Gain:       gain,
+			ActualGain: actualGain,
+			Value:      value,
+		}},
+	})
+}
+
+func (sim *Simulation) recordPetEvent(pet *Pet, enabled bool) {
+	sim.recordEvent(&pet.Unit, ActionID{}, &proto.SimEvent{
+		Event: &proto.SimEvent_Pet{Pet: &proto.PetEvent{Enabled: enabled}},
+	})
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+func TestSimEvents(t *testing.T) {
+	runWithConcurrency := func(concurrency int32) []*proto.SimEvent {
+		result := RunRaidSim(fakeCasterRequest(&proto.SimOptions{
+			Iterations:      10,
+			RandomSeed:      101,
+			Concurrency:     concurrency,
+			EventIterations: []int32{7},
+		}))
+		if result.ErrorResult != "" {
+			t.Fatalf("Sim failed: %s", result.ErrorResult)
+		}
+		return result.Events
+	}
+
+	events := runWithConcurrency(1)
+	counts := make(map[string]int)
+	for _, event := range events {
+		if event.Iteration != 7 {
+			t.Fatalf("Expected only events from iteration 7, got %d", event.Iteration)
+		}
+		switch event.Event.(type) {
+		case *proto.SimEvent_CastStart:
+			counts["cast_start"]++
+		case *proto.SimEvent_CastComplete:
+			counts["cast_complete"]++
+		case *proto.SimEvent_SpellResult:
+			counts["spell_result"]++
+		case *proto.SimEvent_Aura:
+			counts["aura"]++
+		case *proto.SimEvent_Resource:
+			counts["resource"]++
+		case *proto.SimEvent_Pet:
+			counts["pet"]++
+		}
+	}
+	for _, eventType := range []string{"cast_start", "cast_complete", "spell_result", "aura", "resource", "pet"} {
+		if counts[eventType] == 0 {
+			t.Fatalf("Expected %s events, got none", eventType)
+		}
+	}
+
+	// Iteration 7 runs on a different worker, but with the same seed.
+	parallelEvents := runWithConcurrency(4)
+	if len(parallelEvents) != len(events) {
+		t.Fatalf("Expected %d events with concurrency, got %d", len(events), len(parallelEvents))
+	}
+	for i := range events {
+		if !googleProto.Equal(events[i], parallelEvents[i]) {
+			t.Fatalf("Expected identical events, got %v and %v", events[i], parallelEvents[i])
+		}
+	}
+}
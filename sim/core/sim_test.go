@@ -0,0 +1,334 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	"github.com/wowsims/wotlk/sim/core/stats"
+)
+
+func init() {
+	RegisterAgentFactory(
+		proto.Player_Warlock{},
+		proto.Spec_SpecWarlock,
+		NewFakeCaster,
+		func(player *proto.Player, spec interface{}) {
+			playerSpec, ok := spec.(*proto.Player_Warlock)
+			if !ok {
+				panic("Invalid spec value for Warlock!")
+			}
+			player.Spec = playerSpec
+		},
+	)
+}
+
+// A caster with a pet, for tests which run full sims. Keeps a dot up on its
+// target, uses a damage cooldown whenever it's ready and fills with a cast time
+// nuke. An AoE proc hits every target, including despawned ones.
+type FakeCaster struct {
+	Character
+	Pet *FakePet
+
+	Nuke    *Spell
+	Dot     *Spell
+	Empower *Spell
+	Nova    *Spell
+}
+
+func NewFakeCaster(character Character, options *proto.Player) Agent {
+	fc := &FakeCaster{
+		Character: character,
+	}
+	fc.AddStats(stats.Stats{stats.Health: 10000, stats.Intellect: 1000})
+	fc.EnableManaBar()
+
+	fc.Pet = NewFakePet(&fc.Character)
+	fc.AddPet(fc.Pet)
+	return fc
+}
+
+func (fc *FakeCaster) GetCharacter() *Character {
+	return &fc.Character
+}
+func (fc *FakeCaster) Initialize() {
+	fc.Nuke = fc.RegisterSpell(SpellConfig{
+		ActionID:    ActionID{SpellID: 1},
+		SpellSchool: SpellSchoolShadow,
+		ProcMask:    ProcMaskSpellDamage,
+		ManaCost:    ManaCostOptions{FlatCost: 50},
+		Cast: CastConfig{
+			DefaultCast: Cast{GCD: GCDDefault, CastTime: time.Second * 2},
+		},
+		DamageMultiplier: 1,
+		CritMultiplier:   2,
+		ThreatMultiplier: 1,
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			spell.CalcAndDealDamage(sim, target, sim.Roll(900, 1100), spell.OutcomeMagicHitAndCrit)
+		},
+	})
+
+	fc.Dot = fc.RegisterSpell(SpellConfig{
+		ActionID:    ActionID{SpellID: 2},
+		SpellSchool: SpellSchoolShadow,
+		ProcMask:    ProcMaskSpellDamage,
+		ManaCost:    ManaCostOptions{FlatCost: 50},
+		Cast: CastConfig{
+			DefaultCast: Cast{GCD: GCDDefault},
+		},
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		Dot: DotConfig{
+			Aura:          Aura{Label: "Dot"},
+			NumberOfTicks: 6,
+			TickLength:    time.Second * 3,
+			OnSnapshot: func(sim *Simulation, target *Unit, dot *Dot, isRollover bool) {
+				dot.SnapshotBaseDamage = 200
+				dot.SnapshotAttackerMultiplier = dot.Spell.AttackerDamageMultiplier(dot.Spell.Unit.AttackTables[target.UnitIndex])
+			},
+			OnTick: func(sim *Simulation, target *Unit, dot *Dot) {
+				dot.CalcAndDealPeriodicSnapshotDamage(sim, target, dot.OutcomeTick)
+			},
+		},
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			result := spell.CalcOutcome(sim, target, spell.OutcomeMagicHit)
+			if result.Landed() {
+				spell.Dot(target).Apply(sim)
+			}
+			spell.DealOutcome(sim, result)
+		},
+	})
+
+	empowerAura := fc.RegisterAura(Aura{
+		Label:    "Empower",
+		ActionID: ActionID{SpellID: 3},
+		Duration: time.Second * 10,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.DamageDealtMultiplier *= 1.2
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			aura.Unit.PseudoStats.DamageDealtMultiplier /= 1.2
+		},
+	})
+	fc.Empower = fc.RegisterSpell(SpellConfig{
+		ActionID: ActionID{SpellID: 3},
+		Cast: CastConfig{
+			CD: Cooldown{Timer: fc.NewTimer(), Duration: time.Second * 30},
+		},
+		ApplyEffects: func(sim *Simulation, _ *Unit, _ *Spell) {
+			empowerAura.Activate(sim)
+		},
+	})
+
+	MakePermanent(fc.RegisterAura(Aura{
+		Label:    "Stance",
+		ActionID: ActionID{SpellID: 4},
+	}))
+
+	fc.Nova = fc.RegisterSpell(SpellConfig{
+		ActionID:         ActionID{SpellID: 5},
+		SpellSchool:      SpellSchoolShadow,
+		ProcMask:         ProcMaskEmpty,
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		ApplyEffects: func(sim *Simulation, _ *Unit, spell *Spell) {
+			for _, target := range sim.Encounter.TargetUnits {
+				spell.CalcAndDealDamage(sim, target, 100, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+}
+func (fc *FakeCaster) AddRaidBuffs(raidBuffs *proto.RaidBuffs)    {}
+func (fc *FakeCaster) AddPartyBuffs(partyBuffs *proto.PartyBuffs) {}
+func (fc *FakeCaster) ApplyTalents()                              {}
+func (fc *FakeCaster) Reset(sim *Simulation) {
+	StartPeriodicAction(sim, PeriodicActionOptions{
+		Period: FakeCasterNovaPeriod,
+		OnAction: func(sim *Simulation) {
+			fc.Nova.Cast(sim, nil)
+		},
+	})
+}
+func (fc *FakeCaster) OnGCDReady(sim *Simulation) {
+	if fc.Empower.IsReady(sim) {
+		fc.Empower.Cast(sim, nil)
+	}
+	if !fc.Dot.CurDot().IsActive() {
+		fc.Dot.Cast(sim, fc.CurrentTarget)
+		return
+	}
+	fc.Nuke.Cast(sim, fc.CurrentTarget)
+}
+func (fc *FakeCaster) OnAutoAttack(sim *Simulation, spell *Spell) {}
+
+// How often the FakeCaster's AoE proc hits every target.
+const FakeCasterNovaPeriod = time.Second * 7
+
+// A pet which spams an instant spell on its target.
+type FakePet struct {
+	Pet
+	Bolt *Spell
+}
+
+func NewFakePet(owner *Character) *FakePet {
+	return &FakePet{
+		Pet: NewPet("Fake Pet", owner, stats.Stats{stats.Health: 5000}, func(ownerStats stats.Stats) stats.Stats {
+			return stats.Stats{}
+		}, nil, true, false),
+	}
+}
+
+func (fp *FakePet) GetPet() *Pet {
+	return &fp.Pet
+}
+func (fp *FakePet) Initialize() {
+	fp.Bolt = fp.RegisterSpell(SpellConfig{
+		ActionID:    ActionID{SpellID: 6},
+		SpellSchool: SpellSchoolFire,
+		ProcMask:    ProcMaskSpellDamage,
+		Cast: CastConfig{
+			DefaultCast: Cast{GCD: GCDDefault},
+		},
+		DamageMultiplier: 1,
+		CritMultiplier:   2,
+		ThreatMultiplier: 1,
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			spell.CalcAndDealDamage(sim, target, sim.Roll(400, 600), spell.OutcomeMagicHitAndCrit)
+		},
+	})
+}
+func (fp *FakePet) Reset(sim *Simulation) {}
+func (fp *FakePet) OnGCDReady(sim *Simulation) {
+	fp.Bolt.Cast(sim, fp.CurrentTarget)
+}
+func (fp *FakePet) OnAutoAttack(sim *Simulation, spell *Spell) {}
+
+func fakeCasterRequest(simOptions *proto.SimOptions) *proto.RaidSimRequest {
+	return &proto.RaidSimRequest{
+		Raid: SinglePlayerRaidProto(&proto.Player{
+			Name:      "Caster",
+			Class:     proto.Class_ClassWarlock,
+			Race:      proto.Race_RaceHuman,
+			Equipment: &proto.EquipmentSpec{},
+			Consumes:  &proto.Consumes{},
+			Spec:      &proto.Player_Warlock{Warlock: &proto.Warlock{}},
+		}, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Duration: 300,
+			Targets:  []*proto.Target{NewDefaultTarget()},
+		},
+		SimOptions: simOptions,
+	}
+}
+
+func TestRunSimWithContextCancelled(t *testing.T) {
+	rsr := &proto.RaidSimRequest{
+		Raid: &proto.Raid{},
+		Encounter: &proto.Encounter{
+			Duration: 180,
+			Targets:  []*proto.Target{NewDefaultTarget()},
+		},
+		SimOptions: &proto.SimOptions{Iterations: 1000000},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	result := RunSimWithContext(ctx, rsr, nil)
+	if result.ErrorResult != "" {
+		t.Fatalf("Unexpected error: %s", result.ErrorResult)
+	}
+	if !result.Cancelled || result.RaidMetrics == nil {
+		t.Fatalf("Expected a partial cancelled result, got: %v", result)
+	}
+
+	rsr.SimOptions.Iterations = 10
+	result = RunSimWithContext(context.Background(), rsr, nil)
+	if result.Cancelled {
+		t.Fatalf("Expected a completed result")
+	}
+}
+
+func TestRunSimTargetError(t *testing.T) {
+	rsr := &proto.RaidSimRequest{
+		Raid: &proto.Raid{},
+		Encounter: &proto.Encounter{
+			Duration: 180,
+			Targets:  []*proto.Target{NewDefaultTarget()},
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations:    1000,
+			TargetError:   1,
+			MinIterations: 50,
+		},
+	}
+
+	// Without any players the error is always 0, so this stops at the minimum.
+	result := RunSim(rsr, nil)
+	if result.Cancelled || result.Iterations != 50 || result.DpsError != 0 {
+		t.Fatalf("Expected 50 iterations, got %d (error %f)", result.Iterations, result.DpsError)
+	}
+
+	rsr.SimOptions.TargetError = 0
+	result = RunSim(rsr, nil)
+	if result.Iterations != 1000 {
+		t.Fatalf("Expected 1000 iterations, got %d", result.Iterations)
+	}
+
+	// With a real player, iterations continue until the error drops to the target.
+	rsr = fakeCasterRequest(&proto.SimOptions{
+		Iterations:    10000,
+		RandomSeed:    101,
+		TargetError:   2,
+		MinIterations: 20,
+	})
+	result = RunSim(rsr, nil)
+	if result.Iterations <= 20 || result.Iterations >= 10000 || result.DpsError <= 0 || result.DpsError > 2 {
+		t.Fatalf("Expected to stop early once the error is below 2, got %d iterations (error %f)", result.Iterations, result.DpsError)
+	}
+
+	// An error target which is met right away still runs the minimum iterations.
+	rsr.SimOptions.TargetError = 1000
+	result = RunSim(rsr, nil)
+	if result.Iterations != 20 || result.DpsError <= 0 {
+		t.Fatalf("Expected 20 iterations, got %d (error %f)", result.Iterations, result.DpsError)
+	}
+}
+
+func TestReplaySeed(t *testing.T) {
+	result := RunRaidSim(fakeCasterRequest(&proto.SimOptions{
+		Iterations:  20,
+		RandomSeed:  101,
+		Concurrency: 4,
+	}))
+	if result.ErrorResult != "" {
+		t.Fatalf("Sim failed: %s", result.ErrorResult)
+	}
+	dps := result.RaidMetrics.Parties[0].Players[0].Dps
+
+	for _, iteration := range []struct {
+		seed int64
+		dps  float64
+	}{
+		{dps.MaxSeed, dps.Max},
+		{dps.MinSeed, dps.Min},
+	} {
+		replay := RunRaidSim(fakeCasterRequest(&proto.SimOptions{
+			Iterations: 20,
+			RandomSeed: 101,
+			ReplaySeed: iteration.seed,
+		}))
+		if replay.ErrorResult != "" {
+			t.Fatalf("Replay failed: %s", replay.ErrorResult)
+		}
+		if replay.Iterations != 1 {
+			t.Fatalf("Expected 1 iteration, got %d", replay.Iterations)
+		}
+		if replayDps := replay.RaidMetrics.Parties[0].Players[0].Dps.Avg; replayDps != iteration.dps {
+			t.Fatalf("Expected seed %d to replay with %f DPS, got %f", iteration.seed, iteration.dps, replayDps)
+		}
+		if replay.Logs == "" || len(replay.Events) == 0 {
+			t.Fatalf("Expected logs and events for the replayed iteration")
+		}
+	}
+}
@@ -404,6 +404,20 @@ func (spell *Spell) CanCast(sim *Simulation, target *Unit) bool {
 		return false
 	}
 
+	if spell.targetIsUntargetable(target) {
+		if sim.Log != nil {
+			sim.Log("Cant cast because target is untargetable")
+		}
+		return false
+	}
+
+	if spell.castBlockedByMovement(&spell.DefaultCast) {
+		if sim.Log != nil {
+			sim.Log("Cant cast because of movement")
+		}
+		return false
+	}
+
 	// While casting or channeling, no other action is possible
 	if spell.Unit.Hardcast.Expires > sim.CurrentTime {
 		if sim.Log != nil {
@@ -444,6 +458,12 @@ func (spell *Spell) Cast(sim *Simulation, target *Unit) bool {
 	if target == nil {
 		target = spell.Unit.CurrentTarget
 	}
+	if spell.targetIsUntargetable(target) {
+		if sim.Log != nil {
+			sim.Log("Failed cast because target is untargetable")
+		}
+		return false
+	}
 	return spell.castFn(sim, target)
 }
 
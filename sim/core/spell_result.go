@@ -41,6 +41,12 @@ func (spell *Spell) DisposeResult(result *SpellResult) {
 	result.inUse = false
 }
 
+// Despawned targets can't be hit, e.g. by AoE spells which loop over every
+// target. Their results are left empty, without an outcome roll.
+func (result *SpellResult) targetDespawned() bool {
+	return result.Target.Type == EnemyUnit && !result.Target.IsEnabled()
+}
+
 func (result *SpellResult) Landed() bool {
 	return result.Outcome.Matches(OutcomeLanded)
 }
@@ -167,6 +173,9 @@ func (spell *Spell) ApplyPostOutcomeDamageModifiers(sim *Simulation, result *Spe
 func (spell *Spell) CalcOutcome(sim *Simulation, target *Unit, outcomeApplier OutcomeApplier) *SpellResult {
 	attackTable := spell.Unit.AttackTables[target.UnitIndex]
 	result := spell.NewResult(target)
+	if result.targetDespawned() {
+		return result
+	}
 
 	outcomeApplier(sim, result, attackTable)
 	result.Threat = spell.ThreatFromDamage(result.Outcome, result.Damage)
@@ -177,6 +186,9 @@ func (spell *Spell) calcDamageInternal(sim *Simulation, target *Unit, baseDamage
 	attackTable := spell.Unit.AttackTables[target.UnitIndex]
 
 	result := spell.NewResult(target)
+	if result.targetDespawned() {
+		return result
+	}
 	result.Damage = baseDamage
 
 	if sim.Log == nil {
@@ -230,8 +242,23 @@ func (spell *Spell) CalcAndDealOutcome(sim *Simulation, target *Unit, outcomeApp
 
 // Applies the fully computed spell result to the sim.
 func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result *SpellResult) {
+	if result.targetDespawned() {
+		spell.DisposeResult(result)
+		return
+	}
+	// Untargetable targets take no damage, e.g. from dots which were already on them.
+	if result.Target.untargetable && spell.Unit.IsOpponent(result.Target) {
+		spell.Unit.Metrics.DamageLost += result.Damage
+		result.Target.Metrics.DamageLost += result.Damage
+		spell.DisposeResult(result)
+		return
+	}
+
 	spell.SpellMetrics[result.Target.UnitIndex].TotalDamage += result.Damage
 	spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
+	if spell.Unit.IsOpponent(result.Target) {
+		spell.Unit.Metrics.timelines.addDamage(sim, result.Damage, result.Threat)
+	}
 
 	// Mark total damage done in raid so far for health based fights.
 	// Don't include damage done by EnemyUnits to Players
@@ -246,6 +273,9 @@ func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result
 			spell.Unit.Log(sim, "%s %s %s. (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, result.DamageString(), result.Threat)
 		}
 	}
+	if sim.recordingEvents {
+		sim.recordSpellResult(spell, result, isPeriodic, false)
+	}
 
 	if !spell.Flags.Matches(SpellFlagNoOnDamageDealt) {
 		if isPeriodic {
@@ -321,6 +351,9 @@ func (dot *Dot) CalcSnapshotHealing(sim *Simulation, target *Unit, outcomeApplie
 func (spell *Spell) dealHealingInternal(sim *Simulation, isPeriodic bool, result *SpellResult) {
 	spell.SpellMetrics[result.Target.UnitIndex].TotalHealing += result.Damage
 	spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
+	if !spell.Unit.IsOpponent(result.Target) {
+		spell.Unit.Metrics.timelines.addHealing(sim, result.Damage)
+	}
 	if result.Target.HasHealthBar() {
 		result.Target.GainHealth(sim, result.Damage, spell.HealthMetrics(result.Target))
 	}
@@ -332,6 +365,9 @@ func (spell *Spell) dealHealingInternal(sim *Simulation, isPeriodic bool, result
 			spell.Unit.Log(sim, "%s %s %s. (Threat: %0.3f)", result.Target.LogLabel(), spell.ActionID, result.HealingString(), result.Threat)
 		}
 	}
+	if sim.recordingEvents {
+		sim.recordSpellResult(spell, result, isPeriodic, true)
+	}
 
 	if isPeriodic {
 		spell.Unit.OnPeriodicHealDealt(sim, spell, result)
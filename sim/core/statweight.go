@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"math"
 	"runtime"
 	"sync"
@@ -78,6 +79,8 @@ type StatWeightsResult struct {
 	Dtps   StatWeightValues
 	Tmi    StatWeightValues
 	PDeath StatWeightValues
+
+	Cancelled bool
 }
 
 func NewStatWeightsResult() StatWeightsResult {
@@ -99,10 +102,12 @@ func (swr StatWeightsResult) ToProto() *proto.StatWeightsResult {
 		Dtps:   swr.Dtps.ToProto(),
 		Tmi:    swr.Tmi.ToProto(),
 		PDeath: swr.PDeath.ToProto(),
+
+		Cancelled: swr.Cancelled,
 	}
 }
 
-func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, progress chan *proto.ProgressMetrics) StatWeightsResult {
+func CalcStatWeight(ctx context.Context, swr *proto.StatWeightsRequest, referenceStat stats.Stat, progress chan *proto.ProgressMetrics) StatWeightsResult {
 	if swr.Player.BonusStats == nil {
 		swr.Player.BonusStats = &proto.UnitStats{}
 	}
@@ -133,6 +138,11 @@ func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, pro
 	// Reduce variance even more by using test-level RNG controls.
 	simOptions.IsTest = true
 
+	// The stat sims already run in parallel.
+	simOptions.Concurrency = 0
+	simOptions.EventIterations = nil
+	simOptions.ReplaySeed = 0
+
 	//baseStatsResult := ComputeStats(&proto.ComputeStatsRequest{
 	//	Raid: raidProto,
 	//})
@@ -143,11 +153,24 @@ func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, pro
 		Encounter:  swr.Encounter,
 		SimOptions: simOptions,
 	}
-	baselineResult := RunRaidSim(baseSimRequest)
+	baselineResult := RunSimWithContext(ctx, baseSimRequest, nil)
 	if baselineResult.ErrorResult != "" {
 		// TODO: get stack trace out.
 		return StatWeightsResult{}
 	}
+	if baselineResult.Cancelled {
+		// Weights can't be compared against a partial baseline.
+		result := NewStatWeightsResult()
+		result.Cancelled = true
+		return result
+	}
+
+	// With adaptive iterations, only the baseline decides when to stop. The
+	// stat sims then use the same number of iterations so their RNG lines up.
+	if simOptions.TargetError > 0 {
+		simOptions.Iterations = baselineResult.Iterations
+		simOptions.TargetError = 0
+	}
 
 	var waitGroup sync.WaitGroup
 
@@ -174,12 +197,17 @@ func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, pro
 		defer waitGroup.Done()
 		// wait until we have CPU time available.
 		<-tickets
+		if ctx.Err() != nil {
+			// Leave the result empty, it will be reported as cancelled.
+			tickets <- struct{}{}
+			return
+		}
 
 		simRequest := googleProto.Clone(baseSimRequest).(*proto.RaidSimRequest)
 		stat.AddToStatsProto(simRequest.Raid.Parties[0].Players[0].BonusStats, value)
 
 		reporter := make(chan *proto.ProgressMetrics, 10)
-		go RunSim(simRequest, reporter) // RunRaidSim(simRequest)
+		go RunSimWithContext(ctx, simRequest, reporter) // RunRaidSim(simRequest)
 
 		var localIterations int32
 		var errorStr string
@@ -206,7 +234,7 @@ func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, pro
 			}
 		}
 		// TODO: get stack trace out if final result error is set.
-		if errorStr != "" {
+		if errorStr != "" && ctx.Err() == nil {
 			panic("Stat weights error: " + errorStr)
 		}
 
@@ -267,7 +295,10 @@ func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, pro
 	result := NewStatWeightsResult()
 	for i := 0; i < stats.UnitStatsLen; i++ {
 		stat := stats.UnitStatFromIdx(i)
-		if resultsLow[stat] == nil && resultsHigh[stat] == nil {
+		if statModsLow[stat] != 0 && (resultsLow[stat] == nil || resultsLow[stat].Cancelled || resultsHigh[stat] == nil || resultsHigh[stat].Cancelled) {
+			result.Cancelled = true
+		}
+		if resultsLow[stat] == nil || resultsHigh[stat] == nil {
 			continue
 		}
 
@@ -282,14 +313,17 @@ func CalcStatWeight(swr *proto.StatWeightsRequest, referenceStat stats.Stat, pro
 		}
 
 		calcWeightResults := func(baselineMetrics *proto.DistributionMetrics, modLowMetrics *proto.DistributionMetrics, modHighMetrics *proto.DistributionMetrics, weightResults *StatWeightValues) {
+			// Cancelled sims only have values for the iterations that completed.
+			numIterations := MinInt(len(baselineMetrics.AllValues), MinInt(len(modLowMetrics.AllValues), len(modHighMetrics.AllValues)))
+
 			var sample []float64
 			if resultsLow != nil {
-				for i := 0; i < int(simOptions.Iterations); i++ {
+				for i := 0; i < numIterations; i++ {
 					sample = append(sample, (modLowMetrics.AllValues[i]-baselineMetrics.AllValues[i])/statModsLow[stat])
 				}
 			}
 			if resultsHigh != nil {
-				for i := 0; i < int(simOptions.Iterations); i++ {
+				for i := 0; i < numIterations; i++ {
 					sample = append(sample, (modHighMetrics.AllValues[i]-baselineMetrics.AllValues[i])/statModsHigh[stat])
 				}
 			}
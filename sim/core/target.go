@@ -4,6 +4,8 @@ import (
 	"strconv"
 	"time"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/wowsims/wotlk/sim/core/proto"
 	"github.com/wowsims/wotlk/sim/core/stats"
 )
@@ -14,6 +16,11 @@ type Encounter struct {
 	Targets           []*Target
 	TargetUnits       []*Unit
 
+	// Targets which are currently spawned and targetable, in index order. Targets and
+	// TargetUnits always hold every target so indices stay stable.
+	ActiveTargets     []*Target
+	ActiveTargetUnits []*Unit
+
 	ExecuteProportion_20 float64
 	ExecuteProportion_25 float64
 	ExecuteProportion_35 float64
@@ -27,6 +34,23 @@ type Encounter struct {
 
 	// Value to multiply by, for damage spells which are subject to the aoe cap.
 	aoeCapMultiplier float64
+
+	movementWindows     []movementWindow
+	addWaves            []addWave
+	untargetableWindows []untargetableWindow
+	raidDamage          []*raidDamage
+
+	// Whether players other than tanks take damage, see encounterDamagesRaid.
+	damagesRaid bool
+
+	// Raid units moved to another target by a spawn or despawn, with the target
+	// they started the iteration on.
+	retargetedUnits []retargetedUnit
+}
+
+type retargetedUnit struct {
+	unit          *Unit
+	initialTarget *Unit
 }
 
 func NewEncounter(options *proto.Encounter) Encounter {
@@ -40,6 +64,7 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		ExecuteProportion_25: MaxFloat(options.ExecuteProportion_25, 0),
 		ExecuteProportion_35: MaxFloat(options.ExecuteProportion_35, 0),
 		Targets:              []*Target{},
+		damagesRaid:          encounterDamagesRaid(options),
 	}
 	// If UseHealth is set, we use the sum of targets health.
 	if options.UseHealth {
@@ -70,7 +95,7 @@ func NewEncounter(options *proto.Encounter) Encounter {
 		encounter.DurationIsEstimate = true
 	}
 
-	encounter.updateAOECapMultiplier()
+	encounter.updateActiveTargets()
 
 	return encounter
 }
@@ -79,7 +104,36 @@ func (encounter *Encounter) AOECapMultiplier() float64 {
 	return encounter.aoeCapMultiplier
 }
 func (encounter *Encounter) updateAOECapMultiplier() {
-	encounter.aoeCapMultiplier = MinFloat(10/float64(len(encounter.Targets)), 1)
+	encounter.aoeCapMultiplier = MinFloat(10/float64(MaxInt(len(encounter.ActiveTargets), 1)), 1)
+}
+
+// Rebuilds the active target lists. New slices are allocated so AoE loops
+// ranging over the old ones aren't affected by targets dying mid-loop.
+func (encounter *Encounter) updateActiveTargets() {
+	encounter.ActiveTargets = make([]*Target, 0, len(encounter.Targets))
+	encounter.ActiveTargetUnits = make([]*Unit, 0, len(encounter.Targets))
+	for _, target := range encounter.Targets {
+		if target.IsTargetable() {
+			encounter.ActiveTargets = append(encounter.ActiveTargets, target)
+			encounter.ActiveTargetUnits = append(encounter.ActiveTargetUnits, &target.Unit)
+		}
+	}
+	encounter.updateAOECapMultiplier()
+}
+
+func (encounter *Encounter) retarget(unit *Unit, target *Unit) {
+	if slices.IndexFunc(encounter.retargetedUnits, func(r retargetedUnit) bool { return r.unit == unit }) == -1 {
+		encounter.retargetedUnits = append(encounter.retargetedUnits, retargetedUnit{unit: unit, initialTarget: unit.CurrentTarget})
+	}
+	unit.CurrentTarget = target
+}
+
+// Points retargeted raid units back at the targets they started on.
+func (encounter *Encounter) restoreRetargetedUnits() {
+	for _, r := range encounter.retargetedUnits {
+		r.unit.CurrentTarget = r.initialTarget
+	}
+	encounter.retargetedUnits = encounter.retargetedUnits[:0]
 }
 
 func (encounter *Encounter) doneIteration(sim *Simulation) {
@@ -108,6 +162,13 @@ type Target struct {
 	Unit
 
 	AI TargetAI
+
+	// When the target last spawned, for tracking UnitMetrics.ActiveTime.
+	spawnedAt time.Duration
+
+	// Untargetable window state, see BecomeUntargetableUntil.
+	untargetableAction     *PendingAction
+	untargetableRetargeted []*Unit
 }
 
 func NewTarget(options *proto.Target, targetIndex int32) *Target {
@@ -119,6 +180,7 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 	target := &Target{
 		Unit: Unit{
 			Type:        EnemyUnit,
+			enabled:     true,
 			Index:       targetIndex,
 			Label:       "Target " + strconv.Itoa(int(targetIndex)+1),
 			Level:       options.Level,
@@ -152,8 +214,9 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 	target.PseudoStats.InFrontOfTarget = true
 	target.PseudoStats.TightEnemyDamage = options.TightEnemyDamage
 
-	preset := GetPresetTargetWithID(options.Id)
-	if preset != nil && preset.AI != nil {
+	if options.Script != nil {
+		target.AI = NewScriptedAI(options.Script)
+	} else if preset := GetPresetTargetWithID(options.Id); preset != nil && preset.AI != nil {
 		target.AI = preset.AI()
 	}
 
@@ -171,8 +234,65 @@ func (target *Target) init(sim *Simulation) {
 func (target *Target) Reset(sim *Simulation) {
 	target.Unit.reset(sim, nil)
 	target.SetGCDTimer(sim, 0)
-	if target.AI != nil {
-		target.AI.Reset(sim)
+	target.spawnedAt = 0
+	target.untargetableAction = nil
+	target.untargetableRetargeted = target.untargetableRetargeted[:0]
+}
+
+// Brings a despawned target into the fight. Raid units keep their current
+// targets unless those are despawned too, in which case they switch to this one.
+func (target *Target) Spawn(sim *Simulation) {
+	if target.enabled {
+		return
+	}
+
+	target.enabled = true
+	target.spawnedAt = sim.CurrentTime
+	target.healthBar.reset(sim)
+	target.Env.Encounter.updateActiveTargets()
+
+	if sim.CurrentTime >= 0 {
+		target.SetGCDTimer(sim, sim.CurrentTime)
+		target.AutoAttacks.EnableAutoSwing(sim)
+	}
+
+	for _, unit := range target.Env.Raid.AllUnits {
+		if unit.CurrentTarget != nil && unit.CurrentTarget.Type == EnemyUnit && !unit.CurrentTarget.IsTargetable() {
+			target.Env.Encounter.retarget(unit, &target.Unit)
+		}
+	}
+
+	if sim.Log != nil {
+		target.Log(sim, "Spawned")
+	}
+}
+
+// Removes a target from the fight. Its actions stop, damage against it is
+// ignored, and raid units attacking it switch to the next spawned target.
+func (target *Target) Despawn(sim *Simulation) {
+	if !target.enabled {
+		return
+	}
+
+	if target.gcdAction != nil {
+		target.CancelGCDTimer(sim)
+	}
+	target.AutoAttacks.CancelAutoSwing(sim)
+	target.Hardcast = Hardcast{}
+	target.enabled = false
+	target.Metrics.ActiveTime += sim.CurrentTime - target.spawnedAt
+	target.Env.Encounter.updateActiveTargets()
+
+	if next := target.NextTarget(); next != target {
+		for _, unit := range target.Env.Raid.AllUnits {
+			if unit.CurrentTarget == &target.Unit {
+				target.Env.Encounter.retarget(unit, &next.Unit)
+			}
+		}
+	}
+
+	if sim.Log != nil {
+		target.Log(sim, "Despawned")
 	}
 }
 
@@ -182,14 +302,26 @@ func (target *Target) Advance(sim *Simulation, elapsedTime time.Duration) {
 
 func (target *Target) doneIteration(sim *Simulation) {
 	target.Unit.doneIteration(sim)
+	if target.enabled {
+		target.Metrics.ActiveTime += sim.CurrentTime - target.spawnedAt
+	}
 }
 
+// Returns the next targetable target after this one, wrapping around. Returns
+// this target if no other target is targetable.
 func (target *Target) NextTarget() *Target {
-	nextIndex := target.Index + 1
-	if nextIndex >= target.Env.GetNumTargets() {
-		nextIndex = 0
+	numTargets := target.Env.GetNumTargets()
+	nextIndex := target.Index
+	for i := int32(1); i < numTargets; i++ {
+		nextIndex++
+		if nextIndex >= numTargets {
+			nextIndex = 0
+		}
+		if next := target.Env.GetTarget(nextIndex); next.IsTargetable() {
+			return next
+		}
 	}
-	return target.Env.GetTarget(nextIndex)
+	return target
 }
 
 func (target *Target) GetMetricsProto() *proto.UnitMetrics {
@@ -197,6 +329,7 @@ func (target *Target) GetMetricsProto() *proto.UnitMetrics {
 	metrics.Name = target.Label
 	metrics.UnitIndex = target.UnitIndex
 	metrics.Auras = target.auraTracker.GetMetricsProto()
+	metrics.AuraOverlaps = target.auraTracker.getOverlapMetricsProto()
 	return metrics
 }
 
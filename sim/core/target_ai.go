@@ -3,6 +3,7 @@ package core
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/wowsims/wotlk/sim/core/proto"
@@ -168,3 +169,22 @@ func AddPresetEncounter(name string, targetPaths []string) {
 		Targets: targetProtos,
 	})
 }
+
+// Registers a preset encounter and all of its targets from proto, e.g. a
+// scripted boss defined as protojson. Target paths must end in the target name.
+func AddPresetEncounterFromProto(encounter *proto.PresetEncounter) {
+	var targetPaths []string
+	for _, presetTarget := range encounter.Targets {
+		suffix := "/" + presetTarget.Target.Name
+		if !strings.HasSuffix(presetTarget.Path, suffix) {
+			log.Fatalf("Preset target path %s does not end with its name %s", presetTarget.Path, presetTarget.Target.Name)
+		}
+		AddPresetTarget(&PresetTarget{
+			PathPrefix: strings.TrimSuffix(presetTarget.Path, suffix),
+			Config:     presetTarget.Target,
+		})
+		targetPaths = append(targetPaths, presetTarget.Path)
+	}
+
+	AddPresetEncounter(encounter.Path[strings.LastIndex(encounter.Path, "/")+1:], targetPaths)
+}
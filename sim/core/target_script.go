@@ -0,0 +1,232 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// How often health-gated phase transitions are checked.
+const scriptedPhaseCheckPeriod = time.Second
+
+// ScriptedAI is a generic TargetAI which runs a declarative EncounterScript,
+// so bosses can be defined as protojson instead of Go code.
+type ScriptedAI struct {
+	Target *Target
+
+	script *proto.EncounterScript
+	phases [][]scriptedEvent
+
+	phaseIndex   int
+	phaseActions []*PendingAction
+}
+
+type scriptedEvent struct {
+	config *proto.EncounterEvent
+	apply  func(sim *Simulation)
+}
+
+func NewScriptedAI(script *proto.EncounterScript) *ScriptedAI {
+	return &ScriptedAI{
+		script: script,
+	}
+}
+
+func (ai *ScriptedAI) Initialize(target *Target, config *proto.Target) {
+	ai.Target = target
+
+	ai.phases = make([][]scriptedEvent, len(ai.script.Phases))
+	for phaseIdx, phaseConfig := range ai.script.Phases {
+		for eventIdx, eventConfig := range phaseConfig.Events {
+			tag := int32(phaseIdx*100 + eventIdx + 1)
+			ai.phases[phaseIdx] = append(ai.phases[phaseIdx], scriptedEvent{
+				config: eventConfig,
+				apply:  ai.makeEventFunc(eventConfig, tag),
+			})
+		}
+	}
+}
+
+func (ai *ScriptedAI) getTarget(targetIndex int32) *Target {
+	if targetIndex < 0 || targetIndex >= ai.Target.Env.GetNumTargets() {
+		panic(fmt.Sprintf("Encounter script for `%s` references invalid target index %d", ai.Target.Label, targetIndex))
+	}
+	return ai.Target.Env.GetTarget(targetIndex)
+}
+
+func (ai *ScriptedAI) makeEventFunc(config *proto.EncounterEvent, tag int32) func(*Simulation) {
+	switch event := config.Event.(type) {
+	case *proto.EncounterEvent_Cast:
+		return ai.makeCast(event.Cast, tag)
+	case *proto.EncounterEvent_RaidDamage:
+		raidDamage := ai.Target.Env.Encounter.raidDamage
+		if event.RaidDamage < 0 || int(event.RaidDamage) >= len(raidDamage) {
+			panic(fmt.Sprintf("Encounter script for `%s` references invalid raid damage index %d", ai.Target.Label, event.RaidDamage))
+		}
+		return raidDamage[event.RaidDamage].hit
+	case *proto.EncounterEvent_PlayerAura:
+		return ai.makePlayerAura(event.PlayerAura)
+	case *proto.EncounterEvent_SpawnWave:
+		addWaves := ai.Target.Env.Encounter.addWaves
+		if event.SpawnWave < 0 || int(event.SpawnWave) >= len(addWaves) {
+			panic(fmt.Sprintf("Encounter script for `%s` references invalid add wave index %d", ai.Target.Label, event.SpawnWave))
+		}
+		return addWaves[event.SpawnWave].spawn
+	case *proto.EncounterEvent_DespawnTarget:
+		target := ai.getTarget(event.DespawnTarget)
+		return target.Despawn
+	default:
+		panic(fmt.Sprintf("Encounter script event `%s` has no effect", config.Name))
+	}
+}
+
+func (ai *ScriptedAI) makeCast(config *proto.EncounterCast, tag int32) func(*Simulation) {
+	castTime := DurationFromSeconds(config.CastTime)
+	spell := ai.Target.RegisterSpell(SpellConfig{
+		ActionID:    ActionID{SpellID: config.SpellId}.WithTag(tag),
+		SpellSchool: SpellSchoolFromProto(config.School),
+		ProcMask:    ProcMaskSpellDamage,
+
+		Cast: CastConfig{
+			DefaultCast: Cast{
+				CastTime: castTime,
+			},
+		},
+
+		DamageMultiplier: 1,
+		CritMultiplier:   1,
+
+		ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+			if config.MaxDamage > 0 {
+				baseDamage := sim.Roll(config.MinDamage, MaxFloat(config.MinDamage, config.MaxDamage))
+				spell.CalcAndDealDamage(sim, target, baseDamage, spell.OutcomeAlwaysHit)
+			}
+		},
+	})
+
+	return func(sim *Simulation) {
+		target := ai.Target.CurrentTarget
+		if target == nil || ai.Target.Hardcast.Expires > sim.CurrentTime {
+			return
+		}
+		ai.Target.AutoAttacks.DelayMeleeBy(sim, castTime)
+		spell.Cast(sim, target)
+	}
+}
+
+func (ai *ScriptedAI) makePlayerAura(config *proto.EncounterPlayerAura) func(*Simulation) {
+	label := config.Label
+	if label == "" {
+		label = "Encounter Aura " + strconv.Itoa(int(config.SpellId))
+	}
+	duration := DurationFromSeconds(config.Duration)
+	if duration == 0 {
+		duration = NeverExpires
+	}
+	damageDealt := TernaryFloat64(config.DamageDealtMultiplier == 0, 1, config.DamageDealtMultiplier)
+	damageTaken := TernaryFloat64(config.DamageTakenMultiplier == 0, 1, config.DamageTakenMultiplier)
+	haste := TernaryFloat64(config.HasteMultiplier == 0, 1, config.HasteMultiplier)
+
+	auras := make([]*Aura, 0, len(ai.Target.Env.Raid.AllUnits))
+	for _, unit := range ai.Target.Env.Raid.AllUnits {
+		auras = append(auras, unit.GetOrRegisterAura(Aura{
+			Label:    label,
+			ActionID: ActionID{SpellID: config.SpellId},
+			Duration: duration,
+			OnGain: func(aura *Aura, sim *Simulation) {
+				aura.Unit.PseudoStats.DamageDealtMultiplier *= damageDealt
+				aura.Unit.PseudoStats.DamageTakenMultiplier *= damageTaken
+				aura.Unit.MultiplyAttackSpeed(sim, haste)
+				aura.Unit.MultiplyCastSpeed(haste)
+			},
+			OnExpire: func(aura *Aura, sim *Simulation) {
+				aura.Unit.PseudoStats.DamageDealtMultiplier /= damageDealt
+				aura.Unit.PseudoStats.DamageTakenMultiplier /= damageTaken
+				aura.Unit.MultiplyAttackSpeed(sim, 1/haste)
+				aura.Unit.MultiplyCastSpeed(1 / haste)
+			},
+		}))
+	}
+
+	return func(sim *Simulation) {
+		for _, aura := range auras {
+			if aura.Unit.IsEnabled() {
+				aura.Activate(sim)
+			}
+		}
+	}
+}
+
+func (ai *ScriptedAI) Reset(sim *Simulation) {
+	ai.phaseActions = ai.phaseActions[:0]
+	if len(ai.phases) > 0 {
+		ai.startPhase(sim, 0)
+	}
+}
+
+func (ai *ScriptedAI) startPhase(sim *Simulation, phaseIndex int) {
+	for _, pa := range ai.phaseActions {
+		pa.Cancel(sim)
+	}
+	ai.phaseActions = ai.phaseActions[:0]
+	ai.phaseIndex = phaseIndex
+
+	if sim.Log != nil {
+		ai.Target.Log(sim, "Starting encounter phase %d (%s)", phaseIndex+1, ai.script.Phases[phaseIndex].Name)
+	}
+
+	for _, event := range ai.phases[phaseIndex] {
+		event := event
+		interval := DurationFromSeconds(event.config.Interval)
+		pa := &PendingAction{
+			NextActionAt: sim.CurrentTime + DurationFromSeconds(event.config.Time),
+		}
+		pa.OnAction = func(sim *Simulation) {
+			if !ai.Target.IsEnabled() {
+				return
+			}
+			if sim.Log != nil {
+				ai.Target.Log(sim, "Encounter event: %s", event.config.Name)
+			}
+			event.apply(sim)
+			if interval > 0 {
+				pa.NextActionAt = sim.CurrentTime + interval
+				sim.AddPendingAction(pa)
+			}
+		}
+		sim.AddPendingAction(pa)
+		ai.phaseActions = append(ai.phaseActions, pa)
+	}
+
+	if phaseIndex+1 >= len(ai.phases) {
+		return
+	}
+	nextPhase := ai.script.Phases[phaseIndex+1]
+
+	if nextPhase.StartTime > 0 {
+		ai.phaseActions = append(ai.phaseActions, StartDelayedAction(sim, DelayedActionOptions{
+			DoAt: MaxDuration(sim.CurrentTime, DurationFromSeconds(nextPhase.StartTime)),
+			OnAction: func(sim *Simulation) {
+				ai.startPhase(sim, phaseIndex+1)
+			},
+		}))
+	}
+
+	if nextPhase.StartHealthPercent > 0 {
+		ai.phaseActions = append(ai.phaseActions, StartPeriodicAction(sim, PeriodicActionOptions{
+			Period: scriptedPhaseCheckPeriod,
+			OnAction: func(sim *Simulation) {
+				if ai.Target.estimatedHealthPercent(sim)*100 <= nextPhase.StartHealthPercent {
+					ai.startPhase(sim, phaseIndex+1)
+				}
+			},
+		}))
+	}
+}
+
+// Scripted targets act through pending actions rather than the GCD loop.
+func (ai *ScriptedAI) DoAction(sim *Simulation) {
+	ai.Target.DoNothing()
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+func TestScriptedEncounter(t *testing.T) {
+	boss := googleProto.Clone(NewDefaultTarget()).(*proto.Target)
+	boss.Script = &proto.EncounterScript{
+		Phases: []*proto.EncounterPhase{
+			{
+				Name: "Phase 1",
+				Events: []*proto.EncounterEvent{{
+					Name:     "Shadow Pulse",
+					Time:     10,
+					Interval: 10,
+					Event:    &proto.EncounterEvent_RaidDamage{RaidDamage: 0},
+				}},
+			},
+			{
+				Name:      "Phase 2",
+				StartTime: 100,
+				Events: []*proto.EncounterEvent{
+					{Name: "Add", Event: &proto.EncounterEvent_SpawnWave{SpawnWave: 0}},
+					{Name: "Boss leaves", Event: &proto.EncounterEvent_DespawnTarget{DespawnTarget: 0}},
+				},
+			},
+		},
+	}
+	request := fakeCasterRequest(&proto.SimOptions{Iterations: 5, RandomSeed: 101})
+	request.Encounter.Targets = []*proto.Target{boss, NewDefaultTarget()}
+	// Without an interval, the pulses only come from the script.
+	request.Encounter.RaidDamage = []*proto.RaidDamage{{
+		Name:    "Shadow Pulse",
+		SpellId: 12345,
+		School:  proto.SpellSchool_SpellSchoolShadow,
+		Damage:  1000,
+	}}
+	request.Encounter.AddWaves = []*proto.AddWave{{
+		Name:          "Add",
+		TargetIndices: []int32{1},
+		Scripted:      true,
+	}}
+
+	result := RunRaidSim(request)
+	if result.ErrorResult != "" {
+		t.Fatalf("Sim failed: %s", result.ErrorResult)
+	}
+
+	// 9 pulses before the boss despawns, hitting both the caster and the pet.
+	bossMetrics := result.EncounterMetrics.Targets[0]
+	if expected := 9 * 2 * 1000.0 / 300; math.Abs(bossMetrics.Dps.Avg-expected) > 1e-6 {
+		t.Fatalf("Expected boss dps of %f, got %f", expected, bossMetrics.Dps.Avg)
+	}
+
+	// The pet attacks the add once the boss despawns.
+	damageByTarget := make(map[int32]float64)
+	for _, action := range result.RaidMetrics.Parties[0].Players[0].Pets[0].Actions {
+		for _, target := range action.Targets {
+			damageByTarget[target.UnitIndex] += target.Damage
+		}
+	}
+	if damageByTarget[0] == 0 || damageByTarget[1] == 0 {
+		t.Fatalf("Expected damage on both targets, got %v", damageByTarget)
+	}
+}
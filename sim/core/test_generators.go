@@ -84,6 +84,10 @@ type TalentsCombo struct {
 type SpecOptionsCombo struct {
 	Label       string
 	SpecOptions interface{}
+
+	// If set, replaces any enabled APL rotation for these options. Used when the
+	// options pick a different legacy rotation, e.g. an AoE one.
+	Rotation *proto.APLRotation
 }
 type RotationCombo struct {
 	Label    string
@@ -143,11 +147,20 @@ func (combos *SettingsCombos) GetTest(testIdx int) (string, *proto.ComputeStatsR
 	specOptionsCombo := combos.SpecOptions[specOptionsIdx]
 	testNameParts = append(testNameParts, specOptionsCombo.Label)
 
-	if len(combos.Rotations) > 1 {
+	var rotationsCombo RotationCombo
+	if len(combos.Rotations) > 0 {
 		rotationsIdx := testIdx % len(combos.Rotations)
 		testIdx /= len(combos.Rotations)
-		rotationsCombo := combos.Rotations[rotationsIdx]
-		testNameParts = append(testNameParts, rotationsCombo.Label)
+		rotationsCombo = combos.Rotations[rotationsIdx]
+		// Unlabeled rotations keep the names from before rotations were added.
+		if len(combos.Rotations) > 1 && rotationsCombo.Label != "" {
+			testNameParts = append(testNameParts, rotationsCombo.Label)
+		}
+	}
+
+	rotation := rotationsCombo.Rotation
+	if specOptionsCombo.Rotation != nil && rotation.GetEnabled() {
+		rotation = specOptionsCombo.Rotation
 	}
 
 	buffsIdx := testIdx % len(combos.Buffs)
@@ -171,6 +184,7 @@ func (combos *SettingsCombos) GetTest(testIdx int) (string, *proto.ComputeStatsR
 				Buffs:         buffsCombo.Player,
 				Profession1:   proto.Profession_Engineering,
 				Cooldowns:     combos.Cooldowns,
+				Rotation:      rotation,
 			}, specOptionsCombo.SpecOptions),
 			buffsCombo.Party,
 			buffsCombo.Raid,
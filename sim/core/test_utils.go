@@ -2,6 +2,8 @@ package core
 
 import (
 	"log"
+	"os"
+	"path"
 	"testing"
 
 	"github.com/wowsims/wotlk/sim/core/proto"
@@ -111,6 +113,22 @@ var FullDebuffs = &proto.Debuffs{
 	Vindication:        true,
 }
 
+// Loads one of the default APL rotations shipped with the UI, which are stored
+// in the APL text format under <dir>/apls/.
+func GetAplRotation(dir string, file string) RotationCombo {
+	filePath := path.Join(dir, "apls", file+".apl")
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		log.Fatalf("failed to load apl file: %s, %s", filePath, err)
+	}
+
+	rotation, err := APLRotationFromTextString(string(data))
+	if err != nil {
+		log.Fatalf("failed to parse apl file: %s, %s", filePath, err)
+	}
+	return RotationCombo{Label: "APL", Rotation: rotation}
+}
+
 func NewDefaultTarget() *proto.Target {
 	return DefaultTargetProto // seems to be read-only
 }
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"time"
+)
+
+// Sums per fixed width time bucket, accumulated over all iterations. Used for
+// metrics over fight time, see SimOptions.timeline_bucket_seconds.
+type timeline []float64
+
+// Adds the number of seconds of [start, end) which fall within each bucket.
+func (tl *timeline) addInterval(bucketWidth time.Duration, start time.Duration, end time.Duration) {
+	tl.addLevel(bucketWidth, start, end, 1)
+}
+
+// Adds level times the number of seconds of [start, end) which fall within
+// each bucket, for values which are held over time such as resource levels.
+func (tl *timeline) addLevel(bucketWidth time.Duration, start time.Duration, end time.Duration, level float64) {
+	start = MaxDuration(start, 0)
+	for start < end {
+		bucket := int(start / bucketWidth)
+		segmentEnd := MinDuration(end, time.Duration(bucket+1)*bucketWidth)
+		tl.grow(bucket)
+		(*tl)[bucket] += (segmentEnd - start).Seconds() * level
+		start = segmentEnd
+	}
+}
+
+// Adds value to the bucket containing at. Buckets include their end rather
+// than their start, so that values at the very end of the fight are counted.
+// Values before the pull are added to the first bucket.
+func (tl *timeline) add(bucketWidth time.Duration, at time.Duration, value float64) {
+	bucket := MaxInt(int((at+bucketWidth-1)/bucketWidth)-1, 0)
+	tl.grow(bucket)
+	(*tl)[bucket] += value
+}
+
+func (tl *timeline) grow(bucket int) {
+	for len(*tl) <= bucket {
+		*tl = append(*tl, 0)
+	}
+}
+
+func (tl *timeline) merge(other timeline) {
+	if len(other) > 0 {
+		tl.grow(len(other) - 1)
+	}
+	for i, value := range other {
+		(*tl)[i] += value
+	}
+}
+
+// Returns the sum of each bucket divided by the corresponding exposure, which
+// is the total number of fight seconds in that bucket over all iterations.
+func (tl timeline) averages(exposure timeline) []float64 {
+	averages := make([]float64, len(exposure))
+	for i := range averages {
+		if i < len(tl) && exposure[i] > 0 {
+			averages[i] = tl[i] / exposure[i]
+		}
+	}
+	return averages
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func TestAuraTimelinesAndOverlaps(t *testing.T) {
+	empower := ActionID{SpellID: 3}.ToProto()
+	stance := ActionID{SpellID: 4}.ToProto()
+	unknown := ActionID{SpellID: 99}.ToProto()
+
+	result := RunRaidSim(fakeCasterRequest(&proto.SimOptions{
+		Iterations:            20,
+		RandomSeed:            101,
+		TimelineBucketSeconds: 5,
+		AuraOverlaps: []*proto.AuraPair{
+			{First: empower, Second: stance},
+			{First: empower, Second: unknown},
+		},
+	}))
+	if result.ErrorResult != "" {
+		t.Fatalf("Sim failed: %s", result.ErrorResult)
+	}
+	player := result.RaidMetrics.Parties[0].Players[0]
+
+	var empowerMetrics *proto.AuraMetrics
+	for _, aura := range player.Auras {
+		if aura.Id.GetSpellId() == empower.GetSpellId() {
+			empowerMetrics = aura
+		}
+	}
+	if empowerMetrics == nil || empowerMetrics.UptimeSecondsAvg == 0 {
+		t.Fatalf("Expected Empower to be used")
+	}
+	if len(empowerMetrics.UptimeTimeline) != 60 {
+		t.Fatalf("Expected 60 timeline buckets, got %d", len(empowerMetrics.UptimeTimeline))
+	}
+	timelineUptime := 0.0
+	for _, fraction := range empowerMetrics.UptimeTimeline {
+		timelineUptime += fraction * 5
+	}
+	if math.Abs(timelineUptime-empowerMetrics.UptimeSecondsAvg) > 1e-6 {
+		t.Fatalf("Expected the timeline to add up to an uptime of %f, got %f", empowerMetrics.UptimeSecondsAvg, timelineUptime)
+	}
+
+	// The stance is always active, and the unknown aura is never registered.
+	if len(player.AuraOverlaps) != 1 {
+		t.Fatalf("Expected 1 aura overlap, got %d", len(player.AuraOverlaps))
+	}
+	if overlap := player.AuraOverlaps[0]; math.Abs(overlap.UptimeSecondsAvg-empowerMetrics.UptimeSecondsAvg) > 1e-6 {
+		t.Fatalf("Expected an overlap uptime of %f, got %f", empowerMetrics.UptimeSecondsAvg, overlap.UptimeSecondsAvg)
+	}
+}
@@ -60,6 +60,9 @@ type Unit struct {
 	// Whether this unit is able to perform actions.
 	enabled bool
 
+	// Whether this unit can't be attacked, see Target.BecomeUntargetableUntil.
+	untargetable bool
+
 	// Stats this Unit will have at the very start of each Sim iteration.
 	// Includes all equipment / buffs / permanent effects but not temporary
 	// effects from items / abilities.
@@ -137,6 +140,11 @@ type Unit struct {
 	waitingForMana   float64
 	waitStartTime    time.Duration
 
+	// Movement state, see MoveUntil.
+	moving         bool
+	movementStart  time.Duration
+	movementAction *PendingAction
+
 	// Cached mana return values per tick.
 	manaTickWhileCasting    float64
 	manaTickWhileNotCasting float64
@@ -149,7 +157,7 @@ type Unit struct {
 
 // Units can be disabled for several reasons:
 //  1. Downtime for temporary pets (e.g. Water Elemental)
-//  2. Enemy units in various phases (not yet implemented)
+//  2. Enemy units which are despawned, see Target.Despawn
 //  3. Dead units (not yet implemented)
 func (unit *Unit) IsEnabled() bool {
 	return unit.enabled
@@ -163,6 +171,13 @@ func (unit *Unit) DoNothing() {
 	unit.doNothing = true
 }
 
+// Returns whether this unit is controlled by an APL rotation instead of its
+// agent's OnGCDReady, in which case agents should skip any rotation logic
+// they run from other callbacks.
+func (unit *Unit) IsUsingAPL() bool {
+	return unit.Rotation != nil
+}
+
 func (unit *Unit) IsActive() bool {
 	return unit.IsEnabled() && unit.CurrentHealthPercent() > 0
 }
@@ -423,8 +438,11 @@ func (unit *Unit) init(sim *Simulation) {
 
 func (unit *Unit) reset(sim *Simulation, agent Agent) {
 	unit.enabled = true
+	unit.untargetable = false
 	unit.resetCDs(sim)
 	unit.Hardcast.Expires = startingCDTime
+	unit.moving = false
+	unit.movementAction = nil
 	unit.Metrics.reset()
 	unit.ResetStatDeps()
 	unit.statsWithoutDeps = unit.initialStatsWithoutDeps
@@ -444,6 +462,7 @@ func (unit *Unit) reset(sim *Simulation, agent Agent) {
 	unit.energyBar.reset(sim)
 	unit.rageBar.reset(sim)
 	unit.RunicPowerBar.reset(sim)
+	unit.Metrics.timelines.reset(sim, unit)
 
 	unit.AutoAttacks.reset(sim)
 }
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// Damage, threat, healing and resource levels of a unit over fight time, see
+// SimOptions.timeline_bucket_seconds.
+type unitTimelines struct {
+	// 0 when timelines are disabled.
+	bucketWidth time.Duration
+
+	// Fight seconds per bucket, over all iterations.
+	exposure timeline
+
+	damage  timeline
+	threat  timeline
+	healing timeline
+
+	resources []*resourceTimeline
+}
+
+type resourceTimeline struct {
+	resourceType proto.ResourceType
+
+	// Level times seconds per bucket, over all iterations.
+	levels timeline
+
+	// Level since lastChange, for the current iteration.
+	level      float64
+	lastChange time.Duration
+}
+
+var timelineResourceTypes = []proto.ResourceType{
+	proto.ResourceType_ResourceTypeHealth,
+	proto.ResourceType_ResourceTypeMana,
+	proto.ResourceType_ResourceTypeRage,
+	proto.ResourceType_ResourceTypeEnergy,
+	proto.ResourceType_ResourceTypeRunicPower,
+	proto.ResourceType_ResourceTypeBloodRune,
+	proto.ResourceType_ResourceTypeFrostRune,
+	proto.ResourceType_ResourceTypeUnholyRune,
+	proto.ResourceType_ResourceTypeDeathRune,
+}
+
+func (unit *Unit) hasResource(resourceType proto.ResourceType) bool {
+	switch resourceType {
+	case proto.ResourceType_ResourceTypeHealth:
+		return unit.HasHealthBar()
+	case proto.ResourceType_ResourceTypeMana:
+		return unit.HasManaBar()
+	case proto.ResourceType_ResourceTypeRage:
+		return unit.HasRageBar()
+	case proto.ResourceType_ResourceTypeEnergy:
+		return unit.HasEnergyBar()
+	}
+	// Runic power and runes.
+	return unit.HasRunicPowerBar()
+}
+
+func (unit *Unit) resourceLevel(resourceType proto.ResourceType) float64 {
+	switch resourceType {
+	case proto.ResourceType_ResourceTypeHealth:
+		return unit.CurrentHealth()
+	case proto.ResourceType_ResourceTypeMana:
+		return unit.CurrentMana()
+	case proto.ResourceType_ResourceTypeRage:
+		return unit.CurrentRage()
+	case proto.ResourceType_ResourceTypeEnergy:
+		return unit.CurrentEnergy()
+	case proto.ResourceType_ResourceTypeRunicPower:
+		return unit.CurrentRunicPower()
+	}
+	return float64(unit.currentRunesOfType(resourceType))
+}
+
+// Should be called at the start of each iteration, after resetting the
+// resource bars.
+func (tls *unitTimelines) reset(sim *Simulation, unit *Unit) {
+	tls.bucketWidth = DurationFromSeconds(sim.Options.TimelineBucketSeconds)
+	if tls.bucketWidth <= 0 {
+		return
+	}
+
+	if tls.resources == nil {
+		tls.resources = []*resourceTimeline{}
+		for _, resourceType := range timelineResourceTypes {
+			if unit.hasResource(resourceType) {
+				tls.resources = append(tls.resources, &resourceTimeline{resourceType: resourceType})
+			}
+		}
+	}
+	for _, rt := range tls.resources {
+		rt.level = unit.resourceLevel(rt.resourceType)
+		rt.lastChange = sim.CurrentTime
+	}
+}
+
+func (tls *unitTimelines) addDamage(sim *Simulation, damage float64, threat float64) {
+	if tls.bucketWidth > 0 {
+		tls.damage.add(tls.bucketWidth, sim.CurrentTime, damage)
+		tls.threat.add(tls.bucketWidth, sim.CurrentTime, threat)
+	}
+}
+
+func (tls *unitTimelines) addHealing(sim *Simulation, healing float64) {
+	if tls.bucketWidth > 0 {
+		tls.healing.add(tls.bucketWidth, sim.CurrentTime, healing)
+	}
+}
+
+// Should be called after each change of a resource level.
+func (tls *unitTimelines) setResourceLevel(sim *Simulation, resourceType proto.ResourceType, level float64) {
+	if tls.bucketWidth <= 0 {
+		return
+	}
+	for _, rt := range tls.resources {
+		if rt.resourceType == resourceType {
+			rt.levels.addLevel(tls.bucketWidth, rt.lastChange, sim.CurrentTime, rt.level)
+			rt.level = level
+			rt.lastChange = sim.CurrentTime
+			return
+		}
+	}
+}
+
+func (tls *unitTimelines) doneIteration(sim *Simulation) {
+	if tls.bucketWidth <= 0 {
+		return
+	}
+	tls.exposure.addInterval(tls.bucketWidth, 0, sim.CurrentTime)
+	for _, rt := range tls.resources {
+		rt.levels.addLevel(tls.bucketWidth, rt.lastChange, sim.CurrentTime, rt.level)
+	}
+}
+
+func (tls *unitTimelines) merge(other *unitTimelines) {
+	tls.exposure.merge(other.exposure)
+	tls.damage.merge(other.damage)
+	tls.threat.merge(other.threat)
+	tls.healing.merge(other.healing)
+
+	for _, otherResource := range other.resources {
+		var resource *resourceTimeline
+		for _, rt := range tls.resources {
+			if rt.resourceType == otherResource.resourceType {
+				resource = rt
+				break
+			}
+		}
+		if resource == nil {
+			resource = &resourceTimeline{resourceType: otherResource.resourceType}
+			tls.resources = append(tls.resources, resource)
+		}
+		resource.levels.merge(otherResource.levels)
+	}
+}
+
+func (tls *unitTimelines) applyToProto(protoMetrics *proto.UnitMetrics) {
+	if len(tls.exposure) == 0 {
+		return
+	}
+	protoMetrics.DpsTimeline = tls.damage.averages(tls.exposure)
+	protoMetrics.ThreatTimeline = tls.threat.averages(tls.exposure)
+	protoMetrics.HpsTimeline = tls.healing.averages(tls.exposure)
+	for _, rt := range tls.resources {
+		protoMetrics.ResourceTimelines = append(protoMetrics.ResourceTimelines, &proto.ResourceTimeline{
+			Type:   rt.resourceType,
+			Levels: rt.levels.averages(tls.exposure),
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func TestUnitTimelines(t *testing.T) {
+	result := RunRaidSim(fakeCasterRequest(&proto.SimOptions{
+		Iterations:            20,
+		RandomSeed:            101,
+		Concurrency:           4,
+		TimelineBucketSeconds: 10,
+	}))
+	if result.ErrorResult != "" {
+		t.Fatalf("Sim failed: %s", result.ErrorResult)
+	}
+	player := result.RaidMetrics.Parties[0].Players[0]
+
+	if len(player.DpsTimeline) != 30 {
+		t.Fatalf("Expected 30 timeline buckets, got %d", len(player.DpsTimeline))
+	}
+	// The encounter has a fixed duration, so the timeline adds up to the total
+	// damage, including the pet.
+	timelineDamage := 0.0
+	for _, dps := range player.DpsTimeline {
+		timelineDamage += dps * 10
+	}
+	if totalDamage := player.Dps.Avg * 300; math.Abs(timelineDamage-totalDamage) > 1e-6*totalDamage {
+		t.Fatalf("Expected the timeline to add up to %f damage, got %f", totalDamage, timelineDamage)
+	}
+	if player.Pets[0].DpsTimeline[0] == 0 {
+		t.Fatalf("Expected a pet dps timeline")
+	}
+
+	var manaLevels []float64
+	for _, resourceTimeline := range player.ResourceTimelines {
+		if resourceTimeline.Type == proto.ResourceType_ResourceTypeMana {
+			manaLevels = resourceTimeline.Levels
+		}
+	}
+	if len(manaLevels) != 30 {
+		t.Fatalf("Expected 30 mana timeline buckets, got %d", len(manaLevels))
+	}
+	for i, mana := range manaLevels {
+		if mana <= 0 {
+			t.Fatalf("Unexpected mana level %f in bucket %d", mana, i)
+		}
+	}
+	// The fight starts at full mana.
+	if manaLevels[0] <= manaLevels[1] {
+		t.Fatalf("Expected mana to drop after the first bucket, got %f and %f", manaLevels[0], manaLevels[1])
+	}
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+// An encounter window during which a target can't be attacked.
+type untargetableWindow struct {
+	start    time.Duration
+	duration time.Duration
+	interval time.Duration
+
+	target *Target
+}
+
+func newUntargetableWindow(config *proto.UntargetableWindow, encounter *Encounter) untargetableWindow {
+	if config.TargetIndex < 0 || int(config.TargetIndex) >= len(encounter.Targets) {
+		panic(fmt.Sprintf("Untargetable window references invalid target index %d", config.TargetIndex))
+	}
+	return untargetableWindow{
+		start:    DurationFromSeconds(config.Start),
+		duration: DurationFromSeconds(config.Duration),
+		interval: DurationFromSeconds(config.Interval),
+		target:   encounter.Targets[config.TargetIndex],
+	}
+}
+
+func (encounter *Encounter) scheduleUntargetableWindows(sim *Simulation) {
+	for _, window := range encounter.untargetableWindows {
+		window := window
+		if window.duration <= 0 {
+			continue
+		}
+
+		pa := &PendingAction{
+			NextActionAt: window.start,
+		}
+		pa.OnAction = func(sim *Simulation) {
+			window.target.BecomeUntargetableUntil(sim, sim.CurrentTime+window.duration)
+			if window.interval > 0 {
+				pa.NextActionAt = sim.CurrentTime + window.interval
+				sim.AddPendingAction(pa)
+			}
+		}
+		sim.AddPendingAction(pa)
+	}
+}
+
+// Returns when the given unit becomes targetable again, or 0 if it isn't an
+// encounter target in an untargetable window.
+func (encounter *Encounter) targetableAt(unit *Unit) time.Duration {
+	if unit == nil || !unit.untargetable {
+		return 0
+	}
+	for _, target := range encounter.Targets {
+		if &target.Unit == unit {
+			return target.untargetableAction.NextActionAt
+		}
+	}
+	return 0
+}
+
+// Whether this unit can be attacked, i.e. it is spawned and not in an
+// untargetable window.
+func (unit *Unit) IsTargetable() bool {
+	return unit.enabled && !unit.untargetable
+}
+
+// Makes the target untargetable until the given time, extending any window
+// already in progress. Raid units attacking it switch to the next valid
+// target, and switch back once the window ends.
+func (target *Target) BecomeUntargetableUntil(sim *Simulation, until time.Duration) {
+	if until <= sim.CurrentTime {
+		return
+	}
+
+	if target.untargetable {
+		if until <= target.untargetableAction.NextActionAt {
+			return
+		}
+		target.untargetableAction.Cancel(sim)
+	} else {
+		target.untargetable = true
+		target.Env.Encounter.updateActiveTargets()
+		if sim.Log != nil {
+			target.Log(sim, "Untargetable until %s", until)
+		}
+
+		if next := target.NextTarget(); next != target {
+			for _, unit := range target.Env.Raid.AllUnits {
+				if unit.CurrentTarget == &target.Unit {
+					target.Env.Encounter.retarget(unit, &next.Unit)
+					target.untargetableRetargeted = append(target.untargetableRetargeted, unit)
+				}
+			}
+		}
+	}
+
+	target.untargetableAction = StartDelayedAction(sim, DelayedActionOptions{
+		DoAt:     until,
+		OnAction: target.becomeTargetable,
+	})
+}
+
+func (target *Target) becomeTargetable(sim *Simulation) {
+	target.untargetable = false
+	target.Env.Encounter.updateActiveTargets()
+	if sim.Log != nil {
+		target.Log(sim, "Targetable")
+	}
+
+	if target.IsEnabled() {
+		for _, unit := range target.untargetableRetargeted {
+			unit.CurrentTarget = &target.Unit
+		}
+	}
+	target.untargetableRetargeted = target.untargetableRetargeted[:0]
+}
+
+// Whether this spell is cast on an opponent which can't be targeted right now.
+// Self buffs, which are cast on the current target by default but don't set a
+// proc mask, can still be used.
+func (spell *Spell) targetIsUntargetable(target *Unit) bool {
+	return target != nil &&
+		target.untargetable &&
+		spell.ProcMask != ProcMaskUnknown &&
+		!spell.Flags.Matches(SpellFlagHelpful) &&
+		spell.Unit.IsOpponent(target)
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wowsims/wotlk/sim/core/proto"
+)
+
+func TestUntargetableWindows(t *testing.T) {
+	request := fakeCasterRequest(&proto.SimOptions{Iterations: 5, RandomSeed: 101})
+	request.Encounter.Targets = []*proto.Target{NewDefaultTarget(), NewDefaultTarget()}
+	request.Encounter.UntargetableWindows = []*proto.UntargetableWindow{{
+		TargetIndex: 0,
+		Start:       100,
+		Duration:    50,
+	}}
+
+	result := RunRaidSim(request)
+	if result.ErrorResult != "" {
+		t.Fatalf("Sim failed: %s", result.ErrorResult)
+	}
+
+	// Dots already on the boss keep ticking, but the damage is lost.
+	player := result.RaidMetrics.Parties[0].Players[0]
+	pet := player.Pets[0]
+	if player.DamageLostAvg <= 0 {
+		t.Fatalf("Expected dot damage to be lost, got %f", player.DamageLostAvg)
+	}
+	boss := result.EncounterMetrics.Targets[0]
+	if expected := player.DamageLostAvg + pet.DamageLostAvg; math.Abs(boss.DamageLostAvg-expected) > 1e-6 {
+		t.Fatalf("Expected the boss to avoid %f damage, got %f", expected, boss.DamageLostAvg)
+	}
+
+	// The pet switches to the other target for the duration of the window.
+	damageByTarget := make(map[int32]float64)
+	for _, action := range pet.Actions {
+		for _, target := range action.Targets {
+			damageByTarget[target.UnitIndex] += target.Damage
+		}
+	}
+	if damageByTarget[0] == 0 || damageByTarget[1] == 0 {
+		t.Fatalf("Expected damage on both targets, got %v", damageByTarget)
+	}
+}
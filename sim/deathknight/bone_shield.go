@@ -52,7 +52,7 @@ func (dk *Deathknight) registerBoneShieldSpell() {
 
 	dk.BoneShield = dk.RegisterSpell(core.SpellConfig{
 		ActionID: actionID,
-		Flags:    core.SpellFlagNoOnCastComplete,
+		Flags:    core.SpellFlagNoOnCastComplete | core.SpellFlagAPL,
 
 		RuneCost: core.RuneCostOptions{
 			UnholyRuneCost: 1,
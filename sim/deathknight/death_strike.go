@@ -22,7 +22,7 @@ func (dk *Deathknight) newDeathStrikeSpell(isMH bool) *core.Spell {
 		ActionID:    DeathStrikeActionID.WithTag(core.TernaryInt32(isMH, 1, 2)),
 		SpellSchool: core.SpellSchoolPhysical,
 		ProcMask:    dk.threatOfThassarianProcMask(isMH),
-		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage,
+		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage | core.SpellFlagAPL,
 
 		RuneCost: core.RuneCostOptions{
 			FrostRuneCost:  1,
@@ -84,6 +84,7 @@ func (dk *Deathknight) newDeathStrikeSpell(isMH bool) *core.Spell {
 	if !isMH {
 		conf.RuneCost = core.RuneCostOptions{}
 		conf.Cast = core.CastConfig{}
+		conf.Flags &^= core.SpellFlagAPL
 	}
 
 	return dk.RegisterSpell(conf)
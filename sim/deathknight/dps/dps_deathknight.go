@@ -524,7 +524,10 @@ func (dk *DpsDeathknight) Reset(sim *core.Simulation) {
 	dk.fr.Reset(sim)
 	dk.ur.Reset(sim)
 
-	dk.SetupRotations()
+	// The legacy openers take control of cooldowns, which APL handles itself.
+	if !dk.IsUsingAPL() {
+		dk.SetupRotations()
+	}
 
 	dk.Presence = deathknight.UnsetPresence
 
@@ -24,6 +24,10 @@ func TestBlood(t *testing.T) {
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsBlood},
 
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/deathknight", "blood"),
+		},
+
 		ItemFilter: core.ItemFilter{
 			ArmorType: proto.ArmorType_ArmorTypePlate,
 
@@ -51,6 +55,10 @@ func TestUnholy(t *testing.T) {
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsUnholy},
 
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/deathknight", "unholy"),
+		},
+
 		ItemFilter: core.ItemFilter{
 			ArmorType: proto.ArmorType_ArmorTypePlate,
 
@@ -78,7 +86,11 @@ func TestFrost(t *testing.T) {
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsFrost},
 		OtherSpecOptions: []core.SpecOptionsCombo{
-			{Label: "Desync", SpecOptions: PlayerOptionsDesyncFrost},
+			{Label: "Desync", SpecOptions: PlayerOptionsDesyncFrost, Rotation: core.GetAplRotation("../../../ui/deathknight", "desync").Rotation},
+		},
+
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/deathknight", "frost"),
 		},
 
 		ItemFilter: core.ItemFilter{
@@ -108,6 +120,10 @@ func TestFrostUH(t *testing.T) {
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsFrost},
 
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/deathknight", "frost_unholy"),
+		},
+
 		ItemFilter: core.ItemFilter{
 			ArmorType: proto.ArmorType_ArmorTypePlate,
 
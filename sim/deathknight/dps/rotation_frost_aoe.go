@@ -65,10 +65,9 @@ func (dk *DpsDeathknight) makeCustomRotation() *common.CustomRotation {
 					return true
 				}
 
-				numHits := dk.Env.GetNumTargets()
+				numHits := len(sim.Encounter.ActiveTargetUnits)
 				numDiseased := numHits
-				for i := int32(0); i < numHits; i++ {
-					target := &dk.Env.GetTarget(i).Unit
+				for _, target := range sim.Encounter.ActiveTargetUnits {
 					diseases := dk.FrostFeverSpell.Dot(target).IsActive() && dk.BloodPlagueSpell.Dot(target).IsActive()
 
 					if !diseases {
@@ -13,6 +13,7 @@ func (dk *Deathknight) registerGhoulFrenzySpell() {
 
 	dk.GhoulFrenzy = dk.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 63560},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskSpellHealing,
 
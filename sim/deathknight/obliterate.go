@@ -18,7 +18,7 @@ func (dk *Deathknight) newObliterateHitSpell(isMH bool) *core.Spell {
 		ActionID:    ObliterateActionID.WithTag(core.TernaryInt32(isMH, 1, 2)),
 		SpellSchool: core.SpellSchoolPhysical,
 		ProcMask:    dk.threatOfThassarianProcMask(isMH),
-		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage,
+		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage | core.SpellFlagAPL,
 
 		RuneCost: core.RuneCostOptions{
 			FrostRuneCost:  1,
@@ -83,6 +83,7 @@ func (dk *Deathknight) newObliterateHitSpell(isMH bool) *core.Spell {
 	if !isMH {
 		conf.RuneCost = core.RuneCostOptions{}
 		conf.Cast = core.CastConfig{}
+		conf.Flags &^= core.SpellFlagAPL
 	}
 
 	return dk.RegisterSpell(conf)
@@ -13,6 +13,7 @@ func (dk *Deathknight) registerPestilenceSpell() {
 
 	dk.Pestilence = dk.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 50842},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskSpellDamage,
 
@@ -31,7 +32,7 @@ func (dk *Deathknight) registerPestilenceSpell() {
 		ThreatMultiplier: 0,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				// Zero damage spell with a Hit mechanic, thanks blizz!
 				result := spell.CalcAndDealDamage(sim, aoeTarget, 0, spell.OutcomeMagicHit)
 
@@ -87,8 +88,8 @@ func (dk *Deathknight) registerDrwPestilenceSpell() {
 			// DRW and Pestilence have a weird interaction where the drws Dots can be applied
 			// with the spread effect from pestilence if the target has the Dks dots up but it
 			// only works if there is a valid target for spread mechanic to happen (2+ mobs)
-			shouldApplyDrwDots := dk.Env.GetNumTargets() > 1 || dk.Inputs.DrwPestiApply
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			shouldApplyDrwDots := len(sim.Encounter.ActiveTargetUnits) > 1 || dk.Inputs.DrwPestiApply
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				// Zero damage spell with a Hit mechanic, thanks blizz!
 				result := spell.CalcAndDealDamage(sim, aoeTarget, 0, spell.OutcomeMagicHit)
 
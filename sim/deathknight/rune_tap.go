@@ -27,7 +27,7 @@ func (dk *Deathknight) registerRuneTapSpell() {
 
 	dk.RuneTap = dk.RegisterSpell(core.SpellConfig{
 		ActionID: actionID,
-		Flags:    core.SpellFlagNoOnCastComplete,
+		Flags:    core.SpellFlagNoOnCastComplete | core.SpellFlagAPL,
 
 		RuneCost: core.RuneCostOptions{
 			BloodRuneCost: 1,
@@ -23,6 +23,9 @@ func TestBloodTank(t *testing.T) {
 		Glyphs:      Glyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsBloodTank},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/tank_deathknight", "blood"),
+		},
 
 		IsTank:          true,
 		InFrontOfTarget: true,
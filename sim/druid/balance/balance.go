@@ -90,7 +90,8 @@ func (moonkin *BalanceDruid) Reset(sim *core.Simulation) {
 		moonkin.Rotation.PlayerLatency = 200
 	}
 
-	if moonkin.Rotation.UseSmartCooldowns {
+	// The smart cooldown logic is part of the legacy rotation; APL casts them itself.
+	if moonkin.Rotation.UseSmartCooldowns && !moonkin.IsUsingAPL() {
 		moonkin.potionUsed = false
 		consumes := moonkin.Consumes
 
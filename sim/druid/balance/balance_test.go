@@ -27,9 +27,12 @@ func TestBalance(t *testing.T) {
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Default", SpecOptions: PlayerOptionsAdaptive},
 		OtherSpecOptions: []core.SpecOptionsCombo{
-			{Label: "MultidotIs", SpecOptions: PlayerOptionsMultidotIs},
-			{Label: "MultidotMf", SpecOptions: PlayerOptionsMultidotMf},
-			{Label: "MultidotBoth", SpecOptions: PlayerOptionsMultidotBoth},
+			{Label: "MultidotIs", SpecOptions: PlayerOptionsMultidotIs, Rotation: core.GetAplRotation("../../../ui/balance_druid", "multidot_is").Rotation},
+			{Label: "MultidotMf", SpecOptions: PlayerOptionsMultidotMf, Rotation: core.GetAplRotation("../../../ui/balance_druid", "multidot_mf").Rotation},
+			{Label: "MultidotBoth", SpecOptions: PlayerOptionsMultidotBoth, Rotation: core.GetAplRotation("../../../ui/balance_druid", "multidot_both").Rotation},
+		},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/balance_druid", "default"),
 		},
 
 		ItemFilter: core.ItemFilter{
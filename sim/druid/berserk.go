@@ -45,6 +45,7 @@ func (druid *Druid) registerBerserkCD() {
 
 	druid.Berserk = druid.RegisterSpell(core.SpellConfig{
 		ActionID: actionId,
+		Flags:    core.SpellFlagAPL,
 		Cast: core.CastConfig{
 			DefaultCast: core.Cast{
 				GCD: time.Second,
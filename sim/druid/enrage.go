@@ -42,6 +42,7 @@ func (druid *Druid) registerEnrageSpell() {
 
 	spell := druid.RegisterSpell(core.SpellConfig{
 		ActionID: actionID,
+		Flags:    core.SpellFlagAPL,
 
 		Cast: core.CastConfig{
 			CD: core.Cooldown{
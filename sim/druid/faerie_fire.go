@@ -38,7 +38,7 @@ func (druid *Druid) registerFaerieFireSpell() {
 		ActionID:    actionID,
 		SpellSchool: core.SpellSchoolNature,
 		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       flags,
+		Flags:       flags | core.SpellFlagAPL,
 
 		ManaCost: manaCostOptions,
 		Cast: core.CastConfig{
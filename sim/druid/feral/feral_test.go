@@ -27,7 +27,10 @@ func TestFeral(t *testing.T) {
 		SpecOptions: core.SpecOptionsCombo{Label: "Default", SpecOptions: PlayerOptionsMonoCat},
 		OtherSpecOptions: []core.SpecOptionsCombo{
 			{Label: "Default-NoBleed", SpecOptions: PlayerOptionsMonoCatNoBleed},
-			{Label: "Flower-Aoe", SpecOptions: PlayerOptionsFlowerCatAoe},
+			{Label: "Flower-Aoe", SpecOptions: PlayerOptionsFlowerCatAoe, Rotation: core.GetAplRotation("../../../ui/feral_druid", "aoe").Rotation},
+		},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/feral_druid", "default"),
 		},
 
 		ItemFilter: core.ItemFilter{
@@ -52,6 +52,9 @@ func (cat *FeralDruid) OnAutoAttack(sim *core.Simulation, spell *core.Spell) {
 	if cat.InForm(druid.Humanoid) {
 		panic("auto attack out of form?")
 	}
+	if cat.IsUsingAPL() {
+		return
+	}
 
 	// If the swing resulted in an Omen proc, then schedule the
 	// next player decision based on latency.
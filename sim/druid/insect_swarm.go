@@ -39,7 +39,7 @@ func (druid *Druid) registerInsectSwarmSpell() {
 		ActionID:    core.ActionID{SpellID: 48468},
 		SpellSchool: core.SpellSchoolNature,
 		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       SpellFlagOmenTrigger,
+		Flags:       SpellFlagOmenTrigger | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost:   0.08,
@@ -13,7 +13,7 @@ func (druid *Druid) registerMaulSpell(rageThreshold float64) {
 		flatBaseDamage += 120
 	}
 
-	numHits := core.TernaryInt32(druid.HasMajorGlyph(proto.DruidMajorGlyph_GlyphOfMaul) && druid.Env.GetNumTargets() > 1, 2, 1)
+	maxHits := core.TernaryInt32(druid.HasMajorGlyph(proto.DruidMajorGlyph_GlyphOfMaul) && druid.Env.GetNumTargets() > 1, 2, 1)
 
 	druid.Maul = druid.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 48480},
@@ -45,6 +45,7 @@ func (druid *Druid) registerMaulSpell(rageThreshold float64) {
 				modifier *= 1.0 + (0.04 * float64(druid.Talents.RendAndTear))
 			}
 
+			numHits := core.MinInt32(maxHits, int32(len(sim.Encounter.ActiveTargetUnits)))
 			curTarget := target
 			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
 				baseDamage := flatBaseDamage +
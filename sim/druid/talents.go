@@ -426,6 +426,7 @@ func (druid *Druid) applyEclipse() {
 		Label:    "Solar Eclipse proc",
 		Duration: time.Millisecond * 15000,
 		ActionID: core.ActionID{SpellID: 48517},
+		Icd:      &druid.SolarICD,
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
 			druid.Wrath.DamageMultiplier *= solarProcMultiplier
 		},
@@ -468,6 +469,7 @@ func (druid *Druid) applyEclipse() {
 		Label:    "Lunar Eclipse proc",
 		Duration: time.Millisecond * 15000,
 		ActionID: core.ActionID{SpellID: 48518},
+		Icd:      &druid.LunarICD,
 		OnGain: func(aura *core.Aura, sim *core.Simulation) {
 			druid.Starfire.BonusCritRating += lunarBonusCrit
 		},
@@ -11,6 +11,9 @@ func (bear *FeralTankDruid) OnGCDReady(sim *core.Simulation) {
 }
 
 func (bear *FeralTankDruid) OnAutoAttack(sim *core.Simulation, spell *core.Spell) {
+	if bear.IsUsingAPL() {
+		return
+	}
 	bear.tryQueueMaul(sim)
 }
 
@@ -22,6 +22,9 @@ func TestFeralTank(t *testing.T) {
 		Glyphs:      StandardGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Default", SpecOptions: PlayerOptionsDefault},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/feral_tank_druid", "default"),
+		},
 
 		IsTank:          true,
 		InFrontOfTarget: true,
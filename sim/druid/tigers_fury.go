@@ -28,6 +28,7 @@ func (druid *Druid) registerTigersFurySpell() {
 
 	spell := druid.RegisterSpell(core.SpellConfig{
 		ActionID: actionID,
+		Flags:    core.SpellFlagAPL,
 
 		Cast: core.CastConfig{
 			CD: core.Cooldown{
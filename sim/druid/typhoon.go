@@ -16,7 +16,7 @@ func (druid *Druid) registerTyphoonSpell() {
 		ActionID:    core.ActionID{SpellID: 61384},
 		SpellSchool: core.SpellSchoolNature,
 		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       SpellFlagOmenTrigger,
+		Flags:       SpellFlagOmenTrigger | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost:   0.25,
@@ -41,7 +41,7 @@ func (druid *Druid) registerTyphoonSpell() {
 			spell.WaitTravelTime(sim, func(sim *core.Simulation) {
 				baseDamage := 1190 + 0.193*spell.SpellPower()
 				baseDamage *= sim.Encounter.AOECapMultiplier()
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
 				}
 			})
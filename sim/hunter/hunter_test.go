@@ -23,6 +23,9 @@ func TestBM(t *testing.T) {
 		Glyphs:      BMGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "BM", SpecOptions: PlayerOptionsBM},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../ui/hunter", "bm"),
+		},
 
 		ItemFilter: ItemFilter,
 	}))
@@ -39,6 +42,9 @@ func TestMM(t *testing.T) {
 		Glyphs:      MMGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "MM", SpecOptions: PlayerOptionsMM},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../ui/hunter", "mm"),
+		},
 
 		ItemFilter: ItemFilter,
 	}))
@@ -80,6 +86,23 @@ func TestAPL(t *testing.T) {
 	}))
 }
 
+func TestSVAPL(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator(core.CharacterSuiteConfig{
+		Class:      proto.Class_ClassHunter,
+		Race:       proto.Race_RaceOrc,
+		OtherRaces: []proto.Race{proto.Race_RaceDwarf},
+
+		GearSet:     core.GearSetCombo{Label: "P1", GearSet: P1Gear},
+		Talents:     SVTalents,
+		Glyphs:      SVGlyphs,
+		Consumes:    FullConsumes,
+		SpecOptions: core.SpecOptionsCombo{Label: "SV", SpecOptions: PlayerOptionsSV},
+		Rotation:    core.GetAplRotation("../../ui/hunter", "sv"),
+
+		ItemFilter: ItemFilter,
+	}))
+}
+
 var ItemFilter = core.ItemFilter{
 	ArmorType: proto.ArmorType_ArmorTypeMail,
 	WeaponTypes: []proto.WeaponType{
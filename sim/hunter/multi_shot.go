@@ -8,7 +8,7 @@ import (
 )
 
 func (hunter *Hunter) registerMultiShotSpell(timer *core.Timer) {
-	numHits := core.MinInt32(3, hunter.Env.GetNumTargets())
+	maxHits := core.MinInt32(3, hunter.Env.GetNumTargets())
 
 	hunter.MultiShot = hunter.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 49048},
@@ -50,6 +50,7 @@ func (hunter *Hunter) registerMultiShotSpell(timer *core.Timer) {
 				spell.BonusWeaponDamage() +
 				408
 
+			numHits := core.MinInt32(maxHits, int32(len(sim.Encounter.ActiveTargetUnits)))
 			curTarget := target
 			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
 				baseDamage := sharedDmg + 0.2*spell.RangedAttackPower(curTarget)
@@ -8,6 +8,9 @@ import (
 
 func (hunter *Hunter) OnAutoAttack(sim *core.Simulation, spell *core.Spell) {
 	hunter.mayMoveAt = sim.CurrentTime
+	if hunter.IsUsingAPL() {
+		return
+	}
 	hunter.TryUseCooldowns(sim)
 	if hunter.GCD.IsReady(sim) {
 		hunter.rotation(sim)
@@ -13,7 +13,7 @@ func (mage *Mage) registerBlizzardSpell() {
 		ActionID:    core.ActionID{SpellID: 42939},
 		SpellSchool: core.SpellSchoolFrost,
 		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       SpellFlagMage | core.SpellFlagChanneled,
+		Flags:       SpellFlagMage | core.SpellFlagChanneled | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.74,
@@ -43,10 +43,10 @@ func (mage *Mage) registerBlizzardSpell() {
 				dot.SnapshotAttackerMultiplier = dot.Spell.AttackerDamageMultiplier(dot.Spell.Unit.AttackTables[target.UnitIndex])
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-				for i, aoeTarget := range sim.Encounter.TargetUnits {
+				for i, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					results[i] = dot.CalcSnapshotDamage(sim, aoeTarget, dot.OutcomeTick)
 				}
-				for i := range sim.Encounter.TargetUnits {
+				for i := range sim.Encounter.ActiveTargetUnits {
 					dot.Spell.DealPeriodicDamage(sim, results[i])
 				}
 			},
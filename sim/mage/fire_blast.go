@@ -11,7 +11,7 @@ func (mage *Mage) registerFireBlastSpell() {
 		ActionID:    core.ActionID{SpellID: 42873},
 		SpellSchool: core.SpellSchoolFire,
 		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       SpellFlagMage | HotStreakSpells,
+		Flags:       SpellFlagMage | HotStreakSpells | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.21,
@@ -23,7 +23,10 @@ func TestArcane(t *testing.T) {
 		Consumes:    FullArcaneConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "ArcaneRotation", SpecOptions: PlayerOptionsArcane},
 		OtherSpecOptions: []core.SpecOptionsCombo{
-			{Label: "AOE", SpecOptions: PlayerOptionsArcaneAOE},
+			{Label: "AOE", SpecOptions: PlayerOptionsArcaneAOE, Rotation: core.GetAplRotation("../../ui/mage", "arcane_explosion").Rotation},
+		},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../ui/mage", "arcane"),
 		},
 
 		ItemFilter: core.ItemFilter{
@@ -52,7 +55,10 @@ func TestFire(t *testing.T) {
 		Consumes:    FullFireConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "FireRotation", SpecOptions: PlayerOptionsFire},
 		OtherSpecOptions: []core.SpecOptionsCombo{
-			{Label: "AOE", SpecOptions: PlayerOptionsFireAOE},
+			{Label: "AOE", SpecOptions: PlayerOptionsFireAOE, Rotation: core.GetAplRotation("../../ui/mage", "flamestrike").Rotation},
+		},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../ui/mage", "fire"),
 		},
 
 		ItemFilter: core.ItemFilter{
@@ -80,6 +86,9 @@ func TestFrostFire(t *testing.T) {
 		Glyphs:      FrostFireGlyphs,
 		Consumes:    FullFireConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "FrostFireRotation", SpecOptions: PlayerOptionsFrostFire},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../ui/mage", "frostfire"),
+		},
 
 		ItemFilter: core.ItemFilter{
 			WeaponTypes: []proto.WeaponType{
@@ -107,7 +116,10 @@ func TestFrost(t *testing.T) {
 		Consumes:    FullFrostConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "FrostRotation", SpecOptions: PlayerOptionsFrost},
 		OtherSpecOptions: []core.SpecOptionsCombo{
-			{Label: "AOE", SpecOptions: PlayerOptionsFrostAOE},
+			{Label: "AOE", SpecOptions: PlayerOptionsFrostAOE, Rotation: core.GetAplRotation("../../ui/mage", "blizzard").Rotation},
+		},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../ui/mage", "frost"),
 		},
 
 		ItemFilter: core.ItemFilter{
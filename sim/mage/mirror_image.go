@@ -28,6 +28,7 @@ func (mage *Mage) registerMirrorImageCD() {
 
 	mage.MirrorImage = mage.RegisterSpell(core.SpellConfig{
 		ActionID: core.ActionID{SpellID: 55342},
+		Flags:    core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.1,
@@ -38,6 +38,7 @@ func (paladin *Paladin) registerDivinePleaSpell() {
 
 	paladin.DivinePlea = paladin.RegisterSpell(core.SpellConfig{
 		ActionID:    actionID,
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolHoly,
 
 		Cast: core.CastConfig{
@@ -10,14 +10,14 @@ import (
 func (paladin *Paladin) registerDivineStormSpell() {
 	bonusDmg := core.TernaryFloat64(paladin.Equip[proto.ItemSlot_ItemSlotRanged].ID == 45510, 235, 0) + // Libram of Discord
 		core.TernaryFloat64(paladin.Equip[proto.ItemSlot_ItemSlotRanged].ID == 38362, 81, 0) // Venture Co. Libram of Retribution
-	numHits := core.MinInt32(4, paladin.Env.GetNumTargets())
-	results := make([]*core.SpellResult, numHits)
+	maxHits := core.MinInt32(4, paladin.Env.GetNumTargets())
+	results := make([]*core.SpellResult, maxHits)
 
 	paladin.DivineStorm = paladin.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 53385},
 		SpellSchool: core.SpellSchoolPhysical,
 		ProcMask:    core.ProcMaskMeleeMHSpecial,
-		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage,
+		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost:   0.12,
@@ -44,6 +44,7 @@ func (paladin *Paladin) registerDivineStormSpell() {
 		ThreatMultiplier: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			numHits := core.MinInt32(maxHits, int32(len(sim.Encounter.ActiveTargetUnits)))
 			curTarget := target
 			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
 				baseDamage := bonusDmg +
@@ -12,7 +12,7 @@ func (paladin *Paladin) registerExorcismSpell() {
 		ActionID:    core.ActionID{SpellID: 48801},
 		SpellSchool: core.SpellSchoolHoly,
 		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       core.SpellFlagMeleeMetrics,
+		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost:   0.08,
@@ -8,14 +8,14 @@ import (
 )
 
 func (paladin *Paladin) registerHammerOfTheRighteousSpell() {
-	numHits := core.MinInt32(core.TernaryInt32(paladin.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfHammerOfTheRighteous), 4, 3), paladin.Env.GetNumTargets())
-	results := make([]*core.SpellResult, numHits)
+	maxHits := core.MinInt32(core.TernaryInt32(paladin.HasMajorGlyph(proto.PaladinMajorGlyph_GlyphOfHammerOfTheRighteous), 4, 3), paladin.Env.GetNumTargets())
+	results := make([]*core.SpellResult, maxHits)
 
 	paladin.HammerOfTheRighteous = paladin.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 53595},
 		SpellSchool: core.SpellSchoolHoly,
 		ProcMask:    core.ProcMaskMeleeMHSpecial,
-		Flags:       core.SpellFlagMeleeMetrics,
+		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost:   0.06,
@@ -41,6 +41,7 @@ func (paladin *Paladin) registerHammerOfTheRighteousSpell() {
 			speed := spell.Unit.AutoAttacks.MH.SwingSpeed
 			baseDamage := (avgWeaponDamage / speed) * 4
 
+			numHits := core.MinInt32(maxHits, int32(len(sim.Encounter.ActiveTargetUnits)))
 			curTarget := target
 			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
 				results[hitIndex] = spell.CalcDamage(sim, curTarget, baseDamage, spell.OutcomeMeleeSpecialHitAndCrit)
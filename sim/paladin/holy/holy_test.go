@@ -23,6 +23,9 @@ func TestHoly(t *testing.T) {
 		Glyphs:      StandardGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: BasicOptions},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/holy_paladin", "default"),
+		},
 
 		IsHealer:        true,
 		InFrontOfTarget: true,
@@ -54,6 +54,7 @@ func (paladin *Paladin) registerHolyShieldSpell() {
 
 	paladin.HolyShield = paladin.RegisterSpell(core.SpellConfig{
 		ActionID:    actionID,
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolHoly,
 
 		ManaCost: core.ManaCostOptions{
@@ -14,7 +14,7 @@ func (paladin *Paladin) registerHolyWrathSpell() {
 		ActionID:    core.ActionID{SpellID: 48817},
 		SpellSchool: core.SpellSchoolHoly,
 		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       core.SpellFlagMeleeMetrics,
+		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost:   0.20,
@@ -37,7 +37,7 @@ func (paladin *Paladin) registerHolyWrathSpell() {
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			constBaseDamage := .07*spell.SpellPower() + .07*spell.MeleeAttackPower()
 
-			for i, aoeTarget := range sim.Encounter.TargetUnits {
+			for i, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				baseDamage := constBaseDamage + sim.Roll(1050, 1234)
 
 				if aoeTarget.MobType == proto.MobType_MobTypeDemon || aoeTarget.MobType == proto.MobType_MobTypeUndead {
@@ -47,7 +47,7 @@ func (paladin *Paladin) registerHolyWrathSpell() {
 				}
 			}
 
-			for i := range sim.Encounter.TargetUnits {
+			for i := range sim.Encounter.ActiveTargetUnits {
 				spell.DealDamage(sim, results[i])
 			}
 		},
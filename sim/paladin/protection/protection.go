@@ -103,7 +103,7 @@ func (prot *ProtectionPaladin) Reset(sim *core.Simulation) {
 	prot.HolyShield.CD.Timer.Set(time.Second * 7)
 
 	sim.RegisterExecutePhaseCallback(func(sim *core.Simulation, isExecute int) {
-		if isExecute == 20 {
+		if isExecute == 20 && !prot.IsUsingAPL() {
 			prot.OnGCDReady(sim)
 		}
 	})
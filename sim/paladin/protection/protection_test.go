@@ -23,6 +23,9 @@ func TestProtection(t *testing.T) {
 		Glyphs:      StandardGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Protection Paladin SOV", SpecOptions: DefaultOptions},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/protection_paladin", "default"),
+		},
 		OtherSpecOptions: []core.SpecOptionsCombo{
 			{
 				Label: "Protection Paladin SOC",
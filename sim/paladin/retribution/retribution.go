@@ -152,7 +152,7 @@ func (ret *RetributionPaladin) Reset(sim *core.Simulation) {
 	}
 
 	sim.RegisterExecutePhaseCallback(func(sim *core.Simulation, isExecute int) {
-		if isExecute == 20 {
+		if isExecute == 20 && !ret.IsUsingAPL() {
 			ret.OnGCDReady(sim)
 		}
 	})
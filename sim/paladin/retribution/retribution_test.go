@@ -23,6 +23,9 @@ func TestRetribution(t *testing.T) {
 		Glyphs:      StandardGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Retribution Paladin SOV", SpecOptions: DefaultOptions},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/retribution_paladin", "default"),
+		},
 		OtherSpecOptions: []core.SpecOptionsCombo{
 			{
 				Label: "Retribution Paladin SOC",
@@ -9,6 +9,9 @@ import (
 )
 
 func (ret *RetributionPaladin) OnAutoAttack(sim *core.Simulation, spell *core.Spell) {
+	if ret.IsUsingAPL() {
+		return
+	}
 	if ret.SealOfVengeanceAura.IsActive() && core.MinInt32(ret.MaxSoVTargets, ret.Env.GetNumTargets()) > 1 {
 		minVengeanceDotDuration := time.Second * 15
 		var minVengeanceDotDurationTarget *core.Unit
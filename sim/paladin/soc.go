@@ -24,8 +24,8 @@ func (paladin *Paladin) registerSealOfCommandSpellAndAura() {
 	 *   - CAN MISS, BE DODGED/PARRIED/BLOCKED.
 	 */
 
-	numHits := core.MinInt32(3, paladin.Env.GetNumTargets()) // primary target + 2 others
-	results := make([]*core.SpellResult, numHits)
+	maxHits := core.MinInt32(3, paladin.Env.GetNumTargets()) // primary target + 2 others
+	results := make([]*core.SpellResult, maxHits)
 
 	onJudgementProc := paladin.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 20467}, // Judgement of Command
@@ -71,6 +71,7 @@ func (paladin *Paladin) registerSealOfCommandSpellAndAura() {
 		ThreatMultiplier: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			numHits := core.MinInt32(maxHits, int32(len(sim.Encounter.ActiveTargetUnits)))
 			curTarget := target
 			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
 				baseDamage := 0 +
@@ -157,6 +158,7 @@ func (paladin *Paladin) registerSealOfCommandSpellAndAura() {
 	aura := paladin.SealOfCommandAura
 	paladin.SealOfCommand = paladin.RegisterSpell(core.SpellConfig{
 		ActionID:    auraActionID, // Seal of Command self buff.
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolHoly,
 		ProcMask:    core.ProcMaskEmpty,
 
@@ -105,6 +105,7 @@ func (paladin *Paladin) registerSealOfRighteousnessSpellAndAura() {
 	aura := paladin.SealOfRighteousnessAura
 	paladin.SealOfRighteousness = paladin.RegisterSpell(core.SpellConfig{
 		ActionID:    auraActionID, // Seal of Righteousness self buff.
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolHoly,
 
 		ManaCost: core.ManaCostOptions{
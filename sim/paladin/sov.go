@@ -203,6 +203,7 @@ func (paladin *Paladin) registerSealOfVengeanceSpellAndAura() {
 	aura := paladin.SealOfVengeanceAura
 	paladin.SealOfVengeance = paladin.RegisterSpell(core.SpellConfig{
 		ActionID:    auraActionID, // Seal of Vengeance self buff.
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolHoly,
 
 		ManaCost: core.ManaCostOptions{
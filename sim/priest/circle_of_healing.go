@@ -19,7 +19,7 @@ func (priest *Priest) registerCircleOfHealingSpell() {
 		ActionID:    core.ActionID{SpellID: 48089},
 		SpellSchool: core.SpellSchoolHoly,
 		ProcMask:    core.ProcMaskSpellHealing,
-		Flags:       core.SpellFlagHelpful,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost:   0.21,
@@ -16,7 +16,7 @@ func (priest *Priest) registerDevouringPlagueSpell() {
 		ActionID:    actionID,
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       core.SpellFlagDisease,
+		Flags:       core.SpellFlagDisease | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost:   0.25,
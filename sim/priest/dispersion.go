@@ -36,6 +36,7 @@ func (priest *Priest) registerDispersionSpell() {
 
 	priest.Dispersion = priest.RegisterSpell(core.SpellConfig{
 		ActionID: core.ActionID{SpellID: 47585},
+		Flags:    core.SpellFlagAPL,
 
 		Cast: core.CastConfig{
 			DefaultCast: core.Cast{
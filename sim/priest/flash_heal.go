@@ -14,7 +14,7 @@ func (priest *Priest) registerFlashHealSpell() {
 		ActionID:    core.ActionID{SpellID: 48071},
 		SpellSchool: core.SpellSchoolHoly,
 		ProcMask:    core.ProcMaskSpellHealing,
-		Flags:       core.SpellFlagHelpful,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.18,
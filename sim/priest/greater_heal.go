@@ -13,7 +13,7 @@ func (priest *Priest) registerGreaterHealSpell() {
 		ActionID:    core.ActionID{SpellID: 48063},
 		SpellSchool: core.SpellSchoolHoly,
 		ProcMask:    core.ProcMaskSpellHealing,
-		Flags:       core.SpellFlagHelpful,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.32,
@@ -23,6 +23,9 @@ func TestDisc(t *testing.T) {
 		Glyphs:      DiscGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Disc", SpecOptions: PlayerOptionsDisc},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/healing_priest", "default"),
+		},
 
 		ItemFilter: core.ItemFilter{
 			WeaponTypes: []proto.WeaponType{
@@ -58,6 +61,9 @@ func TestHoly(t *testing.T) {
 		Glyphs:      HolyGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Holy", SpecOptions: PlayerOptionsHoly},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/healing_priest", "default"),
+		},
 
 		ItemFilter: core.ItemFilter{
 			WeaponTypes: []proto.WeaponType{
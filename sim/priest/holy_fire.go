@@ -12,6 +12,7 @@ func (priest *Priest) RegisterHolyFireSpell(memeDream bool) {
 
 	priest.HolyFire = priest.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 48135},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolHoly,
 		ProcMask:    core.ProcMaskSpellDamage,
 
@@ -17,7 +17,7 @@ func (priest *Priest) RegisterHymnOfHopeCD() {
 
 	hymnOfHopeSpell := priest.RegisterSpell(core.SpellConfig{
 		ActionID: actionID,
-		Flags:    core.SpellFlagHelpful,
+		Flags:    core.SpellFlagHelpful | core.SpellFlagAPL,
 
 		Cast: core.CastConfig{
 			DefaultCast: core.Cast{
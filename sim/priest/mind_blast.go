@@ -18,6 +18,7 @@ func (priest *Priest) registerMindBlastSpell() {
 
 	priest.MindBlast = priest.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 48127},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskSpellDamage,
 
@@ -20,7 +20,7 @@ func (priest *Priest) newMindSearSpell(numTicks int32) *core.Spell {
 		ActionID:    core.ActionID{SpellID: 53023, Tag: numTicks},
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       core.SpellFlagChanneled,
+		Flags:       core.SpellFlagChanneled | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost:   0.28,
@@ -66,7 +66,7 @@ func (priest *Priest) newMindSearSpell(numTicks int32) *core.Spell {
 			},
 		},
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				if aoeTarget != sim.Encounter.TargetUnits[0] {
 
 					result := spell.CalcOutcome(sim, aoeTarget, spell.OutcomeMagicHit)
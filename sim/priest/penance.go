@@ -33,7 +33,7 @@ func (priest *Priest) makePenanceSpell(isHeal bool) *core.Spell {
 		ActionID:    core.ActionID{SpellID: 53007},
 		SpellSchool: core.SpellSchoolHoly,
 		ProcMask:    procMask,
-		Flags:       flags,
+		Flags:       flags | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.16,
@@ -22,7 +22,7 @@ func (priest *Priest) registerPowerInfusionCD() {
 
 	piSpell := priest.RegisterSpell(core.SpellConfig{
 		ActionID: actionID,
-		Flags:    core.SpellFlagHelpful,
+		Flags:    core.SpellFlagHelpful | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.16,
@@ -75,19 +75,19 @@ func (spriest *ShadowPriest) chooseSpellAOE(sim *core.Simulation) (*core.Spell,
 		return spriest.MindSear[5], spriest.CurrentTarget
 	}
 
-	for _, t := range sim.Encounter.TargetUnits {
+	for _, t := range sim.Encounter.ActiveTargetUnits {
 		if spriest.ShadowWordPain.Dot(t).IsActive() && spriest.ShadowWordPain.Dot(t).RemainingDuration(sim).Seconds() < 3 {
 			return spriest.MindFlay[2], t
 		}
 	}
 
-	for _, t := range sim.Encounter.TargetUnits {
+	for _, t := range sim.Encounter.ActiveTargetUnits {
 		if !spriest.VampiricTouch.Dot(t).IsActive() && sim.GetRemainingDuration().Seconds() > 5 {
 			return spriest.VampiricTouch, t
 		}
 	}
 
-	for _, t := range sim.Encounter.TargetUnits {
+	for _, t := range sim.Encounter.ActiveTargetUnits {
 		if !spriest.ShadowWordPain.Dot(t).IsActive() && sim.GetRemainingDuration().Seconds() > 12 && spriest.DevouringPlague.Dot(sim.Encounter.TargetUnits[0]).IsActive() {
 			return spriest.ShadowWordPain, t
 		}
@@ -23,10 +23,13 @@ func TestShadow(t *testing.T) {
 		Glyphs:   DefaultGlyphs,
 		Consumes: FullConsumes,
 
-		SpecOptions: core.SpecOptionsCombo{Label: "Ideal", SpecOptions: PlayerOptionsIdeal},
+		SpecOptions: core.SpecOptionsCombo{Label: "Ideal", SpecOptions: PlayerOptionsIdeal, Rotation: core.GetAplRotation("../../../ui/shadow_priest", "ideal").Rotation},
 		OtherSpecOptions: []core.SpecOptionsCombo{
 			{Label: "Basic", SpecOptions: PlayerOptionsBasic},
-			{Label: "Clipping", SpecOptions: PlayerOptionsClipping},
+			{Label: "Clipping", SpecOptions: PlayerOptionsClipping, Rotation: core.GetAplRotation("../../../ui/shadow_priest", "clipping").Rotation},
+		},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/shadow_priest", "default"),
 		},
 
 		ItemFilter: core.ItemFilter{
@@ -21,6 +21,7 @@ func (priest *Priest) registerShadowWordPainSpell() {
 
 	priest.ShadowWordPain = priest.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 48125},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskSpellDamage,
 
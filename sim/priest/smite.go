@@ -9,6 +9,7 @@ import (
 func (priest *Priest) RegisterSmiteSpell(memeDream bool) {
 	priest.Smite = priest.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 48123},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolHoly,
 		ProcMask:    core.ProcMaskSpellDamage,
 
@@ -22,6 +22,9 @@ func TestSmite(t *testing.T) {
 		Glyphs:      DefaultGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsBasic},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/smite_priest", "default"),
+		},
 
 		ItemFilter: core.ItemFilter{
 			WeaponTypes: []proto.WeaponType{
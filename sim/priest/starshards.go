@@ -9,6 +9,7 @@ import (
 func (priest *Priest) registerStarshardsSpell() {
 	priest.Starshards = priest.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 25446},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolArcane,
 		ProcMask:    core.ProcMaskSpellDamage,
 
@@ -11,6 +11,7 @@ func (priest *Priest) registerVampiricTouchSpell() {
 
 	priest.VampiricTouch = priest.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 48160},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskSpellDamage,
 
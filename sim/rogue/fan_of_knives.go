@@ -43,7 +43,7 @@ func (rogue *Rogue) registerFanOfKnives() {
 	rogue.FanOfKnives = rogue.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: FanOfKnivesSpellID},
 		SpellSchool: core.SpellSchoolPhysical,
-		Flags:       core.SpellFlagMeleeMetrics,
+		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
 
 		EnergyCost: core.EnergyCostOptions{
 			Cost: 50,
@@ -57,21 +57,21 @@ func (rogue *Rogue) registerFanOfKnives() {
 
 		ApplyEffects: func(sim *core.Simulation, unit *core.Unit, spell *core.Spell) {
 			// Calc and apply all OH hits first, because MH hits can benefit from an OH felstriker proc.
-			for i, aoeTarget := range sim.Encounter.TargetUnits {
+			for i, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				baseDamage := ohSpell.Unit.OHWeaponDamage(sim, ohSpell.MeleeAttackPower())
 				baseDamage *= sim.Encounter.AOECapMultiplier()
 				results[i] = ohSpell.CalcDamage(sim, aoeTarget, baseDamage, ohSpell.OutcomeMeleeWeaponSpecialHitAndCrit)
 			}
-			for i := range sim.Encounter.TargetUnits {
+			for i := range sim.Encounter.ActiveTargetUnits {
 				ohSpell.DealDamage(sim, results[i])
 			}
 
-			for i, aoeTarget := range sim.Encounter.TargetUnits {
+			for i, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				baseDamage := mhSpell.Unit.MHWeaponDamage(sim, mhSpell.MeleeAttackPower())
 				baseDamage *= sim.Encounter.AOECapMultiplier()
 				results[i] = mhSpell.CalcDamage(sim, aoeTarget, baseDamage, mhSpell.OutcomeMeleeWeaponSpecialHitAndCrit)
 			}
-			for i := range sim.Encounter.TargetUnits {
+			for i := range sim.Encounter.ActiveTargetUnits {
 				mhSpell.DealDamage(sim, results[i])
 			}
 		},
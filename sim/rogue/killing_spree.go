@@ -52,11 +52,11 @@ func (rogue *Rogue) registerKillingSpreeSpell() {
 				NumTicks:        5,
 				TickImmediately: true,
 				OnAction: func(s *core.Simulation) {
-					targetCount := sim.GetNumTargets()
+					targets := sim.Encounter.ActiveTargetUnits
 					target := rogue.CurrentTarget
-					if targetCount > 1 {
-						newUnitIndex := int32(math.Ceil(float64(targetCount)*sim.RandomFloat("Killing Spree"))) - 1
-						target = sim.GetTargetUnit(newUnitIndex)
+					if len(targets) > 1 {
+						newIndex := int(math.Ceil(float64(len(targets))*sim.RandomFloat("Killing Spree"))) - 1
+						target = targets[newIndex]
 					}
 					mhWeaponSwing.Cast(sim, target)
 					ohWeaponSwing.Cast(sim, target)
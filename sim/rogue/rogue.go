@@ -189,10 +189,13 @@ func (rogue *Rogue) ApplyEnergyTickMultiplier(multiplier float64) {
 }
 
 func (rogue *Rogue) Reset(sim *core.Simulation) {
-	for _, mcd := range rogue.GetMajorCooldowns() {
-		mcd.Disable()
+	// The legacy rotations enable cooldowns themselves once the opener is done.
+	if !rogue.IsUsingAPL() {
+		for _, mcd := range rogue.GetMajorCooldowns() {
+			mcd.Disable()
+		}
+		rogue.allMCDsDisabled = true
 	}
-	rogue.allMCDsDisabled = true
 
 	// Stealth triggered effects (Overkill and Master of Subtlety) pre-pull activation
 	if rogue.Rotation.OpenWithGarrote || rogue.Options.StartingOverkillDuration > 0 {
@@ -27,6 +27,9 @@ func TestCombat(t *testing.T) {
 			{Label: "MH Instant OH Instant", SpecOptions: PlayerOptionsCombatII},
 			{Label: "MH Deadly OH Deadly", SpecOptions: PlayerOptionsCombatDD},
 		},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../ui/rogue", "combat"),
+		},
 		ItemFilter: core.ItemFilter{
 			ArmorType: proto.ArmorType_ArmorTypeLeather,
 			RangedWeaponTypes: []proto.RangedWeaponType{
@@ -53,6 +56,9 @@ func TestAssassination(t *testing.T) {
 			{Label: "MH Instant OH Instant", SpecOptions: PlayerOptionsAssassinationII},
 			{Label: "MH Deadly OH Deadly", SpecOptions: PlayerOptionsAssassinationDD},
 		},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../ui/rogue", "assassination"),
+		},
 		ItemFilter: core.ItemFilter{
 			ArmorType: proto.ArmorType_ArmorTypeLeather,
 			RangedWeaponTypes: []proto.RangedWeaponType{
@@ -78,6 +84,9 @@ func TestSubtlety(t *testing.T) {
 		Glyphs:      SubtletyGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Subtlety", SpecOptions: PlayerOptionsSubtletyID},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../ui/rogue", "subtlety"),
+		},
 		ItemFilter: core.ItemFilter{
 			ArmorType: proto.ArmorType_ArmorTypeLeather,
 			RangedWeaponTypes: []proto.RangedWeaponType{
@@ -26,6 +26,7 @@ func (shaman *Shaman) registerBloodlustCD() {
 
 	bloodlustSpell := shaman.RegisterSpell(core.SpellConfig{
 		ActionID: actionID,
+		Flags:    core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost:   0.26,
@@ -30,7 +30,7 @@ func (shaman *Shaman) newChainLightningSpell(isLightningOverload bool) *core.Spe
 		}
 	}
 
-	numHits := core.MinInt32(core.TernaryInt32(shaman.HasMajorGlyph(proto.ShamanMajorGlyph_GlyphOfChainLightning), 4, 3), shaman.Env.GetNumTargets())
+	maxHits := core.MinInt32(core.TernaryInt32(shaman.HasMajorGlyph(proto.ShamanMajorGlyph_GlyphOfChainLightning), 4, 3), shaman.Env.GetNumTargets())
 	dmgReductionPerBounce := core.TernaryFloat64(shaman.HasSetBonus(ItemSetTidefury, 2), 0.83, 0.7)
 	dmgBonus := shaman.electricSpellBonusDamage(0.5714)
 	spellCoeff := 0.5714 + 0.04*float64(shaman.Talents.Shamanism)
@@ -40,6 +40,7 @@ func (shaman *Shaman) newChainLightningSpell(isLightningOverload bool) *core.Spe
 
 	spellConfig.ApplyEffects = func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 		bounceCoeff := 1.0
+		numHits := core.MinInt32(maxHits, int32(len(sim.Encounter.ActiveTargetUnits)))
 		curTarget := target
 		for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
 			baseDamage := dmgBonus + sim.Roll(973, 1111) + spellCoeff*spell.SpellPower()
@@ -33,7 +33,7 @@ func (shaman *Shaman) newElectricSpellConfig(actionID core.ActionID, baseCost fl
 		ActionID:     actionID,
 		SpellSchool:  core.SpellSchoolNature,
 		ProcMask:     mask,
-		Flags:        SpellFlagElectric | SpellFlagFocusable,
+		Flags:        SpellFlagElectric | SpellFlagFocusable | core.SpellFlagAPL,
 		MetricSplits: 6,
 
 		ManaCost: core.ManaCostOptions{
@@ -61,6 +61,7 @@ func (shaman *Shaman) newElectricSpellConfig(actionID core.ActionID, baseCost fl
 
 	if isLightningOverload {
 		spell.ActionID.Tag = CastTagLightningOverload
+		spell.Flags &^= core.SpellFlagAPL
 		spell.Cast.DefaultCast.CastTime = 0
 		spell.Cast.DefaultCast.GCD = 0
 		spell.Cast.DefaultCast.Cost = 0
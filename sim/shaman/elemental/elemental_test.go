@@ -26,6 +26,9 @@ func TestElemental(t *testing.T) {
 		OtherSpecOptions: []core.SpecOptionsCombo{
 			{Label: "EleFireElemental", SpecOptions: PlayerOptionsAdaptiveFireElemental},
 		},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/elemental_shaman", "default"),
+		},
 
 		ItemFilter: core.ItemFilter{
 			WeaponTypes: []proto.WeaponType{
@@ -27,6 +27,9 @@ func TestEnhancement(t *testing.T) {
 			{Label: "EnhFireElemental", SpecOptions: PlayerOptionsFireElemental},
 			{Label: "EnhItemSwap", SpecOptions: PlayerOptionsItemSwap},
 		},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/enhancement_shaman", "default"),
+		},
 
 		ItemFilter: core.ItemFilter{
 			WeaponTypes: []proto.WeaponType{
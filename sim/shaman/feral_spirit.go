@@ -19,6 +19,7 @@ func (shaman *Shaman) registerFeralSpirit() {
 
 	shaman.FeralSpirit = shaman.RegisterSpell(core.SpellConfig{
 		ActionID: core.ActionID{SpellID: 51533},
+		Flags:    core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.12,
@@ -16,7 +16,7 @@ func (shaman *Shaman) registerFireNovaSpell() {
 		ActionID:    core.ActionID{SpellID: 61657},
 		SpellSchool: core.SpellSchoolFire,
 		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       SpellFlagFocusable,
+		Flags:       SpellFlagFocusable | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.22,
@@ -39,7 +39,7 @@ func (shaman *Shaman) registerFireNovaSpell() {
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			// FIXME: double check spell coefficients
 			dmgFromSP := 0.2142 * spell.SpellPower()
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				baseDamage := sim.Roll(893, 997) + dmgFromSP
 				// TODO: Uncomment this
 				//baseDamage *= sim.Encounter.AOECapMultiplier()
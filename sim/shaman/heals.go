@@ -40,7 +40,7 @@ func (shaman *Shaman) registerLesserHealingWaveSpell() {
 		ActionID:    core.ActionID{SpellID: 49276},
 		SpellSchool: core.SpellSchoolNature,
 		ProcMask:    core.ProcMaskSpellHealing,
-		Flags:       core.SpellFlagHelpful,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.15,
@@ -102,7 +102,7 @@ func (shaman *Shaman) registerRiptideSpell() {
 		ActionID:    core.ActionID{SpellID: 61301},
 		SpellSchool: core.SpellSchoolNature,
 		ProcMask:    core.ProcMaskSpellHealing,
-		Flags:       core.SpellFlagHelpful,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.18,
@@ -191,7 +191,7 @@ func (shaman *Shaman) registerHealingWaveSpell() {
 		ActionID:    core.ActionID{SpellID: 49273},
 		SpellSchool: core.SpellSchoolNature,
 		ProcMask:    core.ProcMaskSpellHealing,
-		Flags:       core.SpellFlagHelpful,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.15,
@@ -259,6 +259,7 @@ func (shaman *Shaman) registerEarthShieldSpell() {
 
 	shaman.EarthShield = shaman.RegisterSpell(core.SpellConfig{
 		ActionID: actionID,
+		Flags:    core.SpellFlagAPL,
 		Cast: core.CastConfig{
 			DefaultCast: core.Cast{
 				GCD: core.GCDDefault,
@@ -323,7 +324,7 @@ func (shaman *Shaman) registerChainHealSpell() {
 		ActionID:    core.ActionID{SpellID: 55459},
 		SpellSchool: core.SpellSchoolNature,
 		ProcMask:    core.ProcMaskSpellHealing,
-		Flags:       core.SpellFlagHelpful,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			FlatCost: 0.19*shaman.BaseMana - manaDiscount,
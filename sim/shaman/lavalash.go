@@ -48,7 +48,7 @@ func (shaman *Shaman) registerLavaLashSpell() {
 		ActionID:    core.ActionID{SpellID: 60103},
 		SpellSchool: core.SpellSchoolFire,
 		ProcMask:    core.ProcMaskMeleeOHSpecial,
-		Flags:       core.SpellFlagMeleeMetrics,
+		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.04,
@@ -22,6 +22,9 @@ func TestRestoration(t *testing.T) {
 		Glyphs:      StandardGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Standard", SpecOptions: PlayerOptionsStandard},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/restoration_shaman", "default"),
+		},
 
 		ItemFilter: core.ItemFilter{
 			WeaponTypes: []proto.WeaponType{
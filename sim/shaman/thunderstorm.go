@@ -22,6 +22,7 @@ func (shaman *Shaman) registerThunderstormSpell() {
 
 	shaman.Thunderstorm = shaman.RegisterSpell(core.SpellConfig{
 		ActionID:    actionID,
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolNature,
 		ProcMask:    core.ProcMaskSpellDamage,
 
@@ -46,7 +47,7 @@ func (shaman *Shaman) registerThunderstormSpell() {
 
 			if shaman.thunderstormInRange {
 				dmgFromSP := 0.172 * spell.SpellPower()
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					baseDamage := sim.Roll(1450, 1656) + dmgFromSP
 					baseDamage *= sim.Encounter.AOECapMultiplier()
 					spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMagicHitAndCrit)
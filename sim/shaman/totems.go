@@ -10,7 +10,7 @@ import (
 func (shaman *Shaman) newTotemSpellConfig(baseCost float64, spellID int32) core.SpellConfig {
 	return core.SpellConfig{
 		ActionID: core.ActionID{SpellID: spellID},
-		Flags:    SpellFlagTotem,
+		Flags:    SpellFlagTotem | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: baseCost,
@@ -90,7 +90,7 @@ func (shaman *Shaman) registerTotemOfWrathSpell() {
 }
 
 func (shaman *Shaman) applyToWDebuff(sim *core.Simulation) {
-	for _, target := range sim.Encounter.TargetUnits {
+	for _, target := range sim.Encounter.ActiveTargetUnits {
 		auraDef := core.TotemOfWrathDebuff(target)
 		auraDef.Activate(sim)
 	}
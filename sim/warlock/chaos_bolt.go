@@ -15,6 +15,7 @@ func (warlock *Warlock) registerChaosBoltSpell() {
 	//  or add an unlimited amount of "bonusSpellPenetration".
 	warlock.ChaosBolt = warlock.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 59172},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolFire,
 		ProcMask:    core.ProcMaskSpellDamage,
 
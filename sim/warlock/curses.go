@@ -12,6 +12,7 @@ func (warlock *Warlock) registerCurseOfElementsSpell() {
 
 	warlock.CurseOfElements = warlock.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 47865},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskEmpty,
 
@@ -44,6 +45,7 @@ func (warlock *Warlock) registerCurseOfWeaknessSpell() {
 
 	warlock.CurseOfWeakness = warlock.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 50511},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskEmpty,
 
@@ -83,6 +85,7 @@ func (warlock *Warlock) registerCurseOfTonguesSpell() {
 
 	warlock.CurseOfTongues = warlock.RegisterSpell(core.SpellConfig{
 		ActionID:    actionID,
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskEmpty,
 
@@ -115,7 +118,7 @@ func (warlock *Warlock) registerCurseOfAgonySpell() {
 	warlock.CurseOfAgony = warlock.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 47864},
 		SpellSchool: core.SpellSchoolShadow,
-		Flags:       core.SpellFlagHauntSE,
+		Flags:       core.SpellFlagHauntSE | core.SpellFlagAPL,
 		ProcMask:    core.ProcMaskSpellDamage,
 
 		ManaCost: core.ManaCostOptions{
@@ -167,6 +170,7 @@ func (warlock *Warlock) registerCurseOfAgonySpell() {
 func (warlock *Warlock) registerCurseOfDoomSpell() {
 	warlock.CurseOfDoom = warlock.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 47867},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskSpellDamage,
 
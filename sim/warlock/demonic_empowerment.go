@@ -57,6 +57,7 @@ func (warlock *Warlock) registerDemonicEmpowermentSpell() {
 
 	warlock.DemonicEmpowerment = warlock.RegisterSpell(core.SpellConfig{
 		ActionID: core.ActionID{SpellID: 47193},
+		Flags:    core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost: 0.06,
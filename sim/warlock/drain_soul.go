@@ -36,7 +36,7 @@ func (warlock *Warlock) registerDrainSoulSpell() {
 		ActionID:    core.ActionID{SpellID: 47855},
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskSpellDamage,
-		Flags:       core.SpellFlagChanneled | core.SpellFlagHauntSE,
+		Flags:       core.SpellFlagChanneled | core.SpellFlagHauntSE | core.SpellFlagAPL,
 
 		ManaCost: core.ManaCostOptions{
 			BaseCost:   0.14,
@@ -95,6 +95,20 @@ func (warlock *Warlock) registerDrainSoulSpell() {
 		},
 	})
 
+	// APL rotations can't interrupt the drain themselves, so stop it once any other
+	// GCD spell is cast, mirroring what OnGCDReady does for the legacy rotation.
+	core.MakePermanent(warlock.RegisterAura(core.Aura{
+		Label: "Drain Soul Interrupt",
+		OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+			if !warlock.IsUsingAPL() || spell == warlock.DrainSoul || spell.DefaultCast.GCD == 0 {
+				return
+			}
+			if dot := warlock.DrainSoul.CurDot(); dot.IsActive() && dot.TickCount != 0 {
+				dot.Cancel(sim)
+			}
+		},
+	}))
+
 	warlock.RegisterResetEffect(func(sim *core.Simulation) {
 		sim.RegisterExecutePhaseCallback(func(sim *core.Simulation, isExecute int) {
 			if isExecute == 25 {
@@ -27,6 +27,7 @@ func (warlock *Warlock) registerHauntSpell() {
 
 	warlock.Haunt = warlock.RegisterSpell(core.SpellConfig{
 		ActionID:     actionID,
+		Flags:        core.SpellFlagAPL,
 		SpellSchool:  core.SpellSchoolShadow,
 		ProcMask:     core.ProcMaskSpellDamage,
 		MissileSpeed: 20,
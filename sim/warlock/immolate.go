@@ -17,6 +17,7 @@ func (warlock *Warlock) registerImmolateSpell() {
 
 	warlock.Immolate = warlock.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 47811},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolFire,
 		ProcMask:    core.ProcMaskSpellDamage,
 
@@ -12,6 +12,7 @@ func (warlock *Warlock) registerIncinerateSpell() {
 
 	warlock.Incinerate = warlock.RegisterSpell(core.SpellConfig{
 		ActionID:     core.ActionID{SpellID: 47838},
+		Flags:        core.SpellFlagAPL,
 		SpellSchool:  core.SpellSchoolFire,
 		ProcMask:     core.ProcMaskSpellDamage,
 		MissileSpeed: 24,
@@ -46,7 +46,7 @@ func (warlock *Warlock) registerInfernoSpell() {
 			// TODO: add fire spell damage
 			baseDmg := (200 + 1*spell.SpellPower()) * sim.Encounter.AOECapMultiplier()
 
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				spell.CalcAndDealDamage(sim, aoeTarget, baseDmg, spell.OutcomeMagicHitAndCrit)
 			}
 
@@ -176,7 +176,7 @@ func (infernal *InfernalPet) Initialize() {
 				warlockSP := infernal.owner.Unit.GetStat(stats.SpellPower) - infernal.owner.Unit.GetStat(stats.Spirit)*coef
 				baseDmg := (40 + warlockSP*0.2) * sim.Encounter.AOECapMultiplier()
 
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					dot.Spell.CalcAndDealDamage(sim, aoeTarget, baseDmg, dot.Spell.OutcomeMagicHit)
 				}
 			},
@@ -17,6 +17,7 @@ func (warlock *Warlock) registerLifeTapSpell() {
 
 	warlock.LifeTap = warlock.RegisterSpell(core.SpellConfig{
 		ActionID:    actionID,
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolShadow,
 		ProcMask:    core.ProcMaskSpellDamage,
 
@@ -57,6 +57,7 @@ func (warlock *Warlock) registerMetamorphosisSpell() {
 	warlock.ImmolationAura = warlock.RegisterSpell(core.SpellConfig{
 		// the spellID that deals damage in the combat log is 50590, but we don't use it here
 		ActionID:    core.ActionID{SpellID: 50589},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolFire,
 		ProcMask:    core.ProcMaskEmpty,
 
@@ -89,7 +90,7 @@ func (warlock *Warlock) registerMetamorphosisSpell() {
 			AffectedByCastSpeed: true,
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
 				baseDmg := (251 + 20*11.5 + 0.143*dot.Spell.SpellPower()) * sim.Encounter.AOECapMultiplier()
-				for _, aoeTarget := range sim.Encounter.TargetUnits {
+				for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 					dot.Spell.CalcAndDealDamage(sim, aoeTarget, baseDmg, dot.Spell.OutcomeMagicHit)
 				}
 			},
@@ -8,7 +8,7 @@ import (
 )
 
 func (wp *WarlockPet) registerCleaveSpell() {
-	numHits := core.MinInt32(2, wp.Env.GetNumTargets())
+	maxHits := core.MinInt32(2, wp.Env.GetNumTargets())
 
 	wp.primaryAbility = wp.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 47994},
@@ -37,6 +37,7 @@ func (wp *WarlockPet) registerCleaveSpell() {
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			constBaseDamage := 124 + spell.BonusWeaponDamage()
 
+			numHits := core.MinInt32(maxHits, int32(len(sim.Encounter.ActiveTargetUnits)))
 			curTarget := target
 			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
 				baseDamage := constBaseDamage + spell.Unit.MHWeaponDamage(sim, spell.MeleeAttackPower())
@@ -251,7 +251,7 @@ func (warlock *Warlock) defineRotation() {
 				rem    time.Duration
 			}
 			targets := make([]targetRem, 0, len(sim.Encounter.TargetUnits))
-			for _, target := range sim.Encounter.TargetUnits {
+			for _, target := range sim.Encounter.ActiveTargetUnits {
 				// if there's already an shadowbolt on the way then skip
 				if warlock.corrRefreshList[target.UnitIndex] >= sim.CurrentTime-travel {
 					continue
@@ -412,7 +412,7 @@ func (warlock *Warlock) defineRotation() {
 
 	if len(allUnits) > len(multidotTargets) {
 		acl = aclAppendSimple(acl, warlock.Seed, func(sim *core.Simulation) (bool, *core.Unit, string) {
-			for _, target := range sim.Encounter.TargetUnits {
+			for _, target := range sim.Encounter.ActiveTargetUnits {
 				// avoid mainTarget as we may want to corruption that later
 				if !warlock.Corruption.Dot(target).IsActive() && target != mainTarget {
 					return true, target, ""
@@ -617,7 +617,9 @@ func (warlock *Warlock) getAlternativeAction(sim *core.Simulation, skipIndex int
 	return nextSpell, core.MaxDuration(core.GCDMin, nextSpellTime)
 }
 
-func (warlock *Warlock) OnGCDReady(sim *core.Simulation) {
+// updatePetBonuses refreshes the spell power granted by Demonic Knowledge and
+// accumulates the Demonic Pact uptime metrics.
+func (warlock *Warlock) updatePetBonuses(sim *core.Simulation) {
 	if warlock.Options.Summon != proto.Warlock_Options_NoSummon && warlock.Talents.DemonicKnowledge > 0 {
 		// TODO: investigate a better way of handling this like a "reverse inheritance" for pets.
 		bonus := (warlock.Pet.GetStat(stats.Stamina) + warlock.Pet.GetStat(stats.Intellect)) * (0.04 * float64(warlock.Talents.DemonicKnowledge))
@@ -642,6 +644,10 @@ func (warlock *Warlock) OnGCDReady(sim *core.Simulation) {
 
 		warlock.PreviousTime = sim.CurrentTime
 	}
+}
+
+func (warlock *Warlock) OnGCDReady(sim *core.Simulation) {
+	warlock.updatePetBonuses(sim)
 
 	for _, ac := range warlock.acl {
 		action, target, reason := ac.Condition(sim)
@@ -18,6 +18,7 @@ func (warlock *Warlock) registerShadowBoltSpell() {
 
 	warlock.ShadowBolt = warlock.RegisterSpell(core.SpellConfig{
 		ActionID:     core.ActionID{SpellID: 47809},
+		Flags:        core.SpellFlagAPL,
 		SpellSchool:  core.SpellSchoolShadow,
 		ProcMask:     core.ProcMaskSpellDamage,
 		MissileSpeed: 20,
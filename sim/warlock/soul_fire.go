@@ -9,6 +9,7 @@ import (
 func (warlock *Warlock) registerSoulFireSpell() {
 	warlock.SoulFire = warlock.RegisterSpell(core.SpellConfig{
 		ActionID:     core.ActionID{SpellID: 47825},
+		Flags:        core.SpellFlagAPL,
 		SpellSchool:  core.SpellSchoolFire,
 		ProcMask:     core.ProcMaskSpellDamage,
 		MissileSpeed: 24,
@@ -130,6 +130,18 @@ func (warlock *Warlock) Initialize() {
 
 	warlock.defineRotation()
 
+	// APL rotations bypass OnGCDReady, so refresh the pet bonuses after every cast instead.
+	if warlock.Talents.DemonicKnowledge > 0 || warlock.Talents.DemonicPact > 0 {
+		core.MakePermanent(warlock.RegisterAura(core.Aura{
+			Label: "Pet Bonus Tracker",
+			OnCastComplete: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell) {
+				if warlock.IsUsingAPL() {
+					warlock.updatePetBonuses(sim)
+				}
+			},
+		}))
+	}
+
 	precastSpell := warlock.ShadowBolt
 	if warlock.Rotation.Type == proto.Warlock_Rotation_Destruction {
 		precastSpell = warlock.SoulFire
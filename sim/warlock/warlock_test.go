@@ -24,6 +24,9 @@ func TestAffliction(t *testing.T) {
 		OtherSpecOptions: []core.SpecOptionsCombo{
 			{Label: "AffItemSwap", SpecOptions: afflictionItemSwap},
 		},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../ui/warlock", "affliction"),
+		},
 
 		ItemFilter: ItemFilter,
 	}))
@@ -39,6 +42,9 @@ func TestDemonology(t *testing.T) {
 		Glyphs:      DemonologyGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Demonology Warlock", SpecOptions: DefaultDemonologyWarlock},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../ui/warlock", "demonology"),
+		},
 
 		ItemFilter: ItemFilter,
 	}))
@@ -54,6 +60,9 @@ func TestDestruction(t *testing.T) {
 		Glyphs:      DestructionGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Destruction Warlock", SpecOptions: DefaultDestroWarlock},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../ui/warlock", "destruction"),
+		},
 
 		ItemFilter: ItemFilter,
 	}))
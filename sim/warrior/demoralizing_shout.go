@@ -13,6 +13,7 @@ func (warrior *Warrior) registerDemoralizingShoutSpell() {
 
 	warrior.DemoralizingShout = warrior.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 25203},
+		Flags:       core.SpellFlagAPL,
 		SpellSchool: core.SpellSchoolPhysical,
 		ProcMask:    core.ProcMaskEmpty,
 
@@ -30,7 +31,7 @@ func (warrior *Warrior) registerDemoralizingShoutSpell() {
 		FlatThreatBonus:  63.2,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				result := spell.CalcAndDealOutcome(sim, aoeTarget, spell.OutcomeMagicHit)
 				if result.Landed() {
 					warrior.DemoralizingShoutAuras.Get(aoeTarget).Activate(sim)
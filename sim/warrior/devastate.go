@@ -21,7 +21,7 @@ func (warrior *Warrior) registerDevastateSpell() {
 		ActionID:    core.ActionID{SpellID: 47498},
 		SpellSchool: core.SpellSchoolPhysical,
 		ProcMask:    core.ProcMaskMeleeMHSpecial,
-		Flags:       core.SpellFlagMeleeMetrics,
+		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
 
 		RageCost: core.RageCostOptions{
 			Cost:   15 - float64(warrior.Talents.FocusedRage) - float64(warrior.Talents.Puncture),
@@ -23,6 +23,9 @@ func TestFury(t *testing.T) {
 		GearSet:     core.GearSetCombo{Label: "Fury P1", GearSet: FuryP1Gear},
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsFury},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/warrior", "fury"),
+		},
 
 		ItemFilter: core.ItemFilter{
 			ArmorType: proto.ArmorType_ArmorTypePlate,
@@ -49,6 +52,9 @@ func TestArms(t *testing.T) {
 		GearSet:     core.GearSetCombo{Label: "Arms P1", GearSet: FuryP1Gear},
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsArms},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/warrior", "arms"),
+		},
 
 		ItemFilter: core.ItemFilter{
 			ArmorType: proto.ArmorType_ArmorTypePlate,
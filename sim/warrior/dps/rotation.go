@@ -21,6 +21,9 @@ func (war *DpsWarrior) OnGCDReady(sim *core.Simulation) {
 }
 
 func (war *DpsWarrior) OnAutoAttack(sim *core.Simulation, spell *core.Spell) {
+	if war.IsUsingAPL() {
+		return
+	}
 	war.tryQueueHsCleave(sim)
 }
 
@@ -365,12 +368,8 @@ func (war *DpsWarrior) makeCustomRotation() *common.CustomRotation {
 					return false
 				}
 
-				if (war.ShouldSlam(sim) && war.CurrentRage() >= war.Rotation.SlamRageThreshold || war.ShouldInstantSlam(sim)) &&
-					war.Slam.CanCast(sim, war.CurrentTarget) {
-					war.AutoAttacks.DelayMeleeBy(sim, war.Slam.CurCast.CastTime)
-					return true
-				}
-				return false
+				return (war.ShouldSlam(sim) && war.CurrentRage() >= war.Rotation.SlamRageThreshold || war.ShouldInstantSlam(sim)) &&
+					war.Slam.CanCast(sim, war.CurrentTarget)
 			},
 		},
 
@@ -389,11 +388,7 @@ func (war *DpsWarrior) makeCustomRotation() *common.CustomRotation {
 					return false
 				}
 
-				if war.CurrentRage() >= war.Rotation.SlamRageThreshold && war.Slam.CanCast(sim, war.CurrentTarget) {
-					war.AutoAttacks.DelayMeleeBy(sim, war.Slam.CurCast.CastTime)
-					return true
-				}
-				return false
+				return war.CurrentRage() >= war.Rotation.SlamRageThreshold && war.Slam.CanCast(sim, war.CurrentTarget)
 			},
 		},
 
@@ -51,8 +51,8 @@ func (warrior *Warrior) registerCleaveSpell() {
 	flatDamageBonus := 222 * (1 + 0.4*float64(warrior.Talents.ImprovedCleave))
 
 	targets := core.TernaryInt32(warrior.HasMajorGlyph(proto.WarriorMajorGlyph_GlyphOfCleaving), 3, 2)
-	numHits := core.MinInt32(targets, warrior.Env.GetNumTargets())
-	results := make([]*core.SpellResult, numHits)
+	maxHits := core.MinInt32(targets, warrior.Env.GetNumTargets())
+	results := make([]*core.SpellResult, maxHits)
 
 	warrior.HeroicStrikeOrCleave = warrior.RegisterSpell(core.SpellConfig{
 		ActionID:    core.ActionID{SpellID: 47520},
@@ -71,6 +71,7 @@ func (warrior *Warrior) registerCleaveSpell() {
 		FlatThreatBonus:  225,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			numHits := core.MinInt32(maxHits, int32(len(sim.Encounter.ActiveTargetUnits)))
 			curTarget := target
 			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
 				baseDamage := flatDamageBonus +
@@ -98,12 +99,10 @@ func (warrior *Warrior) QueueHSOrCleave(sim *core.Simulation) {
 		return
 	}
 	warrior.HSOrCleaveQueueAura.Activate(sim)
-	warrior.PseudoStats.DisableDWMissPenalty = true
 }
 
 func (warrior *Warrior) DequeueHSOrCleave(sim *core.Simulation) {
 	warrior.HSOrCleaveQueueAura.Deactivate(sim)
-	warrior.PseudoStats.DisableDWMissPenalty = false
 }
 
 // Returns true if the regular melee swing should be used, false otherwise.
@@ -146,6 +145,12 @@ func (warrior *Warrior) RegisterHSOrCleave(useCleave bool, rageThreshold float64
 		Label:    "HS Queue Aura",
 		ActionID: warrior.HeroicStrikeOrCleave.ActionID,
 		Duration: core.NeverExpires,
+		OnGain: func(aura *core.Aura, sim *core.Simulation) {
+			warrior.PseudoStats.DisableDWMissPenalty = true
+		},
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			warrior.PseudoStats.DisableDWMissPenalty = false
+		},
 	})
 
 	warrior.HSRageThreshold = core.MaxFloat(warrior.HeroicStrikeOrCleave.DefaultCast.Cost, rageThreshold)
@@ -23,6 +23,9 @@ func TestProtectionWarrior(t *testing.T) {
 		Glyphs:      DefaultGlyphs,
 		Consumes:    FullConsumes,
 		SpecOptions: core.SpecOptionsCombo{Label: "Basic", SpecOptions: PlayerOptionsBasic},
+		OtherRotations: []core.RotationCombo{
+			core.GetAplRotation("../../../ui/protection_warrior", "default"),
+		},
 
 		IsTank:          true,
 		InFrontOfTarget: true,
@@ -11,7 +11,7 @@ func (warrior *Warrior) registerSlamSpell() {
 		ActionID:    core.ActionID{SpellID: 47475},
 		SpellSchool: core.SpellSchoolPhysical,
 		ProcMask:    core.ProcMaskMeleeMHSpecial,
-		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage,
+		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage | core.SpellFlagAPL,
 
 		RageCost: core.RageCostOptions{
 			Cost:   15 - float64(warrior.Talents.FocusedRage),
@@ -23,6 +23,10 @@ func (warrior *Warrior) registerSlamSpell() {
 				CastTime: time.Millisecond*1500 - time.Millisecond*500*time.Duration(warrior.Talents.ImprovedSlam),
 			},
 			IgnoreHaste: true,
+			ModifyCast: func(sim *core.Simulation, spell *core.Spell, cast *core.Cast) {
+				// Slam resets the swing timer, so push melee back by the cast time.
+				warrior.AutoAttacks.DelayMeleeBy(sim, cast.CastTime)
+			},
 		},
 
 		BonusCritRating:  core.TernaryFloat64(warrior.HasSetBonus(ItemSetWrynnsBattlegear, 4), 5, 0) * core.CritRatingPerCritChance,
@@ -54,9 +58,5 @@ func (warrior *Warrior) ShouldSlam(sim *core.Simulation) bool {
 }
 
 func (warrior *Warrior) CastSlam(sim *core.Simulation, target *core.Unit) bool {
-	if !warrior.Slam.Cast(sim, target) {
-		return false
-	}
-	warrior.AutoAttacks.DelayMeleeBy(sim, warrior.Slam.CurCast.CastTime)
-	return true
+	return warrior.Slam.Cast(sim, target)
 }
@@ -14,7 +14,7 @@ func (warrior *Warrior) newSunderArmorSpell(isDevastateEffect bool) *core.Spell
 		ActionID:    core.ActionID{SpellID: 47467},
 		SpellSchool: core.SpellSchoolPhysical,
 		ProcMask:    core.ProcMaskMeleeMHSpecial,
-		Flags:       core.SpellFlagMeleeMetrics,
+		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagAPL,
 
 		RageCost: core.RageCostOptions{
 			Cost:   15 - float64(warrior.Talents.FocusedRage) - float64(warrior.Talents.Puncture),
@@ -38,6 +38,7 @@ func (warrior *Warrior) newSunderArmorSpell(isDevastateEffect bool) *core.Spell
 	if isDevastateEffect {
 		config.RageCost = core.RageCostOptions{}
 		config.Cast.DefaultCast.GCD = 0
+		config.Flags &^= core.SpellFlagAPL
 		config.ExtraCastCondition = nil
 
 		// In wrath sunder from devastate generates no threat
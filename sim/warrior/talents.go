@@ -781,8 +781,8 @@ func (warrior *Warrior) RegisterBladestormCD() {
 	}
 
 	actionID := core.ActionID{SpellID: 46924}
-	numHits := core.MinInt32(4, warrior.Env.GetNumTargets())
-	results := make([]*core.SpellResult, numHits)
+	maxHits := core.MinInt32(4, warrior.Env.GetNumTargets())
+	results := make([]*core.SpellResult, maxHits)
 
 	if warrior.AutoAttacks.IsDualWielding {
 		warrior.BladestormOH = warrior.RegisterSpell(core.SpellConfig{
@@ -833,6 +833,7 @@ func (warrior *Warrior) RegisterBladestormCD() {
 				target := warrior.CurrentTarget
 				spell := dot.Spell
 
+				numHits := core.MinInt32(maxHits, int32(len(sim.Encounter.ActiveTargetUnits)))
 				curTarget := target
 				for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
 					baseDamage := 0 +
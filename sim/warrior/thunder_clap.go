@@ -16,7 +16,7 @@ func (warrior *Warrior) registerThunderClapSpell() {
 		ActionID:    core.ActionID{SpellID: 47502},
 		SpellSchool: core.SpellSchoolPhysical,
 		ProcMask:    core.ProcMaskRangedSpecial,
-		Flags:       core.SpellFlagIncludeTargetBonusDamage,
+		Flags:       core.SpellFlagIncludeTargetBonusDamage | core.SpellFlagAPL,
 
 		RageCost: core.RageCostOptions{
 			Cost: 20 -
@@ -48,7 +48,7 @@ func (warrior *Warrior) registerThunderClapSpell() {
 			baseDamage := 300 + 0.12*spell.MeleeAttackPower()
 			baseDamage *= sim.Encounter.AOECapMultiplier()
 
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range sim.Encounter.ActiveTargetUnits {
 				result := spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeRangedHitAndCrit)
 				if result.Landed() {
 					warrior.ThunderClapAuras.Get(aoeTarget).Activate(sim)
@@ -9,8 +9,8 @@ import (
 
 func (warrior *Warrior) registerWhirlwindSpell() {
 	actionID := core.ActionID{SpellID: 1680}
-	numHits := core.MinInt32(4, warrior.Env.GetNumTargets())
-	results := make([]*core.SpellResult, numHits)
+	maxHits := core.MinInt32(4, warrior.Env.GetNumTargets())
+	results := make([]*core.SpellResult, maxHits)
 
 	if warrior.AutoAttacks.IsDualWielding && warrior.GetOHWeapon().WeaponType != proto.WeaponType_WeaponTypeStaff &&
 		warrior.GetOHWeapon().WeaponType != proto.WeaponType_WeaponTypePolearm {
@@ -32,7 +32,7 @@ func (warrior *Warrior) registerWhirlwindSpell() {
 		ActionID:    core.ActionID{SpellID: 1680},
 		SpellSchool: core.SpellSchoolPhysical,
 		ProcMask:    core.ProcMaskMeleeMHSpecial,
-		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage | SpellFlagBloodsurge,
+		Flags:       core.SpellFlagMeleeMetrics | core.SpellFlagIncludeTargetBonusDamage | SpellFlagBloodsurge | core.SpellFlagAPL,
 
 		RageCost: core.RageCostOptions{
 			Cost: 25 - float64(warrior.Talents.FocusedRage),
@@ -54,6 +54,7 @@ func (warrior *Warrior) registerWhirlwindSpell() {
 		ThreatMultiplier: 1.25,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			numHits := core.MinInt32(maxHits, int32(len(sim.Encounter.ActiveTargetUnits)))
 			curTarget := target
 			for hitIndex := int32(0); hitIndex < numHits; hitIndex++ {
 				baseDamage := 0 +
@@ -165,7 +165,7 @@ func raidSimAsync(this js.Value, args []js.Value) interface{} {
 	}
 	reporter := make(chan *proto.ProgressMetrics, 100)
 
-	go core.RunRaidSimAsync(rsr, reporter)
+	go core.RunRaidSimAsync(context.Background(), rsr, reporter)
 	return processAsyncProgress(args[1], reporter)
 }
 
@@ -196,7 +196,7 @@ func statWeightsAsync(this js.Value, args []js.Value) interface{} {
 		return nil
 	}
 	reporter := make(chan *proto.ProgressMetrics, 100)
-	core.StatWeightsAsync(rsr, reporter)
+	core.StatWeightsAsync(context.Background(), rsr, reporter)
 
 	result := processAsyncProgress(args[1], reporter)
 	return result
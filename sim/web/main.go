@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -26,6 +27,7 @@ import (
 	"github.com/wowsims/wotlk/sim/core"
 	proto "github.com/wowsims/wotlk/sim/core/proto"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	googleProto "google.golang.org/protobuf/proto"
 )
 
@@ -103,19 +105,24 @@ var handlers = map[string]apiHandler{
 }
 
 var asyncAPIHandlers = map[string]asyncAPIHandler{
-	"/raidSimAsync": {msg: func() googleProto.Message { return &proto.RaidSimRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
-		core.RunRaidSimAsync(msg.(*proto.RaidSimRequest), reporter)
+	"/raidSimAsync": {msg: func() googleProto.Message { return &proto.RaidSimRequest{} }, handle: func(ctx context.Context, msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.RunRaidSimAsync(ctx, msg.(*proto.RaidSimRequest), reporter)
 	}},
-	"/statWeightsAsync": {msg: func() googleProto.Message { return &proto.StatWeightsRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
-		core.StatWeightsAsync(msg.(*proto.StatWeightsRequest), reporter)
+	"/statWeightsAsync": {msg: func() googleProto.Message { return &proto.StatWeightsRequest{} }, handle: func(ctx context.Context, msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.StatWeightsAsync(ctx, msg.(*proto.StatWeightsRequest), reporter)
 	}},
-	"/bulkSimAsync": {msg: func() googleProto.Message { return &proto.BulkSimRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
-		// TODO: we can use context's to cancel stuff.
-		// We should have all the async APIs take in context and let it be cancelled via its async ID.
-		core.RunBulkSimAsync(context.Background(), msg.(*proto.BulkSimRequest), reporter)
+	"/bulkSimAsync": {msg: func() googleProto.Message { return &proto.BulkSimRequest{} }, handle: func(ctx context.Context, msg googleProto.Message, reporter chan *proto.ProgressMetrics) {
+		core.RunBulkSimAsync(ctx, msg.(*proto.BulkSimRequest), reporter)
 	}},
 }
 
+// Streaming endpoints push progress as Server-Sent Events, using the same handlers as the async endpoints.
+var streamAPIRoutes = map[string]string{
+	"/stream/raidSim":     "/raidSimAsync",
+	"/stream/statWeights": "/statWeightsAsync",
+	"/stream/bulkSim":     "/bulkSimAsync",
+}
+
 type server struct {
 	progMut         sync.RWMutex
 	asyncProgresses map[string]*asyncProgress
@@ -127,18 +134,20 @@ type apiHandler struct {
 }
 type asyncAPIHandler struct {
 	msg    func() googleProto.Message
-	handle func(googleProto.Message, chan *proto.ProgressMetrics)
+	handle func(context.Context, googleProto.Message, chan *proto.ProgressMetrics)
 }
 
 type asyncProgress struct {
 	id             string
 	latestProgress atomic.Value
+	cancel         context.CancelFunc
 }
 
-func (s *server) addNewSim() *asyncProgress {
+func (s *server) addNewSim(cancel context.CancelFunc) *asyncProgress {
 	newID := uuid.NewV4().String()
 	simProgress := &asyncProgress{
-		id: newID,
+		id:     newID,
+		cancel: cancel,
 	}
 	simProgress.latestProgress.Store(&proto.ProgressMetrics{})
 
@@ -149,6 +158,32 @@ func (s *server) addNewSim() *asyncProgress {
 	return simProgress
 }
 
+// Removes the sim from the progress cache, cancelling it if it is still running.
+func (s *server) removeSim(id string) bool {
+	s.progMut.Lock()
+	progress, ok := s.asyncProgresses[id]
+	delete(s.asyncProgresses, id)
+	s.progMut.Unlock()
+
+	if ok {
+		progress.cancel()
+	}
+	return ok
+}
+
+func isFinalProgress(progMetric *proto.ProgressMetrics) bool {
+	return progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil
+}
+
+// Discards the remaining progress of a cancelled sim, so it doesn't block on a full channel while winding down.
+func drainProgress(reporter chan *proto.ProgressMetrics) {
+	for progMetric := range reporter {
+		if isFinalProgress(progMetric) {
+			return
+		}
+	}
+}
+
 func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -173,10 +208,11 @@ func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 	//  as the simulation advances it will push changes to the channel
 	//  these changes will be consumed by the goroutine below so the asyncProgress endpoint can fetch the results.
 	reporter := make(chan *proto.ProgressMetrics, 100)
-	handler.handle(msg, reporter)
+	ctx, cancel := context.WithCancel(context.Background())
+	handler.handle(ctx, msg, reporter)
 
 	// Generate a new async simulation
-	simProgress := s.addNewSim()
+	simProgress := s.addNewSim(cancel)
 
 	// Now launch a background process that pulls progress reports off the reporter channel
 	// and pushes it into the async progress cache.
@@ -184,17 +220,20 @@ func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 		for {
 			select {
 			case <-time.After(time.Minute * 10):
-				// if we get no progress after 10 minutes, delete the pending sim and exit.
-				s.progMut.Lock()
-				delete(s.asyncProgresses, simProgress.id)
-				s.progMut.Unlock()
+				// if we get no progress after 10 minutes, cancel the pending sim and exit.
+				s.removeSim(simProgress.id)
+				drainProgress(reporter)
+				return
+			case <-ctx.Done():
+				drainProgress(reporter)
 				return
 			case progMetric := <-reporter:
 				if progMetric == nil {
 					return
 				}
 				simProgress.latestProgress.Store(progMetric)
-				if progMetric.FinalRaidResult != nil || progMetric.FinalWeightResult != nil || progMetric.FinalBulkResult != nil {
+				if isFinalProgress(progMetric) {
+					cancel()
 					return
 				}
 			}
@@ -216,6 +255,85 @@ func (s *server) handleAsyncAPI(w http.ResponseWriter, r *http.Request) {
 	w.Write(outbytes)
 }
 
+// handleStreamAPI starts a sim and streams its progress back as Server-Sent Events.
+//
+// The POST body is the same protobuf request as the matching async endpoint,
+// or its JSON form when sent with a JSON Content-Type. The first event is
+// "sim" with the sim ID, which can be passed to DELETE /sims/{id}. Each
+// ProgressMetrics is then sent as a "progress" event, encoded as JSON, or as
+// base64 protobuf when the "format=proto" query parameter is set. The stream
+// ends after the final result, and the sim is cancelled if the client goes away.
+func (s *server) handleStreamAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	handler := asyncAPIHandlers[streamAPIRoutes[r.URL.Path]]
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return
+	}
+	msg := handler.msg()
+	if strings.Contains(r.Header.Get("Content-Type"), "json") {
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, msg)
+	} else {
+		err = googleProto.Unmarshal(body, msg)
+	}
+	if err != nil {
+		log.Printf("Failed to parse request: %s", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	encode := func(progMetric *proto.ProgressMetrics) ([]byte, error) {
+		return protojson.Marshal(progMetric)
+	}
+	if r.URL.Query().Get("format") == "proto" {
+		encode = func(progMetric *proto.ProgressMetrics) ([]byte, error) {
+			outbytes, err := googleProto.Marshal(progMetric)
+			return []byte(base64.StdEncoding.EncodeToString(outbytes)), err
+		}
+	}
+
+	reporter := make(chan *proto.ProgressMetrics, 100)
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	handler.handle(ctx, msg, reporter)
+
+	simProgress := s.addNewSim(cancel)
+	defer s.removeSim(simProgress.id)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprintf(w, "event: sim\ndata: %s\n\n", simProgress.id)
+	flusher.Flush()
+
+	// Keep going after a cancel, since the sim still reports a final (cancelled) result.
+	for progMetric := range reporter {
+		simProgress.latestProgress.Store(progMetric)
+
+		data, err := encode(progMetric)
+		if err != nil {
+			log.Printf("[ERROR] Failed to marshal progress: %s", err.Error())
+			cancel()
+			drainProgress(reporter)
+			return
+		}
+		fmt.Fprintf(w, "event: progress\ndata: %s\n\n", data)
+		flusher.Flush()
+
+		if isFinalProgress(progMetric) {
+			return
+		}
+	}
+}
+
 func (s *server) setupAsyncServer() {
 	// All async handlers here will call the addNewSim, generating a new UUID and cached progress state.
 	for route := range asyncAPIHandlers {
@@ -254,14 +372,29 @@ func (s *server) setupAsyncServer() {
 		}
 
 		// If this was the last result, delete the cache for this simulation.
-		if latest.FinalRaidResult != nil || latest.FinalWeightResult != nil || latest.FinalBulkResult != nil {
-			s.progMut.Lock()
-			delete(s.asyncProgresses, msg.ProgressId)
-			s.progMut.Unlock()
+		if isFinalProgress(latest) {
+			s.removeSim(msg.ProgressId)
 		}
 		w.Header().Add("Content-Type", "application/x-protobuf")
 		w.Write(outbytes)
 	})
+
+	for route := range streamAPIRoutes {
+		http.HandleFunc(route, s.handleStreamAPI)
+	}
+
+	// DELETE /sims/{id} cancels a running async or streaming sim.
+	http.HandleFunc("/sims/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if !s.removeSim(strings.TrimPrefix(r.URL.Path, "/sims/")) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
 }
 
 func (s *server) runServer(useFS bool, host string, launchBrowser bool, simName string, wasm bool, inputReader *bufio.Reader) {
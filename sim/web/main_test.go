@@ -6,12 +6,14 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/wowsims/wotlk/sim/core"
 	"github.com/wowsims/wotlk/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
 	googleProto "google.golang.org/protobuf/proto"
 )
 
@@ -112,3 +114,125 @@ func TestIndividualSim(t *testing.T) {
 
 	log.Printf("RESULT: %#v", rsr)
 }
+
+// Reads a single Server-Sent Event from the stream.
+func readEvent(reader *bufio.Reader) (string, string, error) {
+	var event, data string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return event, data, err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return event, data, nil
+		}
+		if strings.HasPrefix(line, "event: ") {
+			event = strings.TrimPrefix(line, "event: ")
+		} else if strings.HasPrefix(line, "data: ") {
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func streamRaidSim(t *testing.T, iterations int32) (string, *bufio.Reader) {
+	t.Helper()
+	req := &proto.RaidSimRequest{
+		Raid: core.SinglePlayerRaidProto(
+			&proto.Player{
+				Race:      proto.Race_RaceTroll,
+				Class:     proto.Class_ClassShaman,
+				Equipment: &proto.EquipmentSpec{},
+				Spec:      basicSpec,
+			},
+			&proto.PartyBuffs{},
+			&proto.RaidBuffs{},
+			&proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Duration: 120,
+			Targets: []*proto.Target{
+				{},
+			},
+		},
+		SimOptions: &proto.SimOptions{
+			Iterations: iterations,
+			RandomSeed: 1,
+		},
+	}
+
+	msgBytes, err := googleProto.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to encode request: %s", err.Error())
+	}
+	r, err := http.Post("http://localhost:3339/stream/raidSim", "application/x-protobuf", bytes.NewReader(msgBytes))
+	if err != nil {
+		t.Fatalf("Failed to POST request: %s", err.Error())
+	}
+	t.Cleanup(func() { r.Body.Close() })
+
+	reader := bufio.NewReader(r.Body)
+	event, id, err := readEvent(reader)
+	if err != nil || event != "sim" || id == "" {
+		t.Fatalf("Expected sim ID event, got %q %q (%v)", event, id, err)
+	}
+	return id, reader
+}
+
+// Reads progress events until the final one.
+func readFinalProgress(t *testing.T, reader *bufio.Reader) *proto.ProgressMetrics {
+	t.Helper()
+	for {
+		event, data, err := readEvent(reader)
+		if err != nil {
+			t.Fatalf("Stream ended without a final result: %v", err)
+		}
+		if event != "progress" {
+			t.Fatalf("Unexpected event %q", event)
+		}
+		progress := &proto.ProgressMetrics{}
+		if err := protojson.Unmarshal([]byte(data), progress); err != nil {
+			t.Fatalf("Failed to parse progress: %s", err.Error())
+		}
+		if progress.FinalRaidResult != nil {
+			return progress
+		}
+	}
+}
+
+func TestStreamRaidSim(t *testing.T) {
+	_, reader := streamRaidSim(t, 100)
+	result := readFinalProgress(t, reader).FinalRaidResult
+	if result.ErrorResult != "" || result.RaidMetrics.Dps.Avg <= 0 {
+		t.Fatalf("Unexpected result: %v", result)
+	}
+}
+
+func TestStreamRaidSimCancel(t *testing.T) {
+	id, reader := streamRaidSim(t, 100000000)
+
+	req, _ := http.NewRequest(http.MethodDelete, "http://localhost:3339/sims/"+id, nil)
+	r, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to DELETE sim: %s", err.Error())
+	}
+	r.Body.Close()
+	if r.StatusCode != http.StatusNoContent {
+		t.Fatalf("Unexpected DELETE status: %d", r.StatusCode)
+	}
+
+	result := readFinalProgress(t, reader).FinalRaidResult
+	if !result.Cancelled || result.RaidMetrics == nil {
+		t.Fatalf("Expected a partial cancelled result, got: %v", result)
+	}
+}
+
+func TestStreamRaidSimMethod(t *testing.T) {
+	r, err := http.Get("http://localhost:3339/stream/raidSim")
+	if err != nil {
+		t.Fatalf("Failed to GET stream: %s", err.Error())
+	}
+	r.Body.Close()
+	if r.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("Unexpected GET status: %d", r.StatusCode)
+	}
+}